@@ -11,6 +11,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/gosns"
 	"github.com/Admiral-Piett/goaws/app/gosqs"
 	"github.com/Admiral-Piett/goaws/app/router"
 )
@@ -56,6 +57,13 @@ func main() {
 		}
 	}
 
+	if models.CurrentEnvironment.SigningCertFile != "" || models.CurrentEnvironment.SigningKeyFile != "" {
+		err := gosns.LoadSigningKeyPair(models.CurrentEnvironment.SigningCertFile, models.CurrentEnvironment.SigningKeyFile)
+		if err != nil {
+			log.Fatalf("Failed to load SNS signing key pair: %s", err)
+		}
+	}
+
 	r := router.New()
 
 	quit := make(chan bool, 0)
@@ -107,6 +107,22 @@ func LoadYamlConfig(filename string, env string) []string {
 		models.CurrentEnvironment.Port = "4100"
 	}
 
+	for _, queue := range envs[env].Queues {
+		fifoOnly := queue.ContentBasedDeduplication || queue.DeduplicationScope != "" || queue.FifoThroughputLimit != ""
+		if fifoOnly && !utils.HasFIFOQueueName(queue.Name) {
+			log.Errorf("err: ContentBasedDeduplication, DeduplicationScope and FifoThroughputLimit are only supported by FIFO queues - %s", queue.Name)
+			return ports
+		}
+		if err := models.ValidateFifoThroughput(queue.DeduplicationScope, queue.FifoThroughputLimit); err != nil {
+			log.Errorf("err: %s for queue %s", err, queue.Name)
+			return ports
+		}
+		if queue.Policy != "" && !json.Valid([]byte(queue.Policy)) {
+			log.Errorf("err: invalid Policy for queue %s", queue.Name)
+			return ports
+		}
+	}
+
 	models.SyncQueues.Lock()
 	models.SyncTopics.Lock()
 	for _, queue := range envs[env].Queues {
@@ -145,6 +161,11 @@ func LoadYamlConfig(filename string, env string) []string {
 			IsFIFO:                        utils.HasFIFOQueueName(queue.Name),
 			EnableDuplicates:              models.CurrentEnvironment.EnableDuplicates,
 			Duplicates:                    make(map[string]time.Time),
+			Policy:                        queue.Policy,
+			ContentBasedDeduplication:     queue.ContentBasedDeduplication,
+			DeduplicationScope:            queue.DeduplicationScope,
+			FifoThroughputLimit:           queue.FifoThroughputLimit,
+			Tags:                          queue.Tags,
 		}
 	}
 
@@ -164,7 +185,7 @@ func LoadYamlConfig(filename string, env string) []string {
 	for _, topic := range envs[env].Topics {
 		topicArn := "arn:aws:sns:" + models.CurrentEnvironment.Region + ":" + models.CurrentEnvironment.AccountID + ":" + topic.Name
 
-		newTopic := &models.Topic{Name: topic.Name, Arn: topicArn}
+		newTopic := &models.Topic{Name: topic.Name, Arn: topicArn, Tags: topic.Tags, Attributes: models.NewTopicAttributes().AttributesMap()}
 		newTopic.Subscriptions = make([]*models.Subscription, 0, 0)
 
 		for _, subs := range topic.Subscriptions {
@@ -178,12 +199,25 @@ func LoadYamlConfig(filename string, env string) []string {
 			if subs.FilterPolicy != "" {
 				filterPolicy := &models.FilterPolicy{}
 				err = json.Unmarshal([]byte(subs.FilterPolicy), filterPolicy)
+				if err == nil {
+					err = filterPolicy.Validate()
+				}
 				if err != nil {
 					log.Errorf("err: %s", err)
 					return ports
 				}
 				newSub.FilterPolicy = filterPolicy
 			}
+			newSub.FilterPolicyScope = subs.FilterPolicyScope
+			if subs.RedrivePolicy != "" {
+				redrivePolicy := &models.SubscriptionRedrivePolicy{}
+				err = json.Unmarshal([]byte(subs.RedrivePolicy), redrivePolicy)
+				if err != nil {
+					log.Errorf("err: %s", err)
+					return ports
+				}
+				newSub.RedrivePolicy = redrivePolicy
+			}
 
 			newTopic.Subscriptions = append(newTopic.Subscriptions, newSub)
 		}
@@ -197,7 +231,7 @@ func LoadYamlConfig(filename string, env string) []string {
 }
 
 func createHttpSubscription(configSubscription models.EnvSubsciption) *models.Subscription {
-	newSub := &models.Subscription{EndPoint: configSubscription.EndPoint, Protocol: configSubscription.Protocol, TopicArn: configSubscription.TopicArn, Raw: configSubscription.Raw}
+	newSub := &models.Subscription{EndPoint: configSubscription.EndPoint, Protocol: configSubscription.Protocol, TopicArn: configSubscription.TopicArn, Raw: configSubscription.Raw, ConfirmationWasAuthenticated: true}
 	subArn := uuid.NewString()
 	subArn = configSubscription.TopicArn + ":" + subArn
 	newSub.SubscriptionArn = subArn
@@ -220,13 +254,14 @@ func createSqsSubscription(configSubscription models.EnvSubsciption, topicArn st
 			URL:                           queueUrl,
 			ReceiveMessageWaitTimeSeconds: models.CurrentEnvironment.QueueAttributeDefaults.ReceiveMessageWaitTimeSeconds,
 			MaximumMessageSize:            models.CurrentEnvironment.QueueAttributeDefaults.MaximumMessageSize,
+			MessageRetentionPeriod:        models.CurrentEnvironment.QueueAttributeDefaults.MessageRetentionPeriod,
 			IsFIFO:                        utils.HasFIFOQueueName(configSubscription.QueueName),
 			EnableDuplicates:              models.CurrentEnvironment.EnableDuplicates,
 			Duplicates:                    make(map[string]time.Time),
 		}
 	}
 	qArn := models.SyncQueues.Queues[configSubscription.QueueName].Arn
-	newSub := &models.Subscription{EndPoint: qArn, Protocol: "sqs", TopicArn: topicArn, Raw: configSubscription.Raw}
+	newSub := &models.Subscription{EndPoint: qArn, Protocol: "sqs", TopicArn: topicArn, Raw: configSubscription.Raw, ConfirmationWasAuthenticated: true}
 	subArn := uuid.NewString()
 	subArn = topicArn + ":" + subArn
 	newSub.SubscriptionArn = subArn
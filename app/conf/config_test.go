@@ -80,6 +80,25 @@ func TestConfig_QueueAttributes(t *testing.T) {
 	assert.Equal(t, 128, models.SyncQueues.Queues["local-queue2"].MaximumMessageSize)
 	assert.Equal(t, 150, models.SyncQueues.Queues["local-queue2"].VisibilityTimeout)
 	assert.Equal(t, 245600, models.SyncQueues.Queues["local-queue2"].MessageRetentionPeriod)
+	assert.Equal(t, 345600, models.SyncQueues.Queues["local-queue4"].MessageRetentionPeriod)
+	assert.Equal(t, map[string]string{"team": "platform"}, models.SyncQueues.Queues["local-queue2"].Tags)
+	assert.Equal(t, map[string]string{"team": "platform"}, models.SyncTopics.Topics["local-topic1"].Tags)
+	assert.Equal(t, map[string]string{"SignatureVersion": "1", "TracingConfig": "Active"}, models.SyncTopics.Topics["local-topic1"].Attributes)
+	assert.Nil(t, models.SyncQueues.Queues["local-queue1"].Tags)
+	assert.Equal(t, `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"SQS:SendMessage"}]}`, models.SyncQueues.Queues["local-queue3-dlq"].Policy)
+	assert.Equal(t, "", models.SyncQueues.Queues["local-queue1"].Policy)
+}
+
+func TestConfig_EnforcePolicies(t *testing.T) {
+	LoadYamlConfig("./mock-data/mock-config.yaml", "Local")
+
+	assert.True(t, models.CurrentEnvironment.EnforcePolicies)
+	assert.Equal(t, map[string]string{"AKIAOTHERACCOUNT": "arn:aws:iam::200020002000:user/other"}, models.CurrentEnvironment.AccessKeys)
+
+	LoadYamlConfig("./mock-data/mock-config.yaml", "NoQueuesOrTopics")
+
+	assert.False(t, models.CurrentEnvironment.EnforcePolicies)
+	assert.Nil(t, models.CurrentEnvironment.AccessKeys)
 }
 
 func TestConfig_NoQueueAttributeDefaults(t *testing.T) {
@@ -119,6 +138,38 @@ func TestConfig_invalid_config_resorts_to_default_queue_attributes(t *testing.T)
 	assert.Equal(t, 30, models.CurrentEnvironment.QueueAttributeDefaults.VisibilityTimeout)
 }
 
+func TestConfig_ContentBasedDeduplication(t *testing.T) {
+	LoadYamlConfig("./mock-data/mock-config.yaml", "FifoQueues")
+
+	assert.False(t, models.SyncQueues.Queues["fifo-queue1.fifo"].ContentBasedDeduplication)
+	assert.True(t, models.SyncQueues.Queues["fifo-queue2.fifo"].ContentBasedDeduplication)
+}
+
+func TestConfig_ContentBasedDeduplication_standard_queue_loads_nothing(t *testing.T) {
+	models.ResetResources()
+	LoadYamlConfig("./mock-data/mock-config.yaml", "InvalidContentBasedDeduplication")
+
+	_, ok := models.SyncQueues.Queues["standard-queue1"]
+	assert.False(t, ok)
+}
+
+func TestConfig_FifoThroughput(t *testing.T) {
+	LoadYamlConfig("./mock-data/mock-config.yaml", "FifoQueues")
+
+	assert.Equal(t, "", models.SyncQueues.Queues["fifo-queue1.fifo"].DeduplicationScope)
+	assert.Equal(t, "", models.SyncQueues.Queues["fifo-queue1.fifo"].FifoThroughputLimit)
+	assert.Equal(t, "messageGroup", models.SyncQueues.Queues["fifo-queue3.fifo"].DeduplicationScope)
+	assert.Equal(t, "perMessageGroupId", models.SyncQueues.Queues["fifo-queue3.fifo"].FifoThroughputLimit)
+}
+
+func TestConfig_FifoThroughput_invalid_combination_loads_nothing(t *testing.T) {
+	models.ResetResources()
+	LoadYamlConfig("./mock-data/mock-config.yaml", "InvalidFifoThroughputLimit")
+
+	_, ok := models.SyncQueues.Queues["fifo-queue1.fifo"]
+	assert.False(t, ok)
+}
+
 func TestConfig_LoadYamlConfig_finds_default_config(t *testing.T) {
 	expectedQueues := []string{
 		"local-queue1",
@@ -10,6 +10,7 @@ import (
 var QueueName = "new-queue-1"
 var QueueUrl = fmt.Sprintf("%s/%s", BASE_URL, QueueName)
 var DeadLetterQueueName = "dead-letter-queue-1"
+var QueuePolicy = models.PolicyDocument(`{"this-is":"the-policy"}`)
 
 var FullyPopulatedQueue = &models.Queue{
 	Name: QueueName,
@@ -30,7 +31,10 @@ var FullyPopulatedQueue = &models.Queue{
 	DelaySeconds:                  1,
 	MaximumMessageSize:            2,
 	MessageRetentionPeriod:        3,
+	RedriveAllowPolicy:            &models.RedriveAllowPolicy{RedrivePermission: "allowAll"},
+	Policy:                        `{"this-is":"the-policy"}`,
 	Duplicates:                    make(map[string]time.Time),
+	Tags:                          map[string]string{"my": "tag"},
 }
 
 var CreateQueueRequest = models.CreateQueueRequest{
@@ -43,14 +47,14 @@ var QueueAttributes = models.QueueAttributes{
 	DelaySeconds:                  1,
 	MaximumMessageSize:            2,
 	MessageRetentionPeriod:        3,
-	Policy:                        map[string]interface{}{"this-is": "the-policy"}, //IAM Policy
+	Policy:                        &QueuePolicy, //IAM Policy
 	ReceiveMessageWaitTimeSeconds: 4,
 	VisibilityTimeout:             5,
 	//RedrivePolicy: models.RedrivePolicy{
 	//	MaxReceiveCount:     100,
 	//	DeadLetterTargetArn: fmt.Sprintf("arn:aws:sqs:us-east-1:100010001000:%s", DeadLetterQueueName),
 	//},
-	RedriveAllowPolicy: map[string]interface{}{"this-is": "the-redrive-allow-policy"},
+	RedriveAllowPolicy: &models.RedriveAllowPolicy{RedrivePermission: "allowAll"},
 }
 
 var CreateQueueResult = models.CreateQueueResult{
@@ -2,6 +2,7 @@ package gosns
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/Admiral-Piett/goaws/app/interfaces"
 	"github.com/Admiral-Piett/goaws/app/models"
@@ -17,19 +18,26 @@ func ConfirmSubscriptionV1(req *http.Request) (int, interfaces.AbstractResponseB
 		log.Error("Invalid Request - ConfirmSubscriptionV1")
 		return utils.CreateErrorResponseV1("InvalidParameterValue", false)
 	}
-	topicArn := requestBody.TopicArn
-	confirmToken := requestBody.Token
-	var pendingConfirm pendingConfirm
-
-	if pending, ok := TOPIC_DATA[topicArn]; !ok {
+	// Unknown, expired and already used tokens all look the same to the caller.
+	pendingConfirm, ok := takePendingConfirm(requestBody.TopicArn, requestBody.Token)
+	if !ok {
 		return utils.CreateErrorResponseV1("SubscriptionNotFound", false)
-	} else {
-		pendingConfirm = *pending
 	}
 
-	if pendingConfirm.token != confirmToken {
+	models.SyncTopics.Lock()
+	sub := getSubscription(pendingConfirm.subArn)
+	if sub == nil && pendingConfirm.restore != nil {
+		sub = restoreSubscription(pendingConfirm.restore)
+	}
+	if sub == nil {
+		models.SyncTopics.Unlock()
 		return utils.CreateErrorResponseV1("SubscriptionNotFound", false)
 	}
+	sub.PendingConfirmation = false
+	// Only a signed request counts as authenticated, following the SubscribeURL from the endpoint doesn't.
+	sub.ConfirmationWasAuthenticated = req.Header.Get("Authorization") != ""
+	models.SyncTopics.Unlock()
+
 	respStruct := models.ConfirmSubscriptionResponse{
 		Xmlns:    models.BaseXmlns,
 		Result:   models.ConfirmSubscriptionResult{SubscriptionArn: pendingConfirm.subArn},
@@ -37,3 +45,14 @@ func ConfirmSubscriptionV1(req *http.Request) (int, interfaces.AbstractResponseB
 	}
 	return http.StatusOK, respStruct
 }
+
+// restoreSubscription puts a subscription removed by Unsubscribe back on its topic, as long as the topic still exists.
+func restoreSubscription(subscription *models.Subscription) *models.Subscription {
+	arnSegments := strings.Split(subscription.TopicArn, ":")
+	topic, ok := models.SyncTopics.Topics[arnSegments[len(arnSegments)-1]]
+	if !ok {
+		return nil
+	}
+	topic.Subscriptions = append(topic.Subscriptions, subscription)
+	return subscription
+}
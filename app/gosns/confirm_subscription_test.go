@@ -3,6 +3,7 @@ package gosns
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Admiral-Piett/goaws/app/conf"
 	"github.com/Admiral-Piett/goaws/app/interfaces"
@@ -12,17 +13,30 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// addPendingSubscription adds an HTTP subscription waiting on confirmation to unit-topic1 and returns its token.
+func addPendingSubscription(subscriptionArn string) (*models.Subscription, string) {
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := &models.Subscription{
+		TopicArn:            topic.Arn,
+		Protocol:            "http",
+		EndPoint:            "http://localhost/" + subscriptionArn,
+		SubscriptionArn:     subscriptionArn,
+		PendingConfirmation: true,
+	}
+	topic.Subscriptions = append(topic.Subscriptions, sub)
+	return sub, addPendingConfirm(subscriptionArn, topic.Arn)
+}
+
 func TestConfirmSubscriptionV1_Success(t *testing.T) {
 	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
 	defer func() {
 		models.ResetApp()
 		utils.REQUEST_TRANSFORMER = utils.TransformRequest
-		TOPIC_DATA = make(map[string]*pendingConfirm)
 	}()
 
-	topicArn := "test-topic-arn"
-	confirmToken := "test-token"
-	subscriptionArn := "test-sub-arn"
+	topicArn := models.SyncTopics.Topics["unit-topic1"].Arn
+	subscriptionArn := topicArn + ":confirm-success"
+	sub, confirmToken := addPendingSubscription(subscriptionArn)
 
 	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
 		v := resultingStruct.(*models.ConfirmSubscriptionRequest)
@@ -32,18 +46,109 @@ func TestConfirmSubscriptionV1_Success(t *testing.T) {
 		}
 		return true
 	}
-	// set pending subscription
-	TOPIC_DATA[topicArn] = &pendingConfirm{
-		subArn: subscriptionArn,
-		token:  confirmToken,
-	}
 
 	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	r.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=x")
 	code, response := ConfirmSubscriptionV1(r)
 
 	result := response.GetResult().(models.ConfirmSubscriptionResult)
 	assert.Equal(t, http.StatusOK, code)
 	assert.Equal(t, subscriptionArn, result.SubscriptionArn)
+	assert.False(t, sub.PendingConfirmation)
+	assert.True(t, sub.ConfirmationWasAuthenticated)
+
+	// The token is spent
+	code, _ = ConfirmSubscriptionV1(r)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestConfirmSubscriptionV1_Success_unauthenticated(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	topicArn := models.SyncTopics.Topics["unit-topic1"].Arn
+	sub, confirmToken := addPendingSubscription(topicArn + ":confirm-unauthenticated")
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ConfirmSubscriptionRequest)
+		*v = models.ConfirmSubscriptionRequest{
+			TopicArn: topicArn,
+			Token:    confirmToken,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("GET", "/", nil, true)
+	code, _ := ConfirmSubscriptionV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.False(t, sub.PendingConfirmation)
+	assert.False(t, sub.ConfirmationWasAuthenticated)
+}
+
+func TestConfirmSubscriptionV1_Success_multiple_pending_subscriptions(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	topicArn := models.SyncTopics.Topics["unit-topic1"].Arn
+	sub1, token1 := addPendingSubscription(topicArn + ":confirm-multiple-1")
+	sub2, token2 := addPendingSubscription(topicArn + ":confirm-multiple-2")
+
+	confirmToken := token2
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ConfirmSubscriptionRequest)
+		*v = models.ConfirmSubscriptionRequest{
+			TopicArn: topicArn,
+			Token:    confirmToken,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := ConfirmSubscriptionV1(r)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, sub1.PendingConfirmation)
+	assert.False(t, sub2.PendingConfirmation)
+
+	confirmToken = token1
+	code, _ = ConfirmSubscriptionV1(r)
+	assert.Equal(t, http.StatusOK, code)
+	assert.False(t, sub1.PendingConfirmation)
+}
+
+func TestConfirmSubscriptionV1_ExpiredToken(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	confirmationTokenLifetime = -time.Second
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+		confirmationTokenLifetime = 48 * time.Hour
+	}()
+
+	topicArn := models.SyncTopics.Topics["unit-topic1"].Arn
+	sub, confirmToken := addPendingSubscription(topicArn + ":confirm-expired")
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ConfirmSubscriptionRequest)
+		*v = models.ConfirmSubscriptionRequest{
+			TopicArn: topicArn,
+			Token:    confirmToken,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := ConfirmSubscriptionV1(r)
+	result := response.GetResult().(models.ErrorResult)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Contains(t, result.Message, "The specified subscription does not exist for this wsdl version.")
+	assert.True(t, sub.PendingConfirmation)
 }
 
 func TestConfirmSubscriptionV1_NotFoundSubscription(t *testing.T) {
@@ -51,7 +156,6 @@ func TestConfirmSubscriptionV1_NotFoundSubscription(t *testing.T) {
 	defer func() {
 		models.ResetApp()
 		utils.REQUEST_TRANSFORMER = utils.TransformRequest
-		TOPIC_DATA = make(map[string]*pendingConfirm)
 	}()
 
 	topicArn := "test-topic-arn"
@@ -73,17 +177,14 @@ func TestConfirmSubscriptionV1_NotFoundSubscription(t *testing.T) {
 }
 
 func TestConfirmSubscriptionV1_MismatchToken(t *testing.T) {
-
 	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
 	defer func() {
 		models.ResetApp()
 		utils.REQUEST_TRANSFORMER = utils.TransformRequest
-		TOPIC_DATA = make(map[string]*pendingConfirm)
 	}()
 
-	topicArn := "test-topic-arn"
-	confirmToken := "test-token"
-	subscriptionArn := "test-sub-arn"
+	topicArn := models.SyncTopics.Topics["unit-topic1"].Arn
+	sub, _ := addPendingSubscription(topicArn + ":confirm-mismatch")
 
 	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
 		v := resultingStruct.(*models.ConfirmSubscriptionRequest)
@@ -94,17 +195,37 @@ func TestConfirmSubscriptionV1_MismatchToken(t *testing.T) {
 		return true
 	}
 
-	// set dummy subscription
-	TOPIC_DATA[topicArn] = &pendingConfirm{
-		subArn: subscriptionArn,
-		token:  confirmToken,
-	}
-
 	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
 	code, response := ConfirmSubscriptionV1(r)
 	result := response.GetResult().(models.ErrorResult)
 	assert.Equal(t, http.StatusNotFound, code)
 	assert.Contains(t, result.Message, "The specified subscription does not exist for this wsdl version.")
+	assert.True(t, sub.PendingConfirmation)
+}
+
+func TestConfirmSubscriptionV1_MismatchTopic(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	topicArn := models.SyncTopics.Topics["unit-topic1"].Arn
+	sub, confirmToken := addPendingSubscription(topicArn + ":confirm-mismatch-topic")
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ConfirmSubscriptionRequest)
+		*v = models.ConfirmSubscriptionRequest{
+			TopicArn: models.SyncTopics.Topics["unit-topic2"].Arn,
+			Token:    confirmToken,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := ConfirmSubscriptionV1(r)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.True(t, sub.PendingConfirmation)
 }
 
 func TestConfirmSubscriptionV1_TransformerError(t *testing.T) {
@@ -112,7 +233,6 @@ func TestConfirmSubscriptionV1_TransformerError(t *testing.T) {
 	defer func() {
 		models.ResetApp()
 		utils.REQUEST_TRANSFORMER = utils.TransformRequest
-		TOPIC_DATA = make(map[string]*pendingConfirm)
 	}()
 
 	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
@@ -123,3 +243,58 @@ func TestConfirmSubscriptionV1_TransformerError(t *testing.T) {
 	code, _ := ConfirmSubscriptionV1(r)
 	assert.Equal(t, http.StatusBadRequest, code)
 }
+
+func TestConfirmSubscriptionV1_restores_unsubscribed_subscription(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	topic.Subscriptions = []*models.Subscription{}
+	restoreToken := addRestoreConfirm(sub)
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ConfirmSubscriptionRequest)
+		*v = models.ConfirmSubscriptionRequest{
+			TopicArn: topic.Arn,
+			Token:    restoreToken,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("GET", "/", nil, true)
+	code, response := ConfirmSubscriptionV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, sub.SubscriptionArn, response.GetResult().(models.ConfirmSubscriptionResult).SubscriptionArn)
+	assert.Equal(t, []*models.Subscription{sub}, topic.Subscriptions)
+}
+
+func TestConfirmSubscriptionV1_restore_after_topic_deleted(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	restoreToken := addRestoreConfirm(topic.Subscriptions[0])
+	delete(models.SyncTopics.Topics, "unit-topic1")
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ConfirmSubscriptionRequest)
+		*v = models.ConfirmSubscriptionRequest{
+			TopicArn: topic.Arn,
+			Token:    restoreToken,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("GET", "/", nil, true)
+	code, _ := ConfirmSubscriptionV1(r)
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
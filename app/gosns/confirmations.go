@@ -0,0 +1,134 @@
+package gosns
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/google/uuid"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// pendingConfirm is a confirmation token sent to an HTTP/S endpoint, waiting to be handed back to ConfirmSubscription.
+type pendingConfirm struct {
+	subArn    string
+	topicArn  string
+	expiresAt time.Time
+	// restore is the removed subscription an UnsubscribeConfirmation token puts back on its topic.
+	restore *models.Subscription
+}
+
+// pendingConfirms holds the outstanding confirmation tokens, keyed by token, so a topic can have any number of
+// subscriptions waiting on their endpoints at once.
+var pendingConfirms = struct {
+	sync.Mutex
+	byToken map[string]*pendingConfirm
+}{
+	byToken: make(map[string]*pendingConfirm),
+}
+
+// confirmationTokenLifetime is how long an endpoint has to confirm its subscription with the token it was sent.
+var confirmationTokenLifetime = 48 * time.Hour
+
+func addPendingConfirm(subArn string, topicArn string) string {
+	return storePendingConfirm(&pendingConfirm{subArn: subArn, topicArn: topicArn})
+}
+
+// addRestoreConfirm hands out a token that resubscribes a removed subscription when it's confirmed.
+func addRestoreConfirm(subscription *models.Subscription) string {
+	return storePendingConfirm(&pendingConfirm{
+		subArn:   subscription.SubscriptionArn,
+		topicArn: subscription.TopicArn,
+		restore:  subscription,
+	})
+}
+
+func storePendingConfirm(pending *pendingConfirm) string {
+	token := uuid.NewString()
+	pending.expiresAt = time.Now().Add(confirmationTokenLifetime)
+	pendingConfirms.Lock()
+	defer pendingConfirms.Unlock()
+	pendingConfirms.byToken[token] = pending
+	return token
+}
+
+// takePendingConfirm looks up a live token for the topic.  Once a subscription is confirmed, any other tokens it
+// was sent are spent too.
+func takePendingConfirm(topicArn string, token string) (*pendingConfirm, bool) {
+	pendingConfirms.Lock()
+	defer pendingConfirms.Unlock()
+	pending, ok := pendingConfirms.byToken[token]
+	if !ok || pending.topicArn != topicArn {
+		return nil, false
+	}
+	if time.Now().After(pending.expiresAt) {
+		delete(pendingConfirms.byToken, token)
+		return nil, false
+	}
+	for t, p := range pendingConfirms.byToken {
+		if p.subArn == pending.subArn {
+			delete(pendingConfirms.byToken, t)
+		}
+	}
+	return pending, true
+}
+
+func removePendingConfirms(subArn string) {
+	pendingConfirms.Lock()
+	defer pendingConfirms.Unlock()
+	for token, pending := range pendingConfirms.byToken {
+		if pending.subArn == subArn {
+			delete(pendingConfirms.byToken, token)
+		}
+	}
+}
+
+// subscriptionConfirmation is the SubscriptionConfirmation message sent to a new HTTP/S subscription's endpoint.
+func subscriptionConfirmation(topicArn string, token string) models.SNSMessage {
+	message := fmt.Sprintf("You have chosen to subscribe to the topic %s.\nTo confirm the subscription, visit the SubscribeURL included in this message.", topicArn)
+	return confirmationMessage("SubscriptionConfirmation", topicArn, message, token)
+}
+
+// unsubscribeConfirmation tells a removed HTTP/S subscription's endpoint it won't get any more messages.  The
+// SubscribeURL it includes puts the subscription back.
+func unsubscribeConfirmation(topicArn string, subscriptionArn string, token string) models.SNSMessage {
+	message := fmt.Sprintf("You have chosen to deactivate subscription %s.\nTo cancel this operation and restore the subscription, visit the SubscribeURL included in this message.", subscriptionArn)
+	return confirmationMessage("UnsubscribeConfirmation", topicArn, message, token)
+}
+
+// confirmationMessage builds and signs a confirmation up front, so the post made from the background doesn't read
+// the environment or the subscription while requests are changing them.
+func confirmationMessage(msgType string, topicArn string, message string, token string) models.SNSMessage {
+	id := uuid.NewString()
+	snsMSG := models.SNSMessage{
+		Type:             msgType,
+		MessageId:        id,
+		Token:            token,
+		TopicArn:         topicArn,
+		Message:          message,
+		SigningCertURL:   fmt.Sprintf("http://%s:%s/SimpleNotificationService/%s.pem", models.CurrentEnvironment.Host, models.CurrentEnvironment.Port, id),
+		SignatureVersion: topicSignatureVersion(topicArn),
+		SubscribeURL:     fmt.Sprintf("http://%s:%s/?Action=ConfirmSubscription&TopicArn=%s&Token=%s", models.CurrentEnvironment.Host, models.CurrentEnvironment.Port, topicArn, token),
+		Timestamp:        time.Now().UTC().Format(time.RFC3339),
+	}
+	signature, err := signMessage(PrivateKEY, &snsMSG)
+	if err != nil {
+		log.Error("Error signing message")
+	} else {
+		snsMSG.Signature = signature
+	}
+	return snsMSG
+}
+
+// postConfirmation is swapped out in tests so they can wait for, or skip, the post made from the background.
+var postConfirmation = defaultPostConfirmation
+
+func defaultPostConfirmation(endpoint string, subscriptionArn string, msg models.SNSMessage) {
+	// Confirmations are always the full JSON document, raw delivery only applies to notifications.
+	err := callEndpoint(endpoint, subscriptionArn, msg, false)
+	if err != nil {
+		log.Error("Error posting to url ", err)
+	}
+}
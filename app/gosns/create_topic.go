@@ -28,8 +28,14 @@ func CreateTopicV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
 		topicArn = fmt.Sprintf("arn:aws:sns:%s:%s:%s", models.CurrentEnvironment.Region, models.CurrentEnvironment.AccountID, topicName)
 
 		log.Info("Creating Topic:", topicName)
-		topic := &models.Topic{Name: topicName, Arn: topicArn}
+		topic := &models.Topic{Name: topicName, Arn: topicArn, Attributes: requestBody.Attributes.AttributesMap()}
 		topic.Subscriptions = make([]*models.Subscription, 0)
+		if len(requestBody.Tags) > 0 {
+			topic.Tags = make(map[string]string)
+			for _, tag := range requestBody.Tags {
+				topic.Tags[tag.Key] = tag.Value
+			}
+		}
 		models.SyncTopics.Lock()
 		models.SyncTopics.Topics[topicName] = topic
 		models.SyncTopics.Unlock()
@@ -103,3 +103,57 @@ func TestCreateTopicV1_request_transformer_error(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, code)
 }
+
+func TestCreateTopicV1_success_with_tags(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	targetTopicName := "new-topic-1"
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.CreateTopicRequest)
+		*v = models.CreateTopicRequest{
+			Name: targetTopicName,
+			Tags: []models.TopicTag{{Key: "team", Value: "platform"}},
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, _ := CreateTopicV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]string{"team": "platform"}, models.SyncTopics.Topics[targetTopicName].Tags)
+}
+
+func TestCreateTopicV1_success_stores_attributes(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	targetTopicName := "new-topic-1"
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.CreateTopicRequest)
+		*v = *models.NewCreateTopicRequest()
+		v.Name = targetTopicName
+		v.Attributes.DisplayName = "display-name"
+		v.Attributes.DeliveryPolicy = map[string]interface{}{"i-am": "the-policy"}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, _ := CreateTopicV1(r)
+
+	expectedAttributes := map[string]string{
+		"DeliveryPolicy":   `{"i-am":"the-policy"}`,
+		"DisplayName":      "display-name",
+		"SignatureVersion": "1",
+		"TracingConfig":    "Active",
+	}
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, expectedAttributes, models.SyncTopics.Topics[targetTopicName].Attributes)
+}
@@ -0,0 +1,48 @@
+package gosns
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/google/uuid"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// sendToDeadLetterQueue moves a message that couldn't be delivered to the queue named in the subscription's
+// RedrivePolicy, tagged with the same error attributes AWS adds.  It reports whether the message was saved.
+// ref: https://docs.aws.amazon.com/sns/latest/dg/sns-dead-letter-queues.html
+func sendToDeadLetterQueue(subscription *models.Subscription, msg models.SqsMessage, errorCode string, errorMessage string) bool {
+	if subscription.RedrivePolicy == nil {
+		return false
+	}
+	arnSegments := strings.Split(subscription.RedrivePolicy.DeadLetterTargetArn, ":")
+	queueName := arnSegments[len(arnSegments)-1]
+
+	messageAttributes := map[string]models.MessageAttribute{}
+	for name, value := range msg.MessageAttributes {
+		messageAttributes[name] = value
+	}
+	messageAttributes["RequestID"] = models.MessageAttribute{DataType: "String", StringValue: uuid.NewString()}
+	messageAttributes["ErrorCode"] = models.MessageAttribute{DataType: "String", StringValue: errorCode}
+	messageAttributes["ErrorMessage"] = models.MessageAttribute{DataType: "String", StringValue: errorMessage}
+
+	msg.MessageAttributes = messageAttributes
+	msg.MD5OfMessageAttributes = utils.HashAttributes(messageAttributes)
+	msg.Uuid = uuid.NewString()
+	msg.SentTime = time.Now()
+
+	models.SyncQueues.Lock()
+	defer models.SyncQueues.Unlock()
+	queue, ok := models.SyncQueues.Queues[queueName]
+	if !ok {
+		log.Warnf("Dead-letter queue %s for subscription %s does not exist", queueName, subscription.SubscriptionArn)
+		return false
+	}
+	queue.Messages = append(queue.Messages, msg)
+
+	log.Debugf("Moved message to dead-letter queue %s - Subscription: %s, Error: %s", queueName, subscription.SubscriptionArn, errorMessage)
+	return true
+}
@@ -0,0 +1,204 @@
+package gosns
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// maxDeliveryLogEntries caps how many attempts are remembered per subscription.
+const maxDeliveryLogEntries = 100
+
+// DeliveryAttempt records a single attempt at delivering a message to an HTTP/S subscription.
+type DeliveryAttempt struct {
+	MessageId  string
+	Attempt    int
+	StatusCode int
+	Error      string `json:",omitempty"`
+	Delivered  bool
+	Timestamp  time.Time
+}
+
+type deliveryJob struct {
+	subscription *models.Subscription
+	message      models.SNSMessage
+	policy       models.DeliveryPolicy
+}
+
+// deliveryWorker delivers the queued messages of one subscription in order, so a failing endpoint only holds up
+// its own messages.  It goes away once its queue is empty.
+type deliveryWorker struct {
+	jobs          []deliveryJob
+	lastAttemptAt time.Time
+}
+
+var deliveries = struct {
+	sync.Mutex
+	workers map[*models.Subscription]*deliveryWorker
+	log     map[string][]DeliveryAttempt
+}{
+	workers: make(map[*models.Subscription]*deliveryWorker),
+	log:     make(map[string][]DeliveryAttempt),
+}
+
+// deliverySleep is swapped out in tests so the retries don't have to wait out the real delays.
+var deliverySleep = time.Sleep
+
+// enqueueDelivery is swapped out in tests that check what's handed over to be delivered in the background.
+var enqueueDelivery = queueDelivery
+
+// GetDeliveryLog returns the delivery attempts made to a subscription, oldest first.
+func GetDeliveryLog(subscriptionArn string) []DeliveryAttempt {
+	deliveries.Lock()
+	defer deliveries.Unlock()
+	return append([]DeliveryAttempt{}, deliveries.log[subscriptionArn]...)
+}
+
+// resetDeliveries forgets the delivery log and the workers, so tests don't see each other's deliveries.
+func resetDeliveries() {
+	deliveries.Lock()
+	defer deliveries.Unlock()
+	deliveries.workers = make(map[*models.Subscription]*deliveryWorker)
+	deliveries.log = make(map[string][]DeliveryAttempt)
+}
+
+func queueDelivery(job deliveryJob) {
+	deliveries.Lock()
+	defer deliveries.Unlock()
+	worker, ok := deliveries.workers[job.subscription]
+	if !ok {
+		worker = &deliveryWorker{}
+		deliveries.workers[job.subscription] = worker
+		go worker.run(job.subscription)
+	}
+	worker.jobs = append(worker.jobs, job)
+}
+
+func (w *deliveryWorker) run(subscription *models.Subscription) {
+	for {
+		deliveries.Lock()
+		if len(w.jobs) == 0 {
+			delete(deliveries.workers, subscription)
+			deliveries.Unlock()
+			return
+		}
+		job := w.jobs[0]
+		w.jobs = w.jobs[1:]
+		deliveries.Unlock()
+
+		statusCode, err := w.deliver(job)
+		if err != nil {
+			deadLetterDelivery(job, statusCode, err)
+		}
+	}
+}
+
+// deliver makes the first attempt and then retries on the delays laid out by the retry policy, until the endpoint
+// accepts the message or the retries are used up.  It returns the outcome of the last attempt.
+func (w *deliveryWorker) deliver(job deliveryJob) (statusCode int, err error) {
+	delays := []time.Duration{}
+	if job.policy.HealthyRetryPolicy != nil {
+		delays = job.policy.HealthyRetryPolicy.Delays()
+	}
+
+	for attempt := 0; attempt <= len(delays); attempt++ {
+		if attempt > 0 {
+			deliverySleep(delays[attempt-1])
+		}
+		w.throttle(job.policy.ThrottlePolicy)
+
+		statusCode, err = postToEndpoint(job.subscription.EndPoint, job.subscription.SubscriptionArn, job.message, job.subscription.Raw)
+		recordDeliveryAttempt(job.subscription.SubscriptionArn, DeliveryAttempt{
+			MessageId:  job.message.MessageId,
+			Attempt:    attempt + 1,
+			StatusCode: statusCode,
+			Error:      errorString(err),
+			Delivered:  err == nil,
+			Timestamp:  time.Now().UTC(),
+		})
+		if err == nil {
+			return statusCode, nil
+		}
+		log.WithFields(log.Fields{
+			"EndPoint": job.subscription.EndPoint,
+			"ARN":      job.subscription.SubscriptionArn,
+			"attempt":  attempt + 1,
+			"error":    err.Error(),
+		}).Error("Error calling endpoint")
+	}
+	return statusCode, err
+}
+
+// deadLetterDelivery hands a message the endpoint never accepted over to the subscription's dead-letter queue, in
+// the form it would have been posted to the endpoint.
+func deadLetterDelivery(job deliveryJob, statusCode int, err error) {
+	msg := models.SqsMessage{}
+	if job.subscription.Raw {
+		msg.MessageBody = job.message.Message
+		msg.MessageAttributes = job.message.MessageAttributes
+	} else {
+		body, _ := json.Marshal(job.message)
+		msg.MessageBody = string(body)
+	}
+	msg.MD5OfMessageBody = utils.GetMD5Hash(msg.MessageBody)
+
+	errorCode := "EndpointUnavailable"
+	if statusCode != 0 {
+		errorCode = strconv.Itoa(statusCode)
+	}
+	if !sendToDeadLetterQueue(job.subscription, msg, errorCode, err.Error()) {
+		log.WithFields(log.Fields{
+			"EndPoint": job.subscription.EndPoint,
+			"ARN":      job.subscription.SubscriptionArn,
+		}).Warn("Delivery retries exhausted, message discarded")
+	}
+}
+
+// throttle holds off the next attempt so the endpoint never sees more than maxReceivesPerSecond.
+func (w *deliveryWorker) throttle(policy *models.ThrottlePolicy) {
+	if policy != nil && policy.MaxReceivesPerSecond > 0 {
+		interval := time.Second / time.Duration(policy.MaxReceivesPerSecond)
+		if wait := interval - time.Since(w.lastAttemptAt); wait > 0 {
+			deliverySleep(wait)
+		}
+	}
+	w.lastAttemptAt = time.Now()
+}
+
+func recordDeliveryAttempt(subscriptionArn string, attempt DeliveryAttempt) {
+	deliveries.Lock()
+	defer deliveries.Unlock()
+	entries := append(deliveries.log[subscriptionArn], attempt)
+	if len(entries) > maxDeliveryLogEntries {
+		entries = entries[len(entries)-maxDeliveryLogEntries:]
+	}
+	deliveries.log[subscriptionArn] = entries
+}
+
+// topicDeliveryPolicy returns the DeliveryPolicy attribute of the subscription's topic, if it has one.
+func topicDeliveryPolicy(topicArn string) string {
+	arnSegments := strings.Split(topicArn, ":")
+	topicName := arnSegments[len(arnSegments)-1]
+
+	models.SyncTopics.RLock()
+	defer models.SyncTopics.RUnlock()
+	topic, ok := models.SyncTopics.Topics[topicName]
+	if !ok {
+		return ""
+	}
+	return topic.Attributes["DeliveryPolicy"]
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
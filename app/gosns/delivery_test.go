@@ -0,0 +1,247 @@
+package gosns
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_publishHTTP_retries_until_endpoint_succeeds(t *testing.T) {
+	calls := 0
+	subscribedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		resetDeliveries()
+		subscribedServer.Close()
+	}()
+
+	models.SyncTopics.Lock()
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	sub.Protocol = "http"
+	sub.EndPoint = subscribedServer.URL
+	sub.DeliveryPolicy = &models.DeliveryPolicy{
+		HealthyRetryPolicy: &models.RetryPolicy{NumRetries: 5, NumNoDelayRetries: 5},
+	}
+	models.SyncTopics.Unlock()
+
+	request := models.PublishRequest{
+		TopicArn: topic.Arn,
+		Message:  "message",
+	}
+
+	publishHTTP(sub, topic.Arn, &request)
+
+	assert.Eventually(t, func() bool {
+		return len(GetDeliveryLog(sub.SubscriptionArn)) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	deliveryLog := GetDeliveryLog(sub.SubscriptionArn)
+	assert.Equal(t, 3, calls)
+	for i, attempt := range deliveryLog {
+		assert.Equal(t, i+1, attempt.Attempt)
+		assert.Equal(t, deliveryLog[0].MessageId, attempt.MessageId)
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, deliveryLog[0].StatusCode)
+	assert.False(t, deliveryLog[0].Delivered)
+	assert.NotEmpty(t, deliveryLog[0].Error)
+	assert.Equal(t, http.StatusServiceUnavailable, deliveryLog[1].StatusCode)
+	assert.Equal(t, http.StatusOK, deliveryLog[2].StatusCode)
+	assert.True(t, deliveryLog[2].Delivered)
+	assert.Empty(t, deliveryLog[2].Error)
+}
+
+func Test_publishHTTP_gives_up_after_retries_with_policy_delays(t *testing.T) {
+	subscribedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	var sleptMu sync.Mutex
+	slept := []time.Duration{}
+	deliverySleep = func(d time.Duration) {
+		sleptMu.Lock()
+		defer sleptMu.Unlock()
+		slept = append(slept, d)
+	}
+
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		resetDeliveries()
+		subscribedServer.Close()
+		deliverySleep = time.Sleep
+	}()
+
+	models.SyncTopics.Lock()
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	topic.Attributes["DeliveryPolicy"] = `{"http": {"defaultHealthyRetryPolicy": {"numRetries": 4, "numNoDelayRetries": 1, "minDelayTarget": 2, "maxDelayTarget": 8, "backoffFunction": "linear"}}}`
+	sub := topic.Subscriptions[0]
+	sub.Protocol = "http"
+	sub.EndPoint = subscribedServer.URL
+	models.SyncTopics.Unlock()
+
+	request := models.PublishRequest{
+		TopicArn: topic.Arn,
+		Message:  "message",
+	}
+
+	publishHTTP(sub, topic.Arn, &request)
+
+	assert.Eventually(t, func() bool {
+		return len(GetDeliveryLog(sub.SubscriptionArn)) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for _, attempt := range GetDeliveryLog(sub.SubscriptionArn) {
+		assert.Equal(t, http.StatusInternalServerError, attempt.StatusCode)
+		assert.False(t, attempt.Delivered)
+	}
+	sleptMu.Lock()
+	defer sleptMu.Unlock()
+	assert.Equal(t, []time.Duration{0, 4 * time.Second, 6 * time.Second, 8 * time.Second}, slept)
+}
+
+func Test_publishHTTP_moves_undeliverable_message_to_dead_letter_queue(t *testing.T) {
+	subscribedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		resetDeliveries()
+		subscribedServer.Close()
+	}()
+
+	models.SyncTopics.Lock()
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	sub.Protocol = "http"
+	sub.EndPoint = subscribedServer.URL
+	sub.Raw = false
+	sub.DeliveryPolicy = &models.DeliveryPolicy{
+		HealthyRetryPolicy: &models.RetryPolicy{NumRetries: 1, NumNoDelayRetries: 1},
+	}
+	sub.RedrivePolicy = &models.SubscriptionRedrivePolicy{DeadLetterTargetArn: "arn:aws:sqs:region:accountID:dead-letter-queue1"}
+	models.SyncTopics.Unlock()
+
+	request := models.PublishRequest{
+		TopicArn: topic.Arn,
+		Message:  "message",
+	}
+
+	publishHTTP(sub, topic.Arn, &request)
+
+	assert.Eventually(t, func() bool {
+		models.SyncQueues.RLock()
+		defer models.SyncQueues.RUnlock()
+		return len(models.SyncQueues.Queues["dead-letter-queue1"].Messages) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Len(t, GetDeliveryLog(sub.SubscriptionArn), 2)
+	dlqMessage := models.SyncQueues.Queues["dead-letter-queue1"].Messages[0]
+	snsMessage := models.SNSMessage{}
+	err := json.Unmarshal([]byte(dlqMessage.MessageBody), &snsMessage)
+	assert.Nil(t, err)
+	assert.Equal(t, "message", snsMessage.Message)
+	assert.Equal(t, topic.Arn, snsMessage.TopicArn)
+	assert.Equal(t, utils.GetMD5Hash(dlqMessage.MessageBody), dlqMessage.MD5OfMessageBody)
+	assert.Equal(t, "502", dlqMessage.MessageAttributes["ErrorCode"].StringValue)
+	assert.Equal(t, "Response outside of acceptable (200-499) range", dlqMessage.MessageAttributes["ErrorMessage"].StringValue)
+	assert.NotEmpty(t, dlqMessage.MessageAttributes["RequestID"].StringValue)
+}
+
+func Test_publishHTTP_throttles_deliveries(t *testing.T) {
+	subscribedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	var sleptMu sync.Mutex
+	slept := []time.Duration{}
+	deliverySleep = func(d time.Duration) {
+		sleptMu.Lock()
+		defer sleptMu.Unlock()
+		slept = append(slept, d)
+	}
+
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		resetDeliveries()
+		subscribedServer.Close()
+		deliverySleep = time.Sleep
+	}()
+
+	models.SyncTopics.Lock()
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	sub.Protocol = "http"
+	sub.EndPoint = subscribedServer.URL
+	sub.DeliveryPolicy = &models.DeliveryPolicy{
+		ThrottlePolicy: &models.ThrottlePolicy{MaxReceivesPerSecond: 1},
+	}
+	models.SyncTopics.Unlock()
+
+	for i := 0; i < 3; i++ {
+		request := models.PublishRequest{
+			TopicArn: topic.Arn,
+			Message:  "message",
+		}
+		publishHTTP(sub, topic.Arn, &request)
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(GetDeliveryLog(sub.SubscriptionArn)) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The first delivery goes straight out, the others wait for their slot
+	sleptMu.Lock()
+	defer sleptMu.Unlock()
+	assert.Len(t, slept, 2)
+	for _, d := range slept {
+		assert.True(t, d > 0 && d <= time.Second)
+	}
+}
+
+func Test_recordDeliveryAttempt_keeps_latest_entries(t *testing.T) {
+	subscriptionArn := "arn:aws:sns:region:accountID:record-delivery-attempt:sub"
+	defer resetDeliveries()
+
+	for i := 1; i <= maxDeliveryLogEntries+5; i++ {
+		recordDeliveryAttempt(subscriptionArn, DeliveryAttempt{Attempt: i})
+	}
+
+	deliveryLog := GetDeliveryLog(subscriptionArn)
+	assert.Len(t, deliveryLog, maxDeliveryLogEntries)
+	assert.Equal(t, 6, deliveryLog[0].Attempt)
+	assert.Equal(t, maxDeliveryLogEntries+5, deliveryLog[maxDeliveryLogEntries-1].Attempt)
+}
+
+func Test_resetDeliveries_forgets_the_delivery_log(t *testing.T) {
+	subscriptionArn := "arn:aws:sns:region:accountID:reset-deliveries:sub"
+	recordDeliveryAttempt(subscriptionArn, DeliveryAttempt{Attempt: 1})
+
+	resetDeliveries()
+
+	assert.Empty(t, GetDeliveryLog(subscriptionArn))
+}
+
+func Test_GetDeliveryLog_unknown_subscription(t *testing.T) {
+	assert.Empty(t, GetDeliveryLog("garbage"))
+}
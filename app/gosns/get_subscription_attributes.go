@@ -36,9 +36,9 @@ func GetSubscriptionAttributesV1(req *http.Request) (int, interfaces.AbstractRes
 	entries = append(entries, entry)
 	entry = models.SubscriptionAttributeEntry{Key: "Endpoint", Value: sub.EndPoint}
 	entries = append(entries, entry)
-	entry = models.SubscriptionAttributeEntry{Key: "PendingConfirmation", Value: "false"}
+	entry = models.SubscriptionAttributeEntry{Key: "PendingConfirmation", Value: strconv.FormatBool(sub.PendingConfirmation)}
 	entries = append(entries, entry)
-	entry = models.SubscriptionAttributeEntry{Key: "ConfirmationWasAuthenticated", Value: "true"}
+	entry = models.SubscriptionAttributeEntry{Key: "ConfirmationWasAuthenticated", Value: strconv.FormatBool(sub.ConfirmationWasAuthenticated)}
 	entries = append(entries, entry)
 	entry = models.SubscriptionAttributeEntry{Key: "SubscriptionArn", Value: sub.SubscriptionArn}
 	entries = append(entries, entry)
@@ -49,6 +49,29 @@ func GetSubscriptionAttributesV1(req *http.Request) (int, interfaces.AbstractRes
 		filterPolicyBytes, _ := json.Marshal(sub.FilterPolicy)
 		entry = models.SubscriptionAttributeEntry{Key: "FilterPolicy", Value: string(filterPolicyBytes)}
 		entries = append(entries, entry)
+		filterPolicyScope := sub.FilterPolicyScope
+		if filterPolicyScope == "" {
+			filterPolicyScope = models.FilterPolicyScopeMessageAttributes
+		}
+		entry = models.SubscriptionAttributeEntry{Key: "FilterPolicyScope", Value: filterPolicyScope}
+		entries = append(entries, entry)
+	}
+
+	if sub.DeliveryPolicy != nil {
+		deliveryPolicyBytes, _ := json.Marshal(sub.DeliveryPolicy)
+		entry = models.SubscriptionAttributeEntry{Key: "DeliveryPolicy", Value: string(deliveryPolicyBytes)}
+		entries = append(entries, entry)
+	}
+	if sub.RedrivePolicy != nil {
+		redrivePolicyBytes, _ := json.Marshal(sub.RedrivePolicy)
+		entry = models.SubscriptionAttributeEntry{Key: "RedrivePolicy", Value: string(redrivePolicyBytes)}
+		entries = append(entries, entry)
+	}
+	if models.Protocol(sub.Protocol) == models.ProtocolHTTP || models.Protocol(sub.Protocol) == models.ProtocolHTTPS {
+		effectiveDeliveryPolicy := models.ResolveDeliveryPolicy(topicDeliveryPolicy(sub.TopicArn), sub.DeliveryPolicy)
+		effectiveDeliveryPolicyBytes, _ := json.Marshal(effectiveDeliveryPolicy)
+		entry = models.SubscriptionAttributeEntry{Key: "EffectiveDeliveryPolicy", Value: string(effectiveDeliveryPolicyBytes)}
+		entries = append(entries, entry)
 	}
 
 	result := models.GetSubscriptionAttributesResult{Attributes: models.GetSubscriptionAttributes{Entries: entries}}
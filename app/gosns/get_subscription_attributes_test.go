@@ -114,7 +114,87 @@ func TestGetSubscriptionAttributesV1_success(t *testing.T) {
 			Key:   "FilterPolicy",
 			Value: "{\"foo\":[\"bar\"]}",
 		},
+		{
+			Key:   "FilterPolicyScope",
+			Value: "MessageAttributes",
+		},
 	}
 
 	assert.ElementsMatch(t, expectedAttributes, result.Attributes.Entries)
 }
+
+func TestGetSubscriptionAttributesV1_success_http_delivery_and_redrive_policies(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "Local")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	localTopic1 := models.SyncTopics.Topics["local-topic1"]
+	sub := localTopic1.Subscriptions[0]
+	sub.Protocol = "http"
+	sub.EndPoint = "http://localhost:1234/endpoint"
+	sub.DeliveryPolicy = &models.DeliveryPolicy{
+		HealthyRetryPolicy: &models.RetryPolicy{NumRetries: 5, MinDelayTarget: 1, MaxDelayTarget: 10, BackoffFunction: "exponential"},
+	}
+	sub.RedrivePolicy = &models.SubscriptionRedrivePolicy{DeadLetterTargetArn: "arn:aws:sqs:us-east-1:100010001000:local-queue3-dlq"}
+	localTopic1.Attributes["DeliveryPolicy"] = `{"http": {"defaultThrottlePolicy": {"maxReceivesPerSecond": 3}}}`
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.GetSubscriptionAttributesRequest)
+		*v = models.GetSubscriptionAttributesRequest{
+			SubscriptionArn: sub.SubscriptionArn,
+		}
+		return true
+	}
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := GetSubscriptionAttributesV1(r)
+
+	result := response.GetResult().(models.GetSubscriptionAttributesResult)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, result.Attributes.Entries, models.SubscriptionAttributeEntry{
+		Key:   "DeliveryPolicy",
+		Value: `{"healthyRetryPolicy":{"minDelayTarget":1,"maxDelayTarget":10,"numRetries":5,"numNoDelayRetries":0,"numMinDelayRetries":0,"numMaxDelayRetries":0,"backoffFunction":"exponential"}}`,
+	})
+	assert.Contains(t, result.Attributes.Entries, models.SubscriptionAttributeEntry{
+		Key:   "RedrivePolicy",
+		Value: `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:100010001000:local-queue3-dlq"}`,
+	})
+	assert.Contains(t, result.Attributes.Entries, models.SubscriptionAttributeEntry{
+		Key:   "EffectiveDeliveryPolicy",
+		Value: `{"healthyRetryPolicy":{"minDelayTarget":1,"maxDelayTarget":10,"numRetries":5,"numNoDelayRetries":0,"numMinDelayRetries":0,"numMaxDelayRetries":0,"backoffFunction":"exponential"},"throttlePolicy":{"maxReceivesPerSecond":3}}`,
+	})
+}
+
+func TestGetSubscriptionAttributesV1_success_pending_confirmation(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	topic := models.SyncTopics.Topics["unit-topic2"]
+	sub := &models.Subscription{
+		TopicArn:            topic.Arn,
+		Protocol:            "http",
+		EndPoint:            "http://localhost/endpoint",
+		SubscriptionArn:     topic.Arn + ":pending",
+		PendingConfirmation: true,
+	}
+	topic.Subscriptions = append(topic.Subscriptions, sub)
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.GetSubscriptionAttributesRequest)
+		*v = models.GetSubscriptionAttributesRequest{
+			SubscriptionArn: sub.SubscriptionArn,
+		}
+		return true
+	}
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := GetSubscriptionAttributesV1(r)
+
+	result := response.GetResult().(models.GetSubscriptionAttributesResult)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, result.Attributes.Entries, models.SubscriptionAttributeEntry{Key: "PendingConfirmation", Value: "true"})
+	assert.Contains(t, result.Attributes.Entries, models.SubscriptionAttributeEntry{Key: "ConfirmationWasAuthenticated", Value: "false"})
+}
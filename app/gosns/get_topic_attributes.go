@@ -0,0 +1,72 @@
+package gosns
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+func GetTopicAttributesV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewGetTopicAttributesRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - GetTopicAttributesV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+	}
+
+	uriSegments := strings.Split(requestBody.TopicArn, ":")
+	topicName := uriSegments[len(uriSegments)-1]
+
+	models.SyncTopics.RLock()
+	defer models.SyncTopics.RUnlock()
+	topic, ok := models.SyncTopics.Topics[topicName]
+	if !ok {
+		return utils.CreateErrorResponseV1("TopicNotFound", false)
+	}
+
+	// Sort the stored attributes so the responses come back in a stable order
+	names := make([]string, 0, len(topic.Attributes))
+	for name := range topic.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entries := make([]models.TopicAttributeEntry, 0, len(names)+6)
+	for _, name := range names {
+		entries = append(entries, models.TopicAttributeEntry{Key: name, Value: topic.Attributes[name]})
+	}
+
+	pending := 0
+	for _, sub := range topic.Subscriptions {
+		if sub.PendingConfirmation {
+			pending++
+		}
+	}
+	effectiveDeliveryPolicy := models.DefaultEffectiveDeliveryPolicy
+	if deliveryPolicy, ok := topic.Attributes["DeliveryPolicy"]; ok {
+		effectiveDeliveryPolicy = deliveryPolicy
+	}
+
+	entries = append(entries,
+		models.TopicAttributeEntry{Key: "TopicArn", Value: topic.Arn},
+		models.TopicAttributeEntry{Key: "Owner", Value: models.CurrentEnvironment.AccountID},
+		models.TopicAttributeEntry{Key: "SubscriptionsConfirmed", Value: strconv.Itoa(len(topic.Subscriptions) - pending)},
+		models.TopicAttributeEntry{Key: "SubscriptionsPending", Value: strconv.Itoa(pending)},
+		models.TopicAttributeEntry{Key: "SubscriptionsDeleted", Value: strconv.Itoa(topic.SubscriptionsDeleted)},
+		models.TopicAttributeEntry{Key: "EffectiveDeliveryPolicy", Value: effectiveDeliveryPolicy},
+	)
+
+	respStruct := models.GetTopicAttributesResponse{
+		Xmlns:    models.BaseXmlns,
+		Result:   models.GetTopicAttributesResult{Attributes: models.TopicAttributeEntries{Entries: entries}},
+		Metadata: models.ResponseMetadata{RequestId: uuid.NewString()},
+	}
+	return http.StatusOK, respStruct
+}
@@ -0,0 +1,118 @@
+package gosns
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTopicAttributesV1_success(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	topic.Attributes["DisplayName"] = "display-name"
+	topic.SubscriptionsDeleted = 2
+	pendingSub := &models.Subscription{TopicArn: topic.Arn, Protocol: "http", SubscriptionArn: topic.Arn + ":pending", PendingConfirmation: true}
+	topic.Subscriptions = append(topic.Subscriptions, pendingSub)
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.GetTopicAttributesRequest)
+		*v = models.GetTopicAttributesRequest{TopicArn: topic.Arn}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := GetTopicAttributesV1(r)
+
+	response, _ := res.(models.GetTopicAttributesResponse)
+
+	expectedEntries := []models.TopicAttributeEntry{
+		{Key: "DisplayName", Value: "display-name"},
+		{Key: "SignatureVersion", Value: "1"},
+		{Key: "TracingConfig", Value: "Active"},
+		{Key: "TopicArn", Value: topic.Arn},
+		{Key: "Owner", Value: "accountID"},
+		{Key: "SubscriptionsConfirmed", Value: "1"},
+		{Key: "SubscriptionsPending", Value: "1"},
+		{Key: "SubscriptionsDeleted", Value: "2"},
+		{Key: "EffectiveDeliveryPolicy", Value: models.DefaultEffectiveDeliveryPolicy},
+	}
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, models.BaseXmlns, response.Xmlns)
+	assert.Equal(t, expectedEntries, response.Result.Attributes.Entries)
+}
+
+func TestGetTopicAttributesV1_success_effective_delivery_policy_uses_topic_policy(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	topic := models.SyncTopics.Topics["unit-topic2"]
+	deliveryPolicy := `{"http":{"defaultHealthyRetryPolicy":{"numRetries":5}}}`
+	topic.Attributes["DeliveryPolicy"] = deliveryPolicy
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.GetTopicAttributesRequest)
+		*v = models.GetTopicAttributesRequest{TopicArn: topic.Arn}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := GetTopicAttributesV1(r)
+
+	response, _ := res.(models.GetTopicAttributesResponse)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, response.Result.Attributes.Entries, models.TopicAttributeEntry{Key: "EffectiveDeliveryPolicy", Value: deliveryPolicy})
+	assert.Contains(t, response.Result.Attributes.Entries, models.TopicAttributeEntry{Key: "SubscriptionsConfirmed", Value: "0"})
+}
+
+func TestGetTopicAttributesV1_error_topic_not_found(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.GetTopicAttributesRequest)
+		*v = models.GetTopicAttributesRequest{TopicArn: "arn:aws:sns:region:accountID:garbage"}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := GetTopicAttributesV1(r)
+	response := res.(models.ErrorResponse)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "AWS.SimpleNotificationService.NonExistentTopic", response.Result.Code)
+}
+
+func TestGetTopicAttributesV1_request_transformer_error(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := GetTopicAttributesV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
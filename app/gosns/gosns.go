@@ -21,6 +21,8 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/base64"
@@ -31,34 +33,36 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-type pendingConfirm struct {
-	subArn string
-	token  string
-}
-
 var PemKEY []byte
 var PrivateKEY *rsa.PrivateKey
-var TOPIC_DATA map[string]*pendingConfirm
+
+// signingCertLifetime keeps the generated certificate valid for as long as any local server is likely to run, so
+// subscribers can verify signatures against it the same way they would against real SNS.
+const signingCertLifetime = 10 * 365 * 24 * time.Hour
 
 func init() {
 	models.SyncTopics.Topics = make(map[string]*models.Topic)
-	TOPIC_DATA = make(map[string]*pendingConfirm)
 
 	PrivateKEY, PemKEY, _ = createPemFile()
 }
 
 func createPemFile() (privkey *rsa.PrivateKey, pemkey []byte, err error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return
+	}
 	template := &x509.Certificate{
 		IsCA:                  true,
 		BasicConstraintsValid: true,
 		SubjectKeyId:          []byte{11, 22, 33},
-		SerialNumber:          big.NewInt(1111),
+		SerialNumber:          serialNumber,
 		Subject: pkix.Name{
 			Country:      []string{"USA"},
 			Organization: []string{"Amazon"},
+			CommonName:   "sns.amazonaws.com",
 		},
-		NotBefore:   time.Now(),
-		NotAfter:    time.Now().Add(time.Duration(5) * time.Second),
+		NotBefore:   time.Now().Add(-time.Minute),
+		NotAfter:    time.Now().Add(signingCertLifetime),
 		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
 		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
 	}
@@ -85,18 +89,61 @@ func createPemFile() (privkey *rsa.PrivateKey, pemkey []byte, err error) {
 	return
 }
 
+// LoadSigningKeyPair replaces the generated signing certificate with a PEM encoded certificate and RSA private key
+// from disk, so signatures stay verifiable across restarts.
+func LoadSigningKeyPair(certFile string, keyFile string) error {
+	keyPair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	privkey, ok := keyPair.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return fmt.Errorf("signing key in %s is not an RSA private key", keyFile)
+	}
+
+	PrivateKEY = privkey
+	PemKEY = pem.EncodeToMemory(
+		&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: keyPair.Certificate[0],
+		},
+	)
+	return nil
+}
+
+// signMessage signs the message with SHA1 for SignatureVersion 1 and SHA256 for SignatureVersion 2.
 func signMessage(privkey *rsa.PrivateKey, snsMsg *models.SNSMessage) (string, error) {
 	fs, err := formatSignature(snsMsg)
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 
-	h := sha1.Sum([]byte(fs))
-	signature_b, err := rsa.SignPKCS1v15(rand.Reader, privkey, crypto.SHA1, h[:])
+	var signature_b []byte
+	if snsMsg.SignatureVersion == "2" {
+		h := sha256.Sum256([]byte(fs))
+		signature_b, err = rsa.SignPKCS1v15(rand.Reader, privkey, crypto.SHA256, h[:])
+	} else {
+		h := sha1.Sum([]byte(fs))
+		signature_b, err = rsa.SignPKCS1v15(rand.Reader, privkey, crypto.SHA1, h[:])
+	}
 
 	return base64.StdEncoding.EncodeToString(signature_b), err
 }
 
+// topicSignatureVersion returns the topic's SignatureVersion attribute, falling back to the AWS default of "1".
+func topicSignatureVersion(topicArn string) string {
+	arnSegments := strings.Split(topicArn, ":")
+	topicName := arnSegments[len(arnSegments)-1]
+
+	models.SyncTopics.RLock()
+	defer models.SyncTopics.RUnlock()
+	topic, ok := models.SyncTopics.Topics[topicName]
+	if !ok || topic.Attributes["SignatureVersion"] == "" {
+		return "1"
+	}
+	return topic.Attributes["SignatureVersion"]
+}
+
 func formatSignature(msg *models.SNSMessage) (formated string, err error) {
 	if msg.Type == "Notification" && msg.Subject != "" {
 		formated = fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n",
@@ -135,6 +182,12 @@ func formatSignature(msg *models.SNSMessage) (formated string, err error) {
 // NOTE: The use case for this is to use GoAWS to call some external system with the message payload.  Essentially
 // it is a localized subscription to some non-AWS endpoint.
 func callEndpoint(endpoint string, subArn string, msg models.SNSMessage, raw bool) error {
+	_, err := postToEndpoint(endpoint, subArn, msg, raw)
+	return err
+}
+
+// postToEndpoint makes a single delivery attempt, returning the endpoint's status code (0 if it never responded).
+func postToEndpoint(endpoint string, subArn string, msg models.SNSMessage, raw bool) (int, error) {
 	log.WithFields(log.Fields{
 		"sns":      msg,
 		"subArn":   subArn,
@@ -149,11 +202,11 @@ func callEndpoint(endpoint string, subArn string, msg models.SNSMessage, raw boo
 		byteData, err = json.Marshal(msg)
 	}
 	if err != nil {
-		return err
+		return 0, err
 	}
 	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(byteData))
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	//req.Header.Add("Authorization", "Basic YXV0aEhlYWRlcg==")
@@ -164,11 +217,12 @@ func callEndpoint(endpoint string, subArn string, msg models.SNSMessage, raw boo
 	req.Header.Add("x-amz-sns-subscription-arn", subArn)
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if res == nil {
-		return errors.New("response is nil")
+		return 0, errors.New("response is nil")
 	}
+	defer res.Body.Close()
 
 	//Amazon considers a Notification delivery attempt successful if the endpoint
 	//responds in the range of 200-499. Response codes outside that range will
@@ -181,13 +235,12 @@ func callEndpoint(endpoint string, subArn string, msg models.SNSMessage, raw boo
 			"header":     res.Header,
 			"endpoint":   endpoint,
 		}).Error("Response outside of acceptable (200-499) range")
-		return errors.New("Response outside of acceptable (200-499) range")
+		return res.StatusCode, errors.New("Response outside of acceptable (200-499) range")
 	}
 
-	defer res.Body.Close()
 	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return err
+		return res.StatusCode, err
 	}
 
 	log.WithFields(log.Fields{
@@ -195,7 +248,7 @@ func callEndpoint(endpoint string, subArn string, msg models.SNSMessage, raw boo
 		"res":  res,
 	}).Debug("Received successful response")
 
-	return nil
+	return res.StatusCode, nil
 }
 
 func extractMessageFromJSON(msg string, protocol string) (string, error) {
@@ -237,7 +290,7 @@ func createMessageBody(subs *models.Subscription, entry interfaces.AbstractPubli
 		TopicArn:          subs.TopicArn,
 		Subject:           entry.GetSubject(),
 		Timestamp:         time.Now().UTC().Format(time.RFC3339),
-		SignatureVersion:  "1",
+		SignatureVersion:  topicSignatureVersion(subs.TopicArn),
 		SigningCertURL:    fmt.Sprintf("http://%s:%s/SimpleNotificationService/%s.pem", models.CurrentEnvironment.Host, models.CurrentEnvironment.Port, msgId),
 		UnsubscribeURL:    fmt.Sprintf("http://%s:%s/?Action=Unsubscribe&SubscriptionArn=%s", models.CurrentEnvironment.Host, models.CurrentEnvironment.Port, subs.SubscriptionArn),
 		MessageAttributes: messageAttributes,
@@ -264,7 +317,22 @@ func createMessageBody(subs *models.Subscription, entry interfaces.AbstractPubli
 	return string(byteMsg), nil
 }
 
+// matchesFilterPolicy reports whether the subscription's filter policy, if it has one, lets the entry through.
+func matchesFilterPolicy(subscription *models.Subscription, entry interfaces.AbstractPublishEntry) bool {
+	if subscription.FilterPolicy == nil {
+		return true
+	}
+	if subscription.FilterPolicyScope == models.FilterPolicyScopeMessageBody {
+		return subscription.FilterPolicy.IsSatisfiedByBody(entry.GetMessage())
+	}
+	return subscription.FilterPolicy.IsSatisfiedBy(entry.GetMessageAttributes())
+}
+
 func publishHTTP(subs *models.Subscription, topicArn string, entry interfaces.AbstractPublishEntry) {
+	if !matchesFilterPolicy(subs, entry) {
+		return
+	}
+
 	id := uuid.NewString()
 	msg := models.SNSMessage{
 		Type:              "Notification",
@@ -273,7 +341,7 @@ func publishHTTP(subs *models.Subscription, topicArn string, entry interfaces.Ab
 		Subject:           entry.GetSubject(),
 		Message:           entry.GetMessage(),
 		Timestamp:         time.Now().UTC().Format(time.RFC3339),
-		SignatureVersion:  "1",
+		SignatureVersion:  topicSignatureVersion(topicArn),
 		SigningCertURL:    fmt.Sprintf("http://%s:%s/SimpleNotificationService/%s.pem", models.CurrentEnvironment.Host, models.CurrentEnvironment.Port, id),
 		UnsubscribeURL:    fmt.Sprintf("http://%s:%s/?Action=Unsubscribe&SubscriptionArn=%s", models.CurrentEnvironment.Host, models.CurrentEnvironment.Port, subs.SubscriptionArn),
 		MessageAttributes: entry.GetMessageAttributes(),
@@ -285,21 +353,20 @@ func publishHTTP(subs *models.Subscription, topicArn string, entry interfaces.Ab
 	} else {
 		msg.Signature = signature
 	}
-	err = callEndpoint(subs.EndPoint, subs.SubscriptionArn, msg, subs.Raw)
-	if err != nil {
-		log.WithFields(log.Fields{
-			"EndPoint": subs.EndPoint,
-			"ARN":      subs.SubscriptionArn,
-			"error":    err.Error(),
-		}).Error("Error calling endpoint")
-	}
+	// The delivery itself, and any retries, happen in the background so a slow or failing endpoint doesn't hold
+	// up the publisher.
+	enqueueDelivery(deliveryJob{
+		subscription: subs,
+		message:      msg,
+		policy:       models.ResolveDeliveryPolicy(topicDeliveryPolicy(topicArn), subs.DeliveryPolicy),
+	})
 }
 
 // NOTE: The important thing to know here is that essentially the RAW delivery means we take the message body and
 // put it in the resulting `body`, so that's all that's in that field when the message is received.  If it's not
 // raw, then we put all this other junk in there too, similar to how AWS stores its metadata in there.
 func publishSQS(subscription *models.Subscription, topic *models.Topic, entry interfaces.AbstractPublishEntry) error {
-	if subscription.FilterPolicy != nil && !subscription.FilterPolicy.IsSatisfiedBy(entry.GetMessageAttributes()) {
+	if !matchesFilterPolicy(subscription, entry) {
 		return nil
 	}
 
@@ -309,41 +376,52 @@ func publishSQS(subscription *models.Subscription, topic *models.Topic, entry in
 	arnSegments := strings.Split(queueName, ":")
 	queueName = arnSegments[len(arnSegments)-1]
 
-	if _, ok := models.SyncQueues.Queues[queueName]; ok {
-		msg := models.SqsMessage{}
-
-		if subscription.Raw {
-			msg.MessageAttributes = entry.GetMessageAttributes()
-			msg.MD5OfMessageAttributes = utils.HashAttributes(entry.GetMessageAttributes())
-
-			// NOTE: Admiral-Piett - commenting this out.  I don't understand what this is supposed to achieve
-			// for raw message delivery.  I suspect this doesn't work at all, otherwise you'd have to match the
-			//json message structure pattern with a `default` key at the root to indicate your base message and
-			//all the rest.  I don't think that makes sense for raw delivery.
-			//m, err := extractMessageFromJSON(entry.GetMessage(), subscription.Protocol)
-			//if err == nil {
-			//	msg.MessageBody = []byte(m)
-			//} else {
-			//	msg.MessageBody = []byte(entry.GetMessage())
-			//}
-			msg.MessageBody = entry.GetMessage()
-		} else {
-			m, err := createMessageBody(subscription, entry, entry.GetMessageAttributes())
-			if err != nil {
-				return err
-			}
-
-			msg.MessageBody = m
+	msg := models.SqsMessage{}
+
+	if subscription.Raw {
+		msg.MessageAttributes = entry.GetMessageAttributes()
+		msg.MD5OfMessageAttributes = utils.HashAttributes(entry.GetMessageAttributes())
+
+		// NOTE: Admiral-Piett - commenting this out.  I don't understand what this is supposed to achieve
+		// for raw message delivery.  I suspect this doesn't work at all, otherwise you'd have to match the
+		//json message structure pattern with a `default` key at the root to indicate your base message and
+		//all the rest.  I don't think that makes sense for raw delivery.
+		//m, err := extractMessageFromJSON(entry.GetMessage(), subscription.Protocol)
+		//if err == nil {
+		//	msg.MessageBody = []byte(m)
+		//} else {
+		//	msg.MessageBody = []byte(entry.GetMessage())
+		//}
+		msg.MessageBody = entry.GetMessage()
+	} else {
+		m, err := createMessageBody(subscription, entry, entry.GetMessageAttributes())
+		if err != nil {
+			return err
 		}
 
-		msg.MD5OfMessageBody = utils.GetMD5Hash(entry.GetMessage())
-		msg.Uuid = uuid.NewString()
+		msg.MessageBody = m
+	}
+
+	msg.MD5OfMessageBody = utils.GetMD5Hash(entry.GetMessage())
+	msg.Uuid = uuid.NewString()
+	msg.SentTime = time.Now()
+	msg.AWSTraceHeader = entry.GetAWSTraceHeader()
+
+	models.SyncQueues.RLock()
+	queue, ok := models.SyncQueues.Queues[queueName]
+	allowed := ok && models.TopicCanDeliverTo(queue, topic.Arn)
+	models.SyncQueues.RUnlock()
+	if ok && !allowed {
+		if !sendToDeadLetterQueue(subscription, msg, "AccessDenied", fmt.Sprintf("The queue %s policy does not allow the topic to send messages", queueName)) {
+			log.Warnf("SQS Publish Failure - Access to queue %s denied, message discarded\n", queueName)
+		}
+	} else if ok {
 		models.SyncQueues.Lock()
 		models.SyncQueues.Queues[queueName].Messages = append(models.SyncQueues.Queues[queueName].Messages, msg)
 		models.SyncQueues.Unlock()
 
 		log.Debugf("SQS Publish Success - Topic: %s(%s), Message: %s\n", topic.Name, queueName, msg.MessageBody)
-	} else {
+	} else if !sendToDeadLetterQueue(subscription, msg, "AWS.SimpleQueueService.NonExistentQueue", fmt.Sprintf("The queue %s does not exist", queueName)) {
 		log.Warnf("SQS Publish Failure - Queue %s does not exist, message discarded\n", queueName)
 	}
 	return nil
@@ -360,6 +438,9 @@ var publishMessageByTopicFunc = publishMessageByTopic
 func publishMessageByTopic(topic *models.Topic, message interfaces.AbstractPublishEntry) (messageId string, err error) {
 	messageId = uuid.NewString()
 	for _, sub := range topic.Subscriptions {
+		if sub.PendingConfirmation {
+			continue
+		}
 		switch models.Protocol(sub.Protocol) {
 		case models.ProtocolSQS:
 			err = publishSqsMessageFunc(sub, topic, message)
@@ -1,16 +1,25 @@
 package gosns
 
 import (
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Admiral-Piett/goaws/app/interfaces"
 
 	"github.com/Admiral-Piett/goaws/app/conf"
 	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,6 +50,28 @@ func Test_publishSQS_success_raw_true(t *testing.T) {
 	assert.Equal(t, message, string(messages[0].MessageBody))
 }
 
+func Test_publishSQS_passes_aws_trace_header_to_queue(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	request := models.PublishRequest{
+		TopicArn:       topic.Arn,
+		Message:        "test%20message",
+		AWSTraceHeader: "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1",
+	}
+	err := publishSQS(sub, topic, &request)
+
+	assert.Nil(t, err)
+
+	messages := models.SyncQueues.Queues["subscribed-queue1"].Messages
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1", messages[0].AWSTraceHeader)
+}
+
 func Test_publishSQS_success_json_raw_false(t *testing.T) {
 	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
 	defer func() {
@@ -88,7 +119,7 @@ func Test_publishSQS_filter_policy_not_satisfied_by_attributes(t *testing.T) {
 	models.SyncTopics.Lock()
 	topic := models.SyncTopics.Topics["unit-topic1"]
 	sub := topic.Subscriptions[0]
-	sub.FilterPolicy = &models.FilterPolicy{"foo": []string{"bar"}}
+	sub.FilterPolicy = &models.FilterPolicy{"foo": []interface{}{"bar"}}
 	models.SyncTopics.Unlock()
 
 	request := models.PublishRequest{
@@ -106,6 +137,62 @@ func Test_publishSQS_filter_policy_not_satisfied_by_attributes(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func Test_publishSQS_filter_policy_scope_message_body_satisfied(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	models.SyncTopics.Lock()
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	sub.FilterPolicy = &models.FilterPolicy{"store": map[string]interface{}{"city": []interface{}{"Seattle"}}}
+	sub.FilterPolicyScope = models.FilterPolicyScopeMessageBody
+	models.SyncTopics.Unlock()
+
+	request := models.PublishRequest{
+		TopicArn: topic.Arn,
+		Message:  `{"store": {"city": "Seattle"}, "total": 10}`,
+		MessageAttributes: map[string]models.MessageAttribute{
+			"store": {DataType: "String", StringValue: "garbage"},
+		},
+	}
+	err := publishSQS(sub, topic, &request)
+
+	assert.Nil(t, err)
+	assert.Len(t, models.SyncQueues.Queues["subscribed-queue1"].Messages, 1)
+}
+
+func Test_publishSQS_filter_policy_scope_message_body_not_satisfied(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	models.SyncTopics.Lock()
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	sub.FilterPolicy = &models.FilterPolicy{"store": map[string]interface{}{"city": []interface{}{"Seattle"}}}
+	sub.FilterPolicyScope = models.FilterPolicyScopeMessageBody
+	models.SyncTopics.Unlock()
+
+	messages := []string{
+		`{"store": {"city": "Portland"}}`,
+		`{"store": "Seattle"}`,
+		"not json at all",
+	}
+	for _, message := range messages {
+		request := models.PublishRequest{
+			TopicArn: topic.Arn,
+			Message:  message,
+		}
+		err := publishSQS(sub, topic, &request)
+
+		assert.Nil(t, err)
+	}
+	assert.Len(t, models.SyncQueues.Queues["subscribed-queue1"].Messages, 0)
+}
+
 func Test_publishSQS_missing_queue_returns_nil(t *testing.T) {
 	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
 	defer func() {
@@ -130,6 +217,100 @@ func Test_publishSQS_missing_queue_returns_nil(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func Test_publishSQS_missing_queue_moves_message_to_dead_letter_queue(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	message := "{\"IAm\": \"aMessage\"}"
+
+	models.SyncTopics.Lock()
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	sub.EndPoint = "arn:aws:sqs:region:accountID:garbage"
+	sub.RedrivePolicy = &models.SubscriptionRedrivePolicy{DeadLetterTargetArn: "arn:aws:sqs:region:accountID:dead-letter-queue1"}
+	models.SyncTopics.Unlock()
+
+	request := models.PublishRequest{
+		TopicArn: topic.Arn,
+		Message:  message,
+		MessageAttributes: map[string]models.MessageAttribute{
+			"event": {DataType: "String", StringValue: "created"},
+		},
+	}
+	err := publishSQS(sub, topic, &request)
+
+	assert.Nil(t, err)
+
+	messages := models.SyncQueues.Queues["dead-letter-queue1"].Messages
+	assert.Len(t, messages, 1)
+	assert.Equal(t, message, messages[0].MessageBody)
+	attributes := messages[0].MessageAttributes
+	assert.Equal(t, models.MessageAttribute{DataType: "String", StringValue: "created"}, attributes["event"])
+	assert.Equal(t, models.MessageAttribute{DataType: "String", StringValue: "AWS.SimpleQueueService.NonExistentQueue"}, attributes["ErrorCode"])
+	assert.Equal(t, "The queue garbage does not exist", attributes["ErrorMessage"].StringValue)
+	assert.NotEmpty(t, attributes["RequestID"].StringValue)
+	assert.Equal(t, utils.HashAttributes(attributes), messages[0].MD5OfMessageAttributes)
+}
+
+func Test_publishSQS_enforced_queue_policy_denies_topic(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	models.CurrentEnvironment.EnforcePolicies = true
+	defer func() {
+		models.ResetApp()
+	}()
+
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	sub.RedrivePolicy = &models.SubscriptionRedrivePolicy{DeadLetterTargetArn: "arn:aws:sqs:region:accountID:dead-letter-queue1"}
+	queue := models.SyncQueues.Queues["subscribed-queue1"]
+	queue.Policy = fmt.Sprintf(`{"Statement":[{"Effect":"Allow","Principal":{"Service":"sns.amazonaws.com"},"Action":"SQS:SendMessage","Resource":"%s","Condition":{"ArnEquals":{"aws:SourceArn":"arn:aws:sns:region:accountID:other-topic"}}}]}`, queue.Arn)
+
+	request := models.PublishRequest{
+		TopicArn: topic.Arn,
+		Message:  "denied",
+	}
+	err := publishSQS(sub, topic, &request)
+
+	assert.Nil(t, err)
+	assert.Empty(t, queue.Messages)
+	messages := models.SyncQueues.Queues["dead-letter-queue1"].Messages
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "AccessDenied", messages[0].MessageAttributes["ErrorCode"].StringValue)
+
+	queue.Policy = fmt.Sprintf(`{"Statement":[{"Effect":"Allow","Principal":{"Service":"sns.amazonaws.com"},"Action":"SQS:SendMessage","Resource":"%s","Condition":{"ArnEquals":{"aws:SourceArn":"%s"}}}]}`, queue.Arn, topic.Arn)
+	request.Message = "allowed"
+	err = publishSQS(sub, topic, &request)
+
+	assert.Nil(t, err)
+	assert.Len(t, queue.Messages, 1)
+	assert.Equal(t, "allowed", queue.Messages[0].MessageBody)
+}
+
+func Test_publishSQS_missing_queue_and_missing_dead_letter_queue_returns_nil(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	models.SyncTopics.Lock()
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	sub.EndPoint = "garbage"
+	sub.RedrivePolicy = &models.SubscriptionRedrivePolicy{DeadLetterTargetArn: "arn:aws:sqs:region:accountID:also-garbage"}
+	models.SyncTopics.Unlock()
+
+	request := models.PublishRequest{
+		TopicArn: topic.Arn,
+		Message:  "message",
+	}
+	err := publishSQS(sub, topic, &request)
+
+	assert.Nil(t, err)
+	assert.Len(t, models.SyncQueues.Queues["dead-letter-queue1"].Messages, 0)
+}
+
 func Test_publishHTTP_success(t *testing.T) {
 	called := false
 	subscribedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -140,6 +321,7 @@ func Test_publishHTTP_success(t *testing.T) {
 	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
 	defer func() {
 		models.ResetApp()
+		resetDeliveries()
 		subscribedServer.Close()
 	}()
 
@@ -159,13 +341,180 @@ func Test_publishHTTP_success(t *testing.T) {
 
 	publishHTTP(sub, topicArn, &request)
 
+	assert.Eventually(t, func() bool {
+		return len(GetDeliveryLog(sub.SubscriptionArn)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, called)
+}
+
+func Test_publishHTTP_filter_policy_satisfied_by_attributes(t *testing.T) {
+	received := ""
+	called := false
+	subscribedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.WriteHeader(200)
+	}))
+
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		resetDeliveries()
+		subscribedServer.Close()
+	}()
+
+	models.SyncTopics.Lock()
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	sub.EndPoint = subscribedServer.URL
+	sub.FilterPolicy = &models.FilterPolicy{"event": []interface{}{"created", "updated"}}
+	models.SyncTopics.Unlock()
+
+	request := models.PublishRequest{
+		TopicArn: topic.Arn,
+		Message:  "matching message",
+		MessageAttributes: map[string]models.MessageAttribute{
+			"event": {DataType: "String", StringValue: "updated"},
+		},
+	}
+
+	publishHTTP(sub, topic.Arn, &request)
+
+	assert.Eventually(t, func() bool {
+		return len(GetDeliveryLog(sub.SubscriptionArn)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
 	assert.True(t, called)
+	assert.Contains(t, received, "matching message")
+}
+
+func Test_publishHTTP_filter_policy_not_satisfied_by_attributes(t *testing.T) {
+	enqueued := []deliveryJob{}
+	enqueueDelivery = func(job deliveryJob) {
+		enqueued = append(enqueued, job)
+	}
+
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		enqueueDelivery = queueDelivery
+	}()
+
+	models.SyncTopics.Lock()
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	sub.FilterPolicy = &models.FilterPolicy{"event": []interface{}{"created"}}
+	models.SyncTopics.Unlock()
+
+	requests := []models.PublishRequest{
+		{
+			TopicArn: topic.Arn,
+			Message:  "wrong value",
+			MessageAttributes: map[string]models.MessageAttribute{
+				"event": {DataType: "String", StringValue: "deleted"},
+			},
+		},
+		{
+			TopicArn: topic.Arn,
+			Message:  "missing attribute",
+		},
+	}
+	for _, request := range requests {
+		publishHTTP(sub, topic.Arn, &request)
+	}
+
+	assert.Empty(t, enqueued)
+}
+
+func Test_publishMessageByTopic_http_filter_policy(t *testing.T) {
+	var matchingCalls, otherCalls int32
+	matchingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&matchingCalls, 1)
+		w.WriteHeader(200)
+	}))
+	otherServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&otherCalls, 1)
+		w.WriteHeader(200)
+	}))
+	defer func() {
+		matchingServer.Close()
+		otherServer.Close()
+		resetDeliveries()
+	}()
+
+	topicArn := "arn:aws:sns:region:accountID:my-topic"
+	topic := &models.Topic{
+		Arn: topicArn,
+		Subscriptions: []*models.Subscription{
+			{
+				Protocol:        "http",
+				TopicArn:        topicArn,
+				EndPoint:        matchingServer.URL,
+				SubscriptionArn: topicArn + ":matching",
+				FilterPolicy:    &models.FilterPolicy{"price": []interface{}{map[string]interface{}{"numeric": []interface{}{">=", float64(100)}}}},
+			},
+			{
+				Protocol:        "http",
+				TopicArn:        topicArn,
+				EndPoint:        otherServer.URL,
+				SubscriptionArn: topicArn + ":other",
+				FilterPolicy:    &models.FilterPolicy{"price": []interface{}{map[string]interface{}{"numeric": []interface{}{"<", float64(100)}}}},
+			},
+		},
+	}
+	entry := &models.PublishRequest{
+		TopicArn: topicArn,
+		Message:  "message",
+		MessageAttributes: map[string]models.MessageAttribute{
+			"price": {DataType: "Number", StringValue: "150"},
+		},
+	}
+
+	_, err := publishMessageByTopic(topic, entry)
+
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		return len(GetDeliveryLog(topicArn+":matching")) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, GetDeliveryLog(topicArn+":other"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&matchingCalls))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&otherCalls))
+}
+
+func Test_publishHTTP_filter_policy_scope_message_body_not_satisfied(t *testing.T) {
+	enqueued := []deliveryJob{}
+	enqueueDelivery = func(job deliveryJob) {
+		enqueued = append(enqueued, job)
+	}
+
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		enqueueDelivery = queueDelivery
+	}()
+
+	models.SyncTopics.Lock()
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	sub.FilterPolicy = &models.FilterPolicy{"event": []interface{}{"created"}}
+	sub.FilterPolicyScope = models.FilterPolicyScopeMessageBody
+	models.SyncTopics.Unlock()
+
+	request := models.PublishRequest{
+		TopicArn: topic.Arn,
+		Message:  `{"event": "deleted"}`,
+	}
+
+	publishHTTP(sub, topic.Arn, &request)
+
+	assert.Empty(t, enqueued)
 }
 
 func Test_publishHTTP_callEndpoint_failure(t *testing.T) {
 	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
 	defer func() {
 		models.ResetApp()
+		resetDeliveries()
 	}()
 
 	message := "{\"IAm\": \"aMessage\"}"
@@ -181,8 +530,20 @@ func Test_publishHTTP_callEndpoint_failure(t *testing.T) {
 		Message:  message,
 	}
 
+	deliverySleep = func(time.Duration) {}
+	defer func() {
+		deliverySleep = time.Sleep
+	}()
+
 	publishHTTP(sub, topicArn, &request)
-	// swallows all errors
+
+	// The default policy retries 3 times before giving up
+	assert.Eventually(t, func() bool {
+		return len(GetDeliveryLog(sub.SubscriptionArn)) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+	for _, attempt := range GetDeliveryLog(sub.SubscriptionArn) {
+		assert.False(t, attempt.Delivered)
+	}
 }
 
 func TestCreateMessageBody_success_NoMessageAttributes(t *testing.T) {
@@ -402,6 +763,28 @@ func Test_publishMessageByTopic_https_success(t *testing.T) {
 	assert.Equal(t, []interface{}{subscription, topicArn, entry}, calledWith[0])
 }
 
+func Test_publishMessageByTopic_skips_pending_subscriptions(t *testing.T) {
+	defer func() {
+		publishHttpMessageFunc = publishHTTP
+	}()
+
+	calledWith := [][]interface{}{}
+	publishHttpMessageFunc = func(subscription *models.Subscription, topicArn string, entry interfaces.AbstractPublishEntry) {
+		calledWith = append(calledWith, []interface{}{subscription, topicArn, entry})
+	}
+	topic := &models.Topic{
+		Arn:           "my-topic-arn",
+		Subscriptions: []*models.Subscription{{Protocol: "http", PendingConfirmation: true}},
+	}
+	entry := &models.PublishBatchRequestEntry{}
+
+	msgId, err := publishMessageByTopic(topic, entry)
+
+	assert.NotEqual(t, "", msgId)
+	assert.Nil(t, err)
+	assert.Len(t, calledWith, 0)
+}
+
 func Test_publishMessageByTopic_success_no_subscriptions(t *testing.T) {
 	defer func() {
 		publishSqsMessageFunc = publishSQS
@@ -446,3 +829,190 @@ func Test_publishMessageByTopic_failure_sqs_publish_failure(t *testing.T) {
 
 	assert.True(t, called)
 }
+
+// verifySignature checks the message's signature against the certificate served from the SigningCertURL.
+func verifySignature(t *testing.T, msg *models.SNSMessage) error {
+	block, _ := pem.Decode(PemKEY)
+	if !assert.NotNil(t, block) {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if !assert.Nil(t, err) {
+		return nil
+	}
+	signature, err := base64.StdEncoding.DecodeString(msg.Signature)
+	if !assert.Nil(t, err) {
+		return nil
+	}
+	algorithm := x509.SHA1WithRSA
+	if msg.SignatureVersion == "2" {
+		algorithm = x509.SHA256WithRSA
+	}
+	formatted, _ := formatSignature(msg)
+	return cert.CheckSignature(algorithm, []byte(formatted), signature)
+}
+
+func Test_signMessage_signature_version_1_uses_sha1(t *testing.T) {
+	msg := &models.SNSMessage{
+		Type:             "Notification",
+		MessageId:        "message-id",
+		TopicArn:         "topic-arn",
+		Message:          "message",
+		Timestamp:        "2024-01-01T00:00:00Z",
+		SignatureVersion: "1",
+	}
+
+	signature, err := signMessage(PrivateKEY, msg)
+	assert.Nil(t, err)
+	msg.Signature = signature
+
+	assert.Nil(t, verifySignature(t, msg))
+	msg.SignatureVersion = "2"
+	assert.NotNil(t, verifySignature(t, msg))
+}
+
+func Test_signMessage_signature_version_2_uses_sha256(t *testing.T) {
+	msg := &models.SNSMessage{
+		Type:             "SubscriptionConfirmation",
+		MessageId:        "message-id",
+		TopicArn:         "topic-arn",
+		Message:          "message",
+		Token:            "token",
+		SubscribeURL:     "http://host:port/?Action=ConfirmSubscription",
+		Timestamp:        "2024-01-01T00:00:00Z",
+		SignatureVersion: "2",
+	}
+
+	signature, err := signMessage(PrivateKEY, msg)
+	assert.Nil(t, err)
+	msg.Signature = signature
+
+	assert.Nil(t, verifySignature(t, msg))
+	msg.SignatureVersion = "1"
+	assert.NotNil(t, verifySignature(t, msg))
+}
+
+func Test_signMessage_unknown_type_returns_error(t *testing.T) {
+	signature, err := signMessage(PrivateKEY, &models.SNSMessage{Type: "garbage"})
+
+	assert.Error(t, err)
+	assert.Equal(t, "", signature)
+}
+
+func Test_createPemFile_certificate_stays_valid(t *testing.T) {
+	privkey, pemkey, err := createPemFile()
+	assert.Nil(t, err)
+
+	block, _ := pem.Decode(pemkey)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	assert.Nil(t, err)
+
+	assert.True(t, cert.NotBefore.Before(time.Now()))
+	assert.True(t, cert.NotAfter.After(time.Now().Add(365*24*time.Hour)))
+	assert.Equal(t, &privkey.PublicKey, cert.PublicKey)
+}
+
+func Test_LoadSigningKeyPair_success(t *testing.T) {
+	defaultKey, defaultPem := PrivateKEY, PemKEY
+	defer func() {
+		PrivateKEY, PemKEY = defaultKey, defaultPem
+	}()
+
+	privkey, pemkey, _ := createPemFile()
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	os.WriteFile(certFile, pemkey, 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privkey)}), 0600)
+
+	err := LoadSigningKeyPair(certFile, keyFile)
+	assert.Nil(t, err)
+
+	assert.Equal(t, pemkey, PemKEY)
+	assert.True(t, privkey.Equal(PrivateKEY))
+
+	msg := &models.SNSMessage{
+		Type:             "Notification",
+		MessageId:        "message-id",
+		TopicArn:         "topic-arn",
+		Message:          "message",
+		Timestamp:        "2024-01-01T00:00:00Z",
+		SignatureVersion: "2",
+	}
+	msg.Signature, _ = signMessage(PrivateKEY, msg)
+	assert.Nil(t, verifySignature(t, msg))
+}
+
+func Test_LoadSigningKeyPair_missing_files_keeps_generated_key(t *testing.T) {
+	defaultKey, defaultPem := PrivateKEY, PemKEY
+
+	err := LoadSigningKeyPair("garbage-cert.pem", "garbage-key.pem")
+
+	assert.Error(t, err)
+	assert.Equal(t, defaultKey, PrivateKEY)
+	assert.Equal(t, defaultPem, PemKEY)
+}
+
+func Test_publishHTTP_signs_with_topic_signature_version(t *testing.T) {
+	received := make(chan models.SNSMessage, 1)
+	subscribedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := models.SNSMessage{}
+		json.NewDecoder(r.Body).Decode(&msg)
+		received <- msg
+		w.WriteHeader(200)
+	}))
+
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		subscribedServer.Close()
+	}()
+
+	models.SyncTopics.Lock()
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	topic.Attributes["SignatureVersion"] = "2"
+	sub := topic.Subscriptions[0]
+	sub.Protocol = "http"
+	sub.Raw = false
+	sub.EndPoint = subscribedServer.URL
+	models.SyncTopics.Unlock()
+
+	request := models.PublishRequest{
+		TopicArn: topic.Arn,
+		Message:  "message",
+	}
+
+	publishHTTP(sub, topic.Arn, &request)
+
+	var msg models.SNSMessage
+	select {
+	case msg = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the endpoint never received the message")
+	}
+	assert.Equal(t, "2", msg.SignatureVersion)
+	assert.Nil(t, verifySignature(t, &msg))
+}
+
+func TestCreateMessageBody_signs_with_topic_signature_version(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	topic.Attributes["SignatureVersion"] = "2"
+	subs := &models.Subscription{
+		Protocol:        "sqs",
+		TopicArn:        topic.Arn,
+		SubscriptionArn: "subs-arn",
+	}
+
+	result, err := createMessageBody(subs, &models.PublishRequest{Message: "message"}, map[string]models.MessageAttribute{})
+	assert.Nil(t, err)
+
+	msg := &models.SNSMessage{}
+	json.Unmarshal([]byte(result), msg)
+	assert.Equal(t, "2", msg.SignatureVersion)
+	assert.Nil(t, verifySignature(t, msg))
+}
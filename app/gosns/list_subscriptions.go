@@ -31,6 +31,9 @@ func ListSubscriptionsV1(req *http.Request) (int, interfaces.AbstractResponseBod
 		for _, sub := range topic.Subscriptions {
 			tar := models.TopicMemberResult{TopicArn: topic.Arn, Protocol: sub.Protocol,
 				SubscriptionArn: sub.SubscriptionArn, Endpoint: sub.EndPoint, Owner: models.CurrentEnvironment.AccountID}
+			if sub.PendingConfirmation {
+				tar.SubscriptionArn = models.PendingConfirmationListedArn
+			}
 			respStruct.Result.Subscriptions.Member = append(respStruct.Result.Subscriptions.Member, tar)
 		}
 	}
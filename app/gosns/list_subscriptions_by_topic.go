@@ -35,6 +35,9 @@ func ListSubscriptionsByTopicV1(req *http.Request) (int, interfaces.AbstractResp
 	for _, sub := range topic.Subscriptions {
 		tar := models.TopicMemberResult{TopicArn: topic.Arn, Protocol: sub.Protocol,
 			SubscriptionArn: sub.SubscriptionArn, Endpoint: sub.EndPoint, Owner: models.CurrentEnvironment.AccountID}
+		if sub.PendingConfirmation {
+			tar.SubscriptionArn = models.PendingConfirmationListedArn
+		}
 		resultMember = append(resultMember, tar)
 	}
 
@@ -83,3 +83,41 @@ func TestListSubscriptionsV1_request_transformer_error(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, code)
 }
+
+func TestListSubcriptionsV1_PendingConfirmation(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	topic := models.SyncTopics.Topics["unit-topic2"]
+	topic.Subscriptions = append(topic.Subscriptions, &models.Subscription{
+		TopicArn:            topic.Arn,
+		Protocol:            "http",
+		EndPoint:            "http://localhost/endpoint",
+		SubscriptionArn:     topic.Arn + ":pending",
+		PendingConfirmation: true,
+	})
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListSubscriptionsRequest)
+		*v = models.ListSubscriptionsRequest{}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := ListSubscriptionsV1(r)
+
+	response, _ := res.(models.ListSubscriptionsResponse)
+
+	assert.Equal(t, http.StatusOK, code)
+	found := false
+	for _, member := range response.Result.Subscriptions.Member {
+		if member.Endpoint == "http://localhost/endpoint" {
+			found = true
+			assert.Equal(t, "PendingConfirmation", member.SubscriptionArn)
+		}
+	}
+	assert.True(t, found)
+}
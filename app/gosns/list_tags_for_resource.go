@@ -0,0 +1,56 @@
+package gosns
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func ListTagsForResourceV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewListTagsForResourceRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - ListTagsForResourceV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+	}
+	if requestBody.ResourceArn == "" {
+		return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+	}
+
+	arnSegments := strings.Split(requestBody.ResourceArn, ":")
+	topicName := arnSegments[len(arnSegments)-1]
+
+	models.SyncTopics.RLock()
+	defer models.SyncTopics.RUnlock()
+	topic, ok := models.SyncTopics.Topics[topicName]
+	if !ok {
+		return utils.CreateErrorResponseV1("ResourceNotFound", false)
+	}
+
+	// Sort the keys so the responses come back in a stable order
+	keys := make([]string, 0, len(topic.Tags))
+	for key := range topic.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tags := make([]models.TopicTag, 0, len(keys))
+	for _, key := range keys {
+		tags = append(tags, models.TopicTag{Key: key, Value: topic.Tags[key]})
+	}
+
+	respStruct := models.ListTagsForResourceResponse{
+		Xmlns:    models.BaseXmlns,
+		Result:   models.ListTagsForResourceResult{Tags: models.TopicTags{Member: tags}},
+		Metadata: models.ResponseMetadata{RequestId: uuid.NewString()},
+	}
+	return http.StatusOK, respStruct
+}
@@ -0,0 +1,107 @@
+package gosns
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListTagsForResourceV1_success(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	models.SyncTopics.Topics["unit-topic1"].Tags["env"] = "dev"
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListTagsForResourceRequest)
+		*v = models.ListTagsForResourceRequest{
+			ResourceArn: "arn:aws:sns:region:accountID:unit-topic1",
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := ListTagsForResourceV1(r)
+
+	response, _ := res.(models.ListTagsForResourceResponse)
+
+	expectedTags := []models.TopicTag{
+		{Key: "env", Value: "dev"},
+		{Key: "team", Value: "platform"},
+	}
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expectedTags, response.Result.Tags.Member)
+}
+
+func TestListTagsForResourceV1_success_no_tags(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListTagsForResourceRequest)
+		*v = models.ListTagsForResourceRequest{
+			ResourceArn: "arn:aws:sns:region:accountID:unit-topic2",
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := ListTagsForResourceV1(r)
+
+	response, _ := res.(models.ListTagsForResourceResponse)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, response.Result.Tags.Member)
+}
+
+func TestListTagsForResourceV1_error_topic_not_found(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListTagsForResourceRequest)
+		*v = models.ListTagsForResourceRequest{
+			ResourceArn: "arn:aws:sns:region:accountID:garbage",
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := ListTagsForResourceV1(r)
+	response := res.(models.ErrorResponse)
+
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "AWS.SimpleNotificationService.ResourceNotFound", response.Result.Code)
+}
+
+func TestListTagsForResourceV1_request_transformer_error(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := ListTagsForResourceV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -46,12 +46,17 @@ func PublishV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
 	if !ok {
 		return utils.CreateErrorResponseV1("TopicNotFound", false)
 	}
+	if !models.CallerIsAllowed(utils.GetAccessKeyId(req), topic.Attributes["Policy"], "SNS:Publish", topic.Arn) {
+		return utils.CreateErrorResponseV1("AuthorizationError", false)
+	}
 	log.WithFields(log.Fields{
 		"topic":    topicName,
 		"topicArn": requestBody.TopicArn,
 		"subject":  requestBody.Subject,
 	}).Debug("Publish to Topic")
 
+	// SNS passes the X-Ray trace header of the publish on to SQS subscriptions
+	requestBody.AWSTraceHeader = req.Header.Get("X-Amzn-Trace-Id")
 	messageId, err := publishMessageByTopicFunc(topic, requestBody)
 	if err != nil {
 		utils.CreateErrorResponseV1(err.Error(), false)
@@ -57,10 +57,14 @@ func PublishBatchV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
 	if !ok {
 		return utils.CreateErrorResponseV1("TopicNotFound", false)
 	}
+	if !models.CallerIsAllowed(utils.GetAccessKeyId(req), topic.Attributes["Policy"], "SNS:Publish", topic.Arn) {
+		return utils.CreateErrorResponseV1("AuthorizationError", false)
+	}
 
 	successfulEntries := []models.PublishBatchResultEntry{}
 	failedEntries := []models.BatchResultErrorEntry{}
 	for _, entry := range requestBody.PublishBatchRequestEntries.Member {
+		entry.AWSTraceHeader = req.Header.Get("X-Amzn-Trace-Id")
 		messageId, err := publishMessageByTopicFunc(topic, entry)
 		if err != nil {
 			er := models.SnsErrors[err.Error()]
@@ -50,6 +50,38 @@ func TestPublishV1_success_sqs(t *testing.T) {
 	assert.Equal(t, []interface{}{topic, &expectedPublishRequest}, publishCalledWith[0])
 }
 
+func TestPublishV1_success_sqs_passes_trace_header(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+		publishMessageByTopicFunc = publishMessageByTopic
+	}()
+
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.PublishRequest)
+		*v = models.PublishRequest{
+			TopicArn: topic.Arn,
+			Message:  "{\"IAm\": \"aMessage\"}",
+		}
+		return true
+	}
+
+	publishedTraceHeader := ""
+	publishMessageByTopicFunc = func(topic *models.Topic, message interfaces.AbstractPublishEntry) (string, error) {
+		publishedTraceHeader = message.GetAWSTraceHeader()
+		return "", nil
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	r.Header.Set("X-Amzn-Trace-Id", "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1")
+	status, _ := PublishV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1", publishedTraceHeader)
+}
+
 func TestPublishV1_request_transformer_error(t *testing.T) {
 	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
 	defer func() {
@@ -171,3 +203,46 @@ func TestPublishV1_request_invalid_base64_encoding(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, status)
 
 }
+
+func TestPublishV1_enforced_topic_policy_denies_caller_from_other_account(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	models.CurrentEnvironment.EnforcePolicies = true
+	models.CurrentEnvironment.AccessKeys = map[string]string{"other-key": "arn:aws:iam::200020002000:user/other"}
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+		publishMessageByTopicFunc = publishMessageByTopic
+	}()
+
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.PublishRequest)
+		*v = models.PublishRequest{
+			TopicArn: topic.Arn,
+			Message:  "test",
+		}
+		return true
+	}
+
+	publishCalled := false
+	publishMessageByTopicFunc = func(topic *models.Topic, message interfaces.AbstractPublishEntry) (string, error) {
+		publishCalled = true
+		return "", nil
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	r.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=other-key/20240101/region/sns/aws4_request, SignedHeaders=host, Signature=abc")
+	status, response := PublishV1(r)
+
+	assert.Equal(t, http.StatusForbidden, status)
+	errorResponse, ok := response.(models.ErrorResponse)
+	assert.True(t, ok)
+	assert.Equal(t, "AuthorizationError", errorResponse.Result.Code)
+	assert.False(t, publishCalled)
+
+	topic.Attributes["Policy"] = `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"200020002000"},"Action":"SNS:Publish","Resource":"` + topic.Arn + `"}]}`
+	status, _ = PublishV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, publishCalled)
+}
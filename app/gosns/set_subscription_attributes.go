@@ -42,6 +42,9 @@ func SetSubscriptionAttributesV1(req *http.Request) (int, interfaces.AbstractRes
 	case "FilterPolicy":
 		filterPolicy := &models.FilterPolicy{}
 		err := json.Unmarshal([]byte(attrValue), filterPolicy)
+		if err == nil {
+			err = filterPolicy.Validate()
+		}
 		if err != nil {
 			return utils.CreateErrorResponseV1("InvalidParameterValue", false)
 		}
@@ -49,7 +52,38 @@ func SetSubscriptionAttributesV1(req *http.Request) (int, interfaces.AbstractRes
 		sub.FilterPolicy = filterPolicy
 		models.SyncTopics.Unlock()
 
-	case "DeliveryPolicy", "FilterPolicyScope", "RedrivePolicy", "SubscriptionRoleArn":
+	case "FilterPolicyScope":
+		if attrValue != models.FilterPolicyScopeMessageAttributes && attrValue != models.FilterPolicyScopeMessageBody {
+			return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+		}
+		models.SyncTopics.Lock()
+		sub.FilterPolicyScope = attrValue
+		models.SyncTopics.Unlock()
+
+	case "DeliveryPolicy":
+		deliveryPolicy := &models.DeliveryPolicy{}
+		err := json.Unmarshal([]byte(attrValue), deliveryPolicy)
+		if err != nil {
+			return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+		}
+		models.SyncTopics.Lock()
+		sub.DeliveryPolicy = deliveryPolicy
+		models.SyncTopics.Unlock()
+
+	case "RedrivePolicy":
+		var redrivePolicy *models.SubscriptionRedrivePolicy
+		if attrValue != "" {
+			redrivePolicy = &models.SubscriptionRedrivePolicy{}
+			err := json.Unmarshal([]byte(attrValue), redrivePolicy)
+			if err != nil || redrivePolicy.DeadLetterTargetArn == "" {
+				return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+			}
+		}
+		models.SyncTopics.Lock()
+		sub.RedrivePolicy = redrivePolicy
+		models.SyncTopics.Unlock()
+
+	case "SubscriptionRoleArn":
 		log.Info(fmt.Sprintf("AttributeName [%s] is valid on AWS but it is not implemented.", attrName))
 
 	default:
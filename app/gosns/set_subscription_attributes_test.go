@@ -100,7 +100,7 @@ func TestSetSubscriptionAttributesV1_success_SetFilterPolicy(t *testing.T) {
 
 	// Assert SubscriptionAttribute has been updated
 	expectedFilterPolicy := make(models.FilterPolicy)
-	expectedFilterPolicy["foo"] = []string{"bar"}
+	expectedFilterPolicy["foo"] = []interface{}{"bar"}
 	assert.Equal(t, &expectedFilterPolicy, sub.FilterPolicy)
 }
 
@@ -131,6 +131,33 @@ func TestSetSubscriptionAttributesV1_error_SetFilterPolicy_invalid(t *testing.T)
 	assert.Equal(t, http.StatusBadRequest, code)
 }
 
+func TestSetSubscriptionAttributesV1_error_SetFilterPolicy_unknown_operator(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "Local")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	localTopic1 := models.SyncTopics.Topics["local-topic1"]
+	sub := localTopic1.Subscriptions[0]
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SetSubscriptionAttributesRequest)
+		*v = models.SetSubscriptionAttributesRequest{
+			SubscriptionArn: sub.SubscriptionArn,
+			AttributeName:   "FilterPolicy",
+			AttributeValue:  `{"foo": [{"not-an-operator": "bar"}]}`,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := SetSubscriptionAttributesV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Empty(t, sub.FilterPolicy)
+}
+
 func TestSetSubscriptionAttributesV1_success_SetDeliveryPolicy(t *testing.T) {
 	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "Local")
 	defer func() {
@@ -141,6 +168,37 @@ func TestSetSubscriptionAttributesV1_success_SetDeliveryPolicy(t *testing.T) {
 	localTopic1 := models.SyncTopics.Topics["local-topic1"]
 	sub := localTopic1.Subscriptions[0]
 
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SetSubscriptionAttributesRequest)
+		*v = models.SetSubscriptionAttributesRequest{
+			SubscriptionArn: sub.SubscriptionArn,
+			AttributeName:   "DeliveryPolicy",
+			AttributeValue:  `{"healthyRetryPolicy": {"numRetries": 5, "minDelayTarget": 1, "maxDelayTarget": 10, "backoffFunction": "exponential"}, "throttlePolicy": {"maxReceivesPerSecond": 2}}`,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := SetSubscriptionAttributesV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	expected := &models.DeliveryPolicy{
+		HealthyRetryPolicy: &models.RetryPolicy{NumRetries: 5, MinDelayTarget: 1, MaxDelayTarget: 10, BackoffFunction: "exponential"},
+		ThrottlePolicy:     &models.ThrottlePolicy{MaxReceivesPerSecond: 2},
+	}
+	assert.Equal(t, expected, sub.DeliveryPolicy)
+}
+
+func TestSetSubscriptionAttributesV1_error_invalid_DeliveryPolicy(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "Local")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	localTopic1 := models.SyncTopics.Topics["local-topic1"]
+	sub := localTopic1.Subscriptions[0]
+
 	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
 		v := resultingStruct.(*models.SetSubscriptionAttributesRequest)
 		*v = models.SetSubscriptionAttributesRequest{
@@ -154,7 +212,90 @@ func TestSetSubscriptionAttributesV1_success_SetDeliveryPolicy(t *testing.T) {
 	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
 	code, _ := SetSubscriptionAttributesV1(r)
 
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Nil(t, sub.DeliveryPolicy)
+}
+
+func TestSetSubscriptionAttributesV1_success_SetRedrivePolicy(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "Local")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	localTopic1 := models.SyncTopics.Topics["local-topic1"]
+	sub := localTopic1.Subscriptions[0]
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SetSubscriptionAttributesRequest)
+		*v = models.SetSubscriptionAttributesRequest{
+			SubscriptionArn: sub.SubscriptionArn,
+			AttributeName:   "RedrivePolicy",
+			AttributeValue:  `{"deadLetterTargetArn": "arn:aws:sqs:us-east-1:100010001000:local-queue3-dlq"}`,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := SetSubscriptionAttributesV1(r)
+
 	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, &models.SubscriptionRedrivePolicy{DeadLetterTargetArn: "arn:aws:sqs:us-east-1:100010001000:local-queue3-dlq"}, sub.RedrivePolicy)
+}
+
+func TestSetSubscriptionAttributesV1_success_RemoveRedrivePolicy(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "Local")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	localTopic1 := models.SyncTopics.Topics["local-topic1"]
+	sub := localTopic1.Subscriptions[0]
+	sub.RedrivePolicy = &models.SubscriptionRedrivePolicy{DeadLetterTargetArn: "arn:aws:sqs:us-east-1:100010001000:local-queue3-dlq"}
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SetSubscriptionAttributesRequest)
+		*v = models.SetSubscriptionAttributesRequest{
+			SubscriptionArn: sub.SubscriptionArn,
+			AttributeName:   "RedrivePolicy",
+			AttributeValue:  "",
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := SetSubscriptionAttributesV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, (*models.SubscriptionRedrivePolicy)(nil), sub.RedrivePolicy)
+}
+
+func TestSetSubscriptionAttributesV1_error_invalid_RedrivePolicy(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "Local")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	localTopic1 := models.SyncTopics.Topics["local-topic1"]
+	sub := localTopic1.Subscriptions[0]
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SetSubscriptionAttributesRequest)
+		*v = models.SetSubscriptionAttributesRequest{
+			SubscriptionArn: sub.SubscriptionArn,
+			AttributeName:   "RedrivePolicy",
+			AttributeValue:  `{"maxReceiveCount": 3}`,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := SetSubscriptionAttributesV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, (*models.SubscriptionRedrivePolicy)(nil), sub.RedrivePolicy)
 }
 
 func TestSetSubscriptionAttributesV1_success_SetFilterPolicyScope(t *testing.T) {
@@ -172,7 +313,7 @@ func TestSetSubscriptionAttributesV1_success_SetFilterPolicyScope(t *testing.T)
 		*v = models.SetSubscriptionAttributesRequest{
 			SubscriptionArn: sub.SubscriptionArn,
 			AttributeName:   "FilterPolicyScope",
-			AttributeValue:  "foo",
+			AttributeValue:  "MessageBody",
 		}
 		return true
 	}
@@ -181,9 +322,10 @@ func TestSetSubscriptionAttributesV1_success_SetFilterPolicyScope(t *testing.T)
 	code, _ := SetSubscriptionAttributesV1(r)
 
 	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, models.FilterPolicyScopeMessageBody, sub.FilterPolicyScope)
 }
 
-func TestSetSubscriptionAttributesV1_success_SetRedrivePolicy(t *testing.T) {
+func TestSetSubscriptionAttributesV1_error_SetFilterPolicyScope_invalid(t *testing.T) {
 	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "Local")
 	defer func() {
 		models.ResetApp()
@@ -197,7 +339,7 @@ func TestSetSubscriptionAttributesV1_success_SetRedrivePolicy(t *testing.T) {
 		v := resultingStruct.(*models.SetSubscriptionAttributesRequest)
 		*v = models.SetSubscriptionAttributesRequest{
 			SubscriptionArn: sub.SubscriptionArn,
-			AttributeName:   "SubscriptionRoleArn",
+			AttributeName:   "FilterPolicyScope",
 			AttributeValue:  "foo",
 		}
 		return true
@@ -206,7 +348,8 @@ func TestSetSubscriptionAttributesV1_success_SetRedrivePolicy(t *testing.T) {
 	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
 	code, _ := SetSubscriptionAttributesV1(r)
 
-	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "", sub.FilterPolicyScope)
 }
 
 func TestSetSubscriptionAttributesV1_success_SetSubscriptionRoleArn(t *testing.T) {
@@ -0,0 +1,78 @@
+package gosns
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+func SetTopicAttributesV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewSetTopicAttributesRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - SetTopicAttributesV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+	}
+
+	attrName := requestBody.AttributeName
+	attrValue := requestBody.AttributeValue
+
+	uriSegments := strings.Split(requestBody.TopicArn, ":")
+	topicName := uriSegments[len(uriSegments)-1]
+
+	models.SyncTopics.Lock()
+	defer models.SyncTopics.Unlock()
+	topic, ok := models.SyncTopics.Topics[topicName]
+	if !ok {
+		return utils.CreateErrorResponseV1("TopicNotFound", false)
+	}
+
+	switch attrName {
+	case "DeliveryPolicy", "Policy", "ArchivePolicy":
+		if attrValue != "" && !json.Valid([]byte(attrValue)) {
+			return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+		}
+	case "SignatureVersion":
+		if attrValue != "1" && attrValue != "2" {
+			return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+		}
+	case "TracingConfig":
+		if attrValue != "Active" && attrValue != "PassThrough" {
+			return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+		}
+	case "ContentBasedDeduplication":
+		if topic.Attributes["FifoTopic"] != "true" {
+			return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+		}
+		if _, err := strconv.ParseBool(attrValue); err != nil {
+			return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+		}
+	case "DisplayName", "KmsMasterKeyId":
+	default:
+		// FifoTopic and the computed attributes can't be changed after the topic is created.
+		return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+	}
+
+	log.Infof("Setting Topic Attribute %s on %s", attrName, topicName)
+	if topic.Attributes == nil {
+		topic.Attributes = make(map[string]string)
+	}
+	if attrValue == "" {
+		delete(topic.Attributes, attrName)
+	} else {
+		topic.Attributes[attrName] = attrValue
+	}
+
+	respStruct := models.SetTopicAttributesResponse{
+		Xmlns:    models.BaseXmlns,
+		Metadata: models.ResponseMetadata{RequestId: uuid.NewString()},
+	}
+	return http.StatusOK, respStruct
+}
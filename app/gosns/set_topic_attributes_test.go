@@ -0,0 +1,179 @@
+package gosns
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetTopicAttributesV1_success(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	cases := map[string]string{
+		"DisplayName":      "display-name",
+		"DeliveryPolicy":   `{"http":{"defaultHealthyRetryPolicy":{"numRetries":5}}}`,
+		"Policy":           `{"Version":"2012-10-17"}`,
+		"SignatureVersion": "2",
+		"TracingConfig":    "PassThrough",
+		"KmsMasterKeyId":   "alias/aws/sns",
+	}
+	for name, value := range cases {
+		utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+			v := resultingStruct.(*models.SetTopicAttributesRequest)
+			*v = models.SetTopicAttributesRequest{
+				TopicArn:       "arn:aws:sns:region:accountID:unit-topic1",
+				AttributeName:  name,
+				AttributeValue: value,
+			}
+			return true
+		}
+
+		_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+		code, res := SetTopicAttributesV1(r)
+
+		_, ok := res.(models.SetTopicAttributesResponse)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, value, models.SyncTopics.Topics["unit-topic1"].Attributes[name])
+	}
+}
+
+func TestSetTopicAttributesV1_success_empty_value_clears_attribute(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	models.SyncTopics.Topics["unit-topic1"].Attributes["DisplayName"] = "display-name"
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SetTopicAttributesRequest)
+		*v = models.SetTopicAttributesRequest{
+			TopicArn:      "arn:aws:sns:region:accountID:unit-topic1",
+			AttributeName: "DisplayName",
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := SetTopicAttributesV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	_, ok := models.SyncTopics.Topics["unit-topic1"].Attributes["DisplayName"]
+	assert.False(t, ok)
+}
+
+func TestSetTopicAttributesV1_error_invalid_values(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	cases := map[string]string{
+		"DeliveryPolicy":            "not-json",
+		"SignatureVersion":          "3",
+		"TracingConfig":             "garbage",
+		"ContentBasedDeduplication": "true",
+		"FifoTopic":                 "true",
+		"SubscriptionsConfirmed":    "10",
+		"garbage":                   "garbage",
+	}
+	for name, value := range cases {
+		utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+			v := resultingStruct.(*models.SetTopicAttributesRequest)
+			*v = models.SetTopicAttributesRequest{
+				TopicArn:       "arn:aws:sns:region:accountID:unit-topic1",
+				AttributeName:  name,
+				AttributeValue: value,
+			}
+			return true
+		}
+		previous := models.SyncTopics.Topics["unit-topic1"].Attributes[name]
+
+		_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+		code, _ := SetTopicAttributesV1(r)
+
+		assert.Equal(t, http.StatusBadRequest, code, name)
+		assert.Equal(t, previous, models.SyncTopics.Topics["unit-topic1"].Attributes[name], name)
+	}
+}
+
+func TestSetTopicAttributesV1_success_content_based_deduplication_on_fifo_topic(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	models.SyncTopics.Topics["unit-topic1"].Attributes["FifoTopic"] = "true"
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SetTopicAttributesRequest)
+		*v = models.SetTopicAttributesRequest{
+			TopicArn:       "arn:aws:sns:region:accountID:unit-topic1",
+			AttributeName:  "ContentBasedDeduplication",
+			AttributeValue: "true",
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := SetTopicAttributesV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "true", models.SyncTopics.Topics["unit-topic1"].Attributes["ContentBasedDeduplication"])
+}
+
+func TestSetTopicAttributesV1_error_topic_not_found(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SetTopicAttributesRequest)
+		*v = models.SetTopicAttributesRequest{
+			TopicArn:       "arn:aws:sns:region:accountID:garbage",
+			AttributeName:  "DisplayName",
+			AttributeValue: "display-name",
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := SetTopicAttributesV1(r)
+	response := res.(models.ErrorResponse)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "AWS.SimpleNotificationService.NonExistentTopic", response.Result.Code)
+}
+
+func TestSetTopicAttributesV1_request_transformer_error(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := SetTopicAttributesV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
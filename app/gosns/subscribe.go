@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/google/uuid"
 
@@ -33,69 +32,64 @@ func SubscribeV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
 		"endpoint":     requestBody.Endpoint,
 		"filterPolicy": requestBody.Attributes.FilterPolicy,
 		"raw":          requestBody.Attributes.RawMessageDelivery,
+		"filterScope":  requestBody.Attributes.FilterPolicyScope,
 	}
 	log.WithFields(extraLogFields).Info("Creating Subscription")
 
-	subscription := &models.Subscription{EndPoint: requestBody.Endpoint, Protocol: requestBody.Protocol, TopicArn: requestBody.TopicArn, Raw: requestBody.Attributes.RawMessageDelivery, FilterPolicy: &requestBody.Attributes.FilterPolicy}
+	// Validated the same way SetSubscriptionAttributes validates them
+	filterPolicyScope := requestBody.Attributes.FilterPolicyScope
+	if filterPolicyScope != "" && filterPolicyScope != models.FilterPolicyScopeMessageAttributes && filterPolicyScope != models.FilterPolicyScopeMessageBody {
+		log.WithFields(extraLogFields).Error("Invalid FilterPolicyScope")
+		return utils.CreateErrorResponseV1("InvalidParameter", false)
+	}
+	if err := requestBody.Attributes.FilterPolicy.Validate(); err != nil {
+		log.WithFields(extraLogFields).Errorf("Invalid FilterPolicy - %s", err)
+		return utils.CreateErrorResponseV1("InvalidParameter", false)
+	}
+
+	subscription := &models.Subscription{EndPoint: requestBody.Endpoint, Protocol: requestBody.Protocol, TopicArn: requestBody.TopicArn, Raw: requestBody.Attributes.RawMessageDelivery, FilterPolicy: &requestBody.Attributes.FilterPolicy, FilterPolicyScope: requestBody.Attributes.FilterPolicyScope, DeliveryPolicy: requestBody.Attributes.DeliveryPolicy, RedrivePolicy: requestBody.Attributes.RedrivePolicy}
 
 	subscription.SubscriptionArn = fmt.Sprintf("%s:%s", requestBody.TopicArn, uuid.NewString())
 
-	//Create the response
-	requestId := uuid.NewString()
-	respStruct := models.SubscribeResponse{Xmlns: models.BaseXmlns, Result: models.SubscribeResult{SubscriptionArn: subscription.SubscriptionArn}, Metadata: models.ResponseMetadata{RequestId: requestId}}
-	if models.SyncTopics.Topics[topicName] != nil {
-		models.SyncTopics.Lock()
-		isDuplicate := false
-		// Duplicate check
-		for _, sub := range models.SyncTopics.Topics[topicName].Subscriptions {
-			if sub.EndPoint == requestBody.Endpoint && sub.TopicArn == requestBody.TopicArn {
-				isDuplicate = true
-				sub.SubscriptionArn = subscription.SubscriptionArn
-			}
-		}
-		if !isDuplicate {
-			models.SyncTopics.Topics[topicName].Subscriptions = append(models.SyncTopics.Topics[topicName].Subscriptions, subscription)
-			log.WithFields(extraLogFields).Debug("Created subscription")
-		}
-		models.SyncTopics.Unlock()
+	// HTTP/S endpoints have to confirm they want the messages before they get any, everything else is good to go.
+	needsConfirmation := models.Protocol(subscription.Protocol) == models.ProtocolHTTP || models.Protocol(subscription.Protocol) == models.ProtocolHTTPS
+	subscription.PendingConfirmation = needsConfirmation
+	subscription.ConfirmationWasAuthenticated = !needsConfirmation
 
-		if models.Protocol(subscription.Protocol) == models.ProtocolHTTP || models.Protocol(subscription.Protocol) == models.ProtocolHTTPS {
-			id := uuid.NewString()
-			token := uuid.NewString()
+	if models.SyncTopics.Topics[topicName] == nil {
+		return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+	}
 
-			TOPIC_DATA[requestBody.TopicArn] = &pendingConfirm{
-				subArn: subscription.SubscriptionArn,
-				token:  token,
-			}
+	models.SyncTopics.Lock()
+	isDuplicate := false
+	// Duplicate check
+	for _, sub := range models.SyncTopics.Topics[topicName].Subscriptions {
+		if sub.EndPoint == requestBody.Endpoint && sub.TopicArn == requestBody.TopicArn {
+			isDuplicate = true
+			sub.SubscriptionArn = subscription.SubscriptionArn
+			subscription = sub
+		}
+	}
+	if !isDuplicate {
+		models.SyncTopics.Topics[topicName].Subscriptions = append(models.SyncTopics.Topics[topicName].Subscriptions, subscription)
+		log.WithFields(extraLogFields).Debug("Created subscription")
+	}
+	subscriptionArn := subscription.SubscriptionArn
+	endpoint := subscription.EndPoint
+	pendingConfirmation := subscription.PendingConfirmation
+	models.SyncTopics.Unlock()
 
-			//QUESTION - do we need this?
-			time.Sleep(time.Second)
+	if pendingConfirmation {
+		// The endpoint is sent the token from the background, so it hears about it after we've answered the caller.
+		token := addPendingConfirm(subscriptionArn, requestBody.TopicArn)
+		go postConfirmation(endpoint, subscriptionArn, subscriptionConfirmation(requestBody.TopicArn, token))
 
-			snsMSG := &models.SNSMessage{
-				Type:             "SubscriptionConfirmation",
-				MessageId:        id,
-				Token:            token,
-				TopicArn:         requestBody.TopicArn,
-				Message:          fmt.Sprintf("You have chosen to subscribe to the topic %s.\nTo confirm the subscription, visit the SubscribeURL included in this message.", requestBody.TopicArn),
-				SigningCertURL:   fmt.Sprintf("http://%s:%s/SimpleNotificationService/%s.pem", models.CurrentEnvironment.Host, models.CurrentEnvironment.Port, requestId),
-				SignatureVersion: "1",
-				SubscribeURL:     fmt.Sprintf("http://%s:%s/?Action=ConfirmSubscription&TopicArn=%s&Token=%s", models.CurrentEnvironment.Host, models.CurrentEnvironment.Port, requestBody.TopicArn, token),
-				Timestamp:        time.Now().UTC().Format(time.RFC3339),
-			}
-			signature, err := signMessage(PrivateKEY, snsMSG)
-			if err != nil {
-				log.Error("Error signing message")
-			} else {
-				snsMSG.Signature = signature
-			}
-			err = callEndpoint(subscription.EndPoint, requestId, *snsMSG, subscription.Raw)
-			if err != nil {
-				log.Error("Error posting to url ", err)
-			}
+		if !requestBody.ReturnSubscriptionArn {
+			subscriptionArn = models.PendingConfirmationSubscriptionArn
 		}
-
-	} else {
-		return utils.CreateErrorResponseV1("InvalidParameterValue", false)
 	}
+
+	//Create the response
+	respStruct := models.SubscribeResponse{Xmlns: models.BaseXmlns, Result: models.SubscribeResult{SubscriptionArn: subscriptionArn}, Metadata: models.ResponseMetadata{RequestId: uuid.NewString()}}
 	return http.StatusOK, respStruct
 }
@@ -1,9 +1,12 @@
 package gosns
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/Admiral-Piett/goaws/app/conf"
 	"github.com/Admiral-Piett/goaws/app/fixtures"
@@ -66,7 +69,7 @@ func TestSubscribeV1_success_with_attributes(t *testing.T) {
 			Endpoint: fmt.Sprintf("%s:%s", fixtures.BASE_URL, "unit-queue2"),
 			Protocol: "sqs",
 			Attributes: models.SubscriptionAttributes{
-				FilterPolicy:       models.FilterPolicy{"filter": []string{"policy"}},
+				FilterPolicy:       models.FilterPolicy{"filter": []interface{}{"policy"}},
 				RawMessageDelivery: true,
 			},
 		}
@@ -87,7 +90,7 @@ func TestSubscribeV1_success_with_attributes(t *testing.T) {
 	subscriptions := models.SyncTopics.Topics["unit-topic2"].Subscriptions
 	assert.Len(t, subscriptions, 1)
 
-	expectedFilterPolicy := models.FilterPolicy{"filter": []string{"policy"}}
+	expectedFilterPolicy := models.FilterPolicy{"filter": []interface{}{"policy"}}
 	assert.Equal(t, fmt.Sprintf("%s:%s", fixtures.BASE_URL, "unit-queue2"), subscriptions[0].EndPoint)
 	assert.Equal(t, &expectedFilterPolicy, subscriptions[0].FilterPolicy)
 	assert.Equal(t, "sqs", subscriptions[0].Protocol)
@@ -167,3 +170,161 @@ func TestSubscribeV1_error_missing_topic(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, code)
 }
+
+func TestSubscribeV1_error_invalid_filter_policy(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SubscribeRequest)
+		*v = models.SubscribeRequest{
+			TopicArn: fmt.Sprintf("%s:%s", fixtures.BASE_SNS_ARN, "unit-topic1"),
+			Endpoint: fmt.Sprintf("%s:%s", fixtures.BASE_SQS_ARN, "subscribed-queue1"),
+			Protocol: "sqs",
+			Attributes: models.SubscriptionAttributes{
+				FilterPolicy: models.FilterPolicy{"foo": "bar"},
+			},
+		}
+		return true
+	}
+	subscriptionCount := len(models.SyncTopics.Topics["unit-topic1"].Subscriptions)
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := SubscribeV1(r)
+	errorResult := response.GetResult().(models.ErrorResult)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "InvalidParameter", errorResult.Type)
+	assert.Len(t, models.SyncTopics.Topics["unit-topic1"].Subscriptions, subscriptionCount)
+}
+
+func TestSubscribeV1_error_invalid_filter_policy_scope(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SubscribeRequest)
+		*v = models.SubscribeRequest{
+			TopicArn: fmt.Sprintf("%s:%s", fixtures.BASE_SNS_ARN, "unit-topic1"),
+			Endpoint: fmt.Sprintf("%s:%s", fixtures.BASE_SQS_ARN, "subscribed-queue1"),
+			Protocol: "sqs",
+			Attributes: models.SubscriptionAttributes{
+				FilterPolicyScope: "MessageHeaders",
+			},
+		}
+		return true
+	}
+	subscriptionCount := len(models.SyncTopics.Topics["unit-topic1"].Subscriptions)
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := SubscribeV1(r)
+	errorResult := response.GetResult().(models.ErrorResult)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "InvalidParameter", errorResult.Type)
+	assert.Len(t, models.SyncTopics.Topics["unit-topic1"].Subscriptions, subscriptionCount)
+}
+
+func TestSubscribeV1_success_http_pending_confirmation(t *testing.T) {
+	confirmations := make(chan models.SNSMessage, 1)
+	subscribedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := models.SNSMessage{}
+		json.NewDecoder(r.Body).Decode(&msg)
+		confirmations <- msg
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+		subscribedServer.Close()
+	}()
+
+	topicArn := fmt.Sprintf("%s:%s", fixtures.BASE_SNS_ARN, "unit-topic2")
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SubscribeRequest)
+		*v = models.SubscribeRequest{
+			TopicArn: topicArn,
+			Endpoint: subscribedServer.URL,
+			Protocol: "http",
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := SubscribeV1(r)
+
+	response, _ := res.(models.SubscribeResponse)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "pending confirmation", response.Result.SubscriptionArn)
+
+	subscriptions := models.SyncTopics.Topics["unit-topic2"].Subscriptions
+	assert.Len(t, subscriptions, 1)
+	assert.True(t, subscriptions[0].PendingConfirmation)
+	assert.False(t, subscriptions[0].ConfirmationWasAuthenticated)
+
+	var confirmation models.SNSMessage
+	select {
+	case confirmation = <-confirmations:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the endpoint never received the confirmation")
+	}
+	assert.Equal(t, "SubscriptionConfirmation", confirmation.Type)
+	assert.Equal(t, topicArn, confirmation.TopicArn)
+	assert.NotEmpty(t, confirmation.Signature)
+	assert.Contains(t, confirmation.SubscribeURL, "Action=ConfirmSubscription")
+	assert.Contains(t, confirmation.SubscribeURL, "Token="+confirmation.Token)
+
+	pending, ok := takePendingConfirm(topicArn, confirmation.Token)
+	assert.True(t, ok)
+	assert.Equal(t, subscriptions[0].SubscriptionArn, pending.subArn)
+}
+
+func TestSubscribeV1_success_http_return_subscription_arn(t *testing.T) {
+	posted := make(chan string, 1)
+	postConfirmation = func(endpoint string, subscriptionArn string, msg models.SNSMessage) {
+		posted <- endpoint
+	}
+
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+		postConfirmation = defaultPostConfirmation
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SubscribeRequest)
+		*v = models.SubscribeRequest{
+			TopicArn:              fmt.Sprintf("%s:%s", fixtures.BASE_SNS_ARN, "unit-topic2"),
+			Endpoint:              "http://localhost:9999/endpoint",
+			Protocol:              "http",
+			ReturnSubscriptionArn: true,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := SubscribeV1(r)
+
+	response, _ := res.(models.SubscribeResponse)
+	subscriptions := models.SyncTopics.Topics["unit-topic2"].Subscriptions
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, subscriptions, 1)
+	assert.Equal(t, subscriptions[0].SubscriptionArn, response.Result.SubscriptionArn)
+	assert.True(t, subscriptions[0].PendingConfirmation)
+
+	select {
+	case endpoint := <-posted:
+		assert.Equal(t, "http://localhost:9999/endpoint", endpoint)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the confirmation was never posted")
+	}
+}
@@ -0,0 +1,56 @@
+package gosns
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TagResourceV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewTagResourceRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - TagResourceV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+	}
+	if requestBody.ResourceArn == "" || len(requestBody.Tags) == 0 {
+		return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+	}
+
+	arnSegments := strings.Split(requestBody.ResourceArn, ":")
+	topicName := arnSegments[len(arnSegments)-1]
+
+	models.SyncTopics.Lock()
+	defer models.SyncTopics.Unlock()
+	topic, ok := models.SyncTopics.Topics[topicName]
+	if !ok {
+		return utils.CreateErrorResponseV1("ResourceNotFound", false)
+	}
+
+	tags := make(map[string]string)
+	for key, value := range topic.Tags {
+		tags[key] = value
+	}
+	for _, tag := range requestBody.Tags {
+		tags[tag.Key] = tag.Value
+	}
+	if len(tags) > models.MaxTopicTags {
+		return utils.CreateErrorResponseV1("TagLimitExceeded", false)
+	}
+
+	log.Infof("Tagging Topic: %s", topicName)
+	topic.Tags = tags
+
+	respStruct := models.TagResourceResponse{
+		Xmlns:    models.BaseXmlns,
+		Metadata: models.ResponseMetadata{RequestId: uuid.NewString()},
+	}
+	return http.StatusOK, respStruct
+}
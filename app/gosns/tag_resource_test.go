@@ -0,0 +1,158 @@
+package gosns
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagResourceV1_success_merges_tags(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.TagResourceRequest)
+		*v = models.TagResourceRequest{
+			ResourceArn: "arn:aws:sns:region:accountID:unit-topic1",
+			Tags: []models.TopicTag{
+				{Key: "team", Value: "billing"},
+				{Key: "env", Value: "dev"},
+			},
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := TagResourceV1(r)
+
+	response, _ := res.(models.TagResourceResponse)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, models.BaseXmlns, response.Xmlns)
+	assert.NotEqual(t, "", response.Metadata.RequestId)
+
+	expectedTags := map[string]string{"team": "billing", "env": "dev"}
+	assert.Equal(t, expectedTags, models.SyncTopics.Topics["unit-topic1"].Tags)
+}
+
+func TestTagResourceV1_success_topic_without_tags(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.TagResourceRequest)
+		*v = models.TagResourceRequest{
+			ResourceArn: "arn:aws:sns:region:accountID:unit-topic2",
+			Tags:        []models.TopicTag{{Key: "env", Value: "dev"}},
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := TagResourceV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]string{"env": "dev"}, models.SyncTopics.Topics["unit-topic2"].Tags)
+}
+
+func TestTagResourceV1_error_topic_not_found(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.TagResourceRequest)
+		*v = models.TagResourceRequest{
+			ResourceArn: "arn:aws:sns:region:accountID:garbage",
+			Tags:        []models.TopicTag{{Key: "env", Value: "dev"}},
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := TagResourceV1(r)
+	response := res.(models.ErrorResponse)
+
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "AWS.SimpleNotificationService.ResourceNotFound", response.Result.Code)
+}
+
+func TestTagResourceV1_error_too_many_tags(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	tags := make([]models.TopicTag, 0, models.MaxTopicTags)
+	for i := 0; i < models.MaxTopicTags; i++ {
+		tags = append(tags, models.TopicTag{Key: fmt.Sprintf("key-%d", i), Value: "value"})
+	}
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.TagResourceRequest)
+		*v = models.TagResourceRequest{
+			ResourceArn: "arn:aws:sns:region:accountID:unit-topic1",
+			Tags:        tags,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := TagResourceV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, map[string]string{"team": "platform"}, models.SyncTopics.Topics["unit-topic1"].Tags)
+}
+
+func TestTagResourceV1_error_no_tags(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.TagResourceRequest)
+		*v = models.TagResourceRequest{
+			ResourceArn: "arn:aws:sns:region:accountID:unit-topic1",
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := TagResourceV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestTagResourceV1_request_transformer_error(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := TagResourceV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
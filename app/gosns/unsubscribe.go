@@ -21,24 +21,41 @@ func UnsubscribeV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
 	}
 
 	log.Infof("Unsubscribe: %s", requestBody.SubscriptionArn)
+	models.SyncTopics.Lock()
+	var removed *models.Subscription
 	for _, topic := range models.SyncTopics.Topics {
 		for i, sub := range topic.Subscriptions {
 			if sub.SubscriptionArn == requestBody.SubscriptionArn {
-				models.SyncTopics.Lock()
+				removed = sub
 
 				copy(topic.Subscriptions[i:], topic.Subscriptions[i+1:])
 				topic.Subscriptions[len(topic.Subscriptions)-1] = nil
 				topic.Subscriptions = topic.Subscriptions[:len(topic.Subscriptions)-1]
-
-				models.SyncTopics.Unlock()
-
-				respStruct := models.UnsubscribeResponse{
-					Xmlns:    models.BaseXmlns,
-					Metadata: models.ResponseMetadata{RequestId: uuid.NewString()},
-				}
-				return http.StatusOK, respStruct
+				topic.SubscriptionsDeleted++
+				break
 			}
 		}
+		if removed != nil {
+			break
+		}
+	}
+	models.SyncTopics.Unlock()
+
+	if removed == nil {
+		return utils.CreateErrorResponseV1("SubscriptionNotFound", false)
+	}
+	removePendingConfirms(requestBody.SubscriptionArn)
+
+	// Endpoints that never confirmed weren't getting messages, so there's nothing to tell them.
+	protocol := models.Protocol(removed.Protocol)
+	if (protocol == models.ProtocolHTTP || protocol == models.ProtocolHTTPS) && !removed.PendingConfirmation {
+		token := addRestoreConfirm(removed)
+		go postConfirmation(removed.EndPoint, removed.SubscriptionArn, unsubscribeConfirmation(removed.TopicArn, removed.SubscriptionArn, token))
+	}
+
+	respStruct := models.UnsubscribeResponse{
+		Xmlns:    models.BaseXmlns,
+		Metadata: models.ResponseMetadata{RequestId: uuid.NewString()},
 	}
-	return utils.CreateErrorResponseV1("SubscriptionNotFound", false)
+	return http.StatusOK, respStruct
 }
@@ -1,8 +1,11 @@
 package gosns
 
 import (
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/Admiral-Piett/goaws/app/fixtures"
 
@@ -40,6 +43,7 @@ func TestUnsubscribeV1_success(t *testing.T) {
 
 	subs := models.SyncTopics.Topics["unit-topic1"].Subscriptions
 	assert.Len(t, subs, 0)
+	assert.Equal(t, 1, models.SyncTopics.Topics["unit-topic1"].SubscriptionsDeleted)
 	assert.True(t, ok)
 }
 
@@ -80,3 +84,80 @@ func TestUnsubscribeV1_invalid_subscription_arn(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, status)
 }
+
+func TestUnsubscribeV1_http_subscription_sends_unsubscribe_confirmation(t *testing.T) {
+	received := make(chan models.SNSMessage, 1)
+	subscribedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := models.SNSMessage{}
+		json.NewDecoder(r.Body).Decode(&msg)
+		received <- msg
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+		subscribedServer.Close()
+	}()
+
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	sub := topic.Subscriptions[0]
+	sub.Protocol = "http"
+	sub.EndPoint = subscribedServer.URL
+	subArn := sub.SubscriptionArn
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.UnsubscribeRequest)
+		*v = models.UnsubscribeRequest{
+			SubscriptionArn: subArn,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("GET", "/", nil, true)
+	status, _ := UnsubscribeV1(r)
+	assert.Equal(t, http.StatusOK, status)
+
+	var msg models.SNSMessage
+	select {
+	case msg = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the endpoint never received the unsubscribe confirmation")
+	}
+	assert.Equal(t, "UnsubscribeConfirmation", msg.Type)
+	assert.Equal(t, topic.Arn, msg.TopicArn)
+	assert.Contains(t, msg.Message, subArn)
+	assert.NotEmpty(t, msg.Token)
+	assert.Contains(t, msg.SubscribeURL, "/?Action=ConfirmSubscription&TopicArn="+topic.Arn+"&Token="+msg.Token)
+	assert.Nil(t, verifySignature(t, &msg))
+}
+
+func TestUnsubscribeV1_pending_http_subscription_is_not_notified(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	subArn := models.SyncTopics.Topics["unit-topic1"].Arn + ":unsubscribe-pending"
+	addPendingSubscription(subArn)
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.UnsubscribeRequest)
+		*v = models.UnsubscribeRequest{
+			SubscriptionArn: subArn,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, _ := UnsubscribeV1(r)
+	assert.Equal(t, http.StatusOK, status)
+
+	pendingConfirms.Lock()
+	defer pendingConfirms.Unlock()
+	for _, pending := range pendingConfirms.byToken {
+		assert.NotEqual(t, subArn, pending.subArn)
+	}
+}
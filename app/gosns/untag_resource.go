@@ -0,0 +1,47 @@
+package gosns
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func UntagResourceV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewUntagResourceRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - UntagResourceV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+	}
+	if requestBody.ResourceArn == "" || len(requestBody.TagKeys) == 0 {
+		return utils.CreateErrorResponseV1("InvalidParameterValue", false)
+	}
+
+	arnSegments := strings.Split(requestBody.ResourceArn, ":")
+	topicName := arnSegments[len(arnSegments)-1]
+
+	models.SyncTopics.Lock()
+	defer models.SyncTopics.Unlock()
+	topic, ok := models.SyncTopics.Topics[topicName]
+	if !ok {
+		return utils.CreateErrorResponseV1("ResourceNotFound", false)
+	}
+
+	log.Infof("Untagging Topic: %s", topicName)
+	for _, key := range requestBody.TagKeys {
+		delete(topic.Tags, key)
+	}
+
+	respStruct := models.UntagResourceResponse{
+		Xmlns:    models.BaseXmlns,
+		Metadata: models.ResponseMetadata{RequestId: uuid.NewString()},
+	}
+	return http.StatusOK, respStruct
+}
@@ -0,0 +1,80 @@
+package gosns
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUntagResourceV1_success(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.UntagResourceRequest)
+		*v = models.UntagResourceRequest{
+			ResourceArn: "arn:aws:sns:region:accountID:unit-topic1",
+			TagKeys:     []string{"team", "missing"},
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := UntagResourceV1(r)
+
+	response, _ := res.(models.UntagResourceResponse)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, models.BaseXmlns, response.Xmlns)
+	assert.Empty(t, models.SyncTopics.Topics["unit-topic1"].Tags)
+}
+
+func TestUntagResourceV1_error_topic_not_found(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.UntagResourceRequest)
+		*v = models.UntagResourceRequest{
+			ResourceArn: "arn:aws:sns:region:accountID:garbage",
+			TagKeys:     []string{"team"},
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, res := UntagResourceV1(r)
+	response := res.(models.ErrorResponse)
+
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "AWS.SimpleNotificationService.ResourceNotFound", response.Result.Code)
+}
+
+func TestUntagResourceV1_request_transformer_error(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := UntagResourceV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,104 @@
+package gosqs
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var permissionLabelPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,80}$`)
+
+// permissionActions are the actions AddPermission can grant.
+// ref: https://docs.aws.amazon.com/AWSSimpleQueueService/latest/APIReference/API_AddPermission.html
+var permissionActions = map[string]bool{
+	"*":                          true,
+	"SendMessage":                true,
+	"ReceiveMessage":             true,
+	"DeleteMessage":              true,
+	"ChangeMessageVisibility":    true,
+	"GetQueueAttributes":         true,
+	"GetQueueUrl":                true,
+	"ListDeadLetterSourceQueues": true,
+	"PurgeQueue":                 true,
+}
+
+// AddPermissionV1 adds a statement, identified by its label, to the queue's Policy that lets the given accounts
+// call the given actions.
+func AddPermissionV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewAddPermissionRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - AddPermissionV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+	if !permissionLabelPattern.MatchString(requestBody.Label) || len(requestBody.AWSAccountIds) == 0 || len(requestBody.Actions) == 0 {
+		log.Error("Missing or invalid Label, AWSAccountIds or Actions - AddPermissionV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+	for _, action := range requestBody.Actions {
+		if !permissionActions[action] {
+			log.Errorf("Invalid action %s - AddPermissionV1", action)
+			return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+		}
+	}
+
+	uriSegments := strings.Split(requestBody.QueueUrl, "/")
+	queueName := uriSegments[len(uriSegments)-1]
+
+	models.SyncQueues.Lock()
+	defer models.SyncQueues.Unlock()
+	queue, ok := models.SyncQueues.Queues[queueName]
+	if !ok {
+		log.Errorf("Add Permission: %s, queue does not exist!!!", queueName)
+		return utils.CreateErrorResponseV1("QueueNotFound", true)
+	}
+
+	policy, err := models.NewQueuePolicy(queue)
+	if err != nil {
+		log.Errorf("Add Permission: %s, the queue's Policy can't be parsed: %s", queueName, err)
+		return utils.CreateErrorResponseV1("InvalidPolicy", true)
+	}
+	if policy.HasStatement(requestBody.Label) {
+		log.Errorf("Add Permission: %s, label %s already exists", queueName, requestBody.Label)
+		return utils.CreateErrorResponseV1("PermissionLabelExists", true)
+	}
+
+	principals := []string{}
+	for _, accountId := range requestBody.AWSAccountIds {
+		principals = append(principals, fmt.Sprintf("arn:aws:iam::%s:root", accountId))
+	}
+	actions := []string{}
+	for _, action := range requestBody.Actions {
+		actions = append(actions, fmt.Sprintf("SQS:%s", action))
+	}
+	// AWS writes single values without wrapping them in a list
+	var principal, action interface{} = principals, actions
+	if len(principals) == 1 {
+		principal = principals[0]
+	}
+	if len(actions) == 1 {
+		action = actions[0]
+	}
+	policy.AddStatement(models.PermissionStatement{
+		Sid:       requestBody.Label,
+		Effect:    "Allow",
+		Principal: map[string]interface{}{"AWS": principal},
+		Action:    action,
+		Resource:  queue.Arn,
+	})
+	queue.Policy = policy.Document()
+
+	log.Infof("Added permission %s to queue: %s", requestBody.Label, queueName)
+	respStruct := models.AddPermissionResponse{
+		Xmlns:    models.BaseXmlns,
+		Metadata: models.BaseResponseMetadata,
+	}
+	return http.StatusOK, respStruct
+}
@@ -0,0 +1,165 @@
+package gosqs
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/fixtures"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func addPermission(request models.AddPermissionRequest) (int, interfaces.AbstractResponseBody) {
+	defaultTransformer := utils.REQUEST_TRANSFORMER
+	defer func() {
+		utils.REQUEST_TRANSFORMER = defaultTransformer
+	}()
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.AddPermissionRequest)
+		*v = request
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	return AddPermissionV1(r)
+}
+
+func TestAddPermissionV1_success(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	queueArn := fmt.Sprintf("%s:%s", fixtures.BASE_SQS_ARN, "unit-queue1")
+	code, response := addPermission(models.AddPermissionRequest{
+		QueueUrl:      fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+		Label:         "send",
+		AWSAccountIds: []string{"111122223333"},
+		Actions:       []string{"SendMessage"},
+	})
+
+	expectedResponse := models.AddPermissionResponse{
+		Xmlns:    models.BaseXmlns,
+		Metadata: models.BaseResponseMetadata,
+	}
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expectedResponse, response)
+
+	code, _ = addPermission(models.AddPermissionRequest{
+		QueueUrl:      fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+		Label:         "receive",
+		AWSAccountIds: []string{"111122223333", "444455556666"},
+		Actions:       []string{"ReceiveMessage", "DeleteMessage"},
+	})
+	assert.Equal(t, http.StatusOK, code)
+
+	expectedPolicy := fmt.Sprintf(`{"Version":"2012-10-17","Id":"%s/SQSDefaultPolicy","Statement":[`+
+		`{"Sid":"send","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111122223333:root"},"Action":"SQS:SendMessage","Resource":"%s"},`+
+		`{"Sid":"receive","Effect":"Allow","Principal":{"AWS":["arn:aws:iam::111122223333:root","arn:aws:iam::444455556666:root"]},"Action":["SQS:ReceiveMessage","SQS:DeleteMessage"],"Resource":"%s"}]}`,
+		queueArn, queueArn, queueArn)
+	assert.Equal(t, expectedPolicy, models.SyncQueues.Queues["unit-queue1"].Policy)
+}
+
+func TestAddPermissionV1_success_keeps_existing_policy(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	models.SyncQueues.Queues["unit-queue1"].Policy = `{"Version": "2012-10-17", "Id": "mine", "Statement": [{"Sid": "existing", "Effect": "Deny", "Principal": "*", "Action": "SQS:*"}]}`
+
+	code, _ := addPermission(models.AddPermissionRequest{
+		QueueUrl:      fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+		Label:         "send",
+		AWSAccountIds: []string{"111122223333"},
+		Actions:       []string{"*"},
+	})
+
+	assert.Equal(t, http.StatusOK, code)
+	expectedPolicy := fmt.Sprintf(`{"Version":"2012-10-17","Id":"mine","Statement":[`+
+		`{"Sid":"existing","Effect":"Deny","Principal":"*","Action":"SQS:*"},`+
+		`{"Sid":"send","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111122223333:root"},"Action":"SQS:*","Resource":"%s:%s"}]}`,
+		fixtures.BASE_SQS_ARN, "unit-queue1")
+	assert.Equal(t, expectedPolicy, models.SyncQueues.Queues["unit-queue1"].Policy)
+}
+
+func TestAddPermissionV1_error_label_exists(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	request := models.AddPermissionRequest{
+		QueueUrl:      fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+		Label:         "send",
+		AWSAccountIds: []string{"111122223333"},
+		Actions:       []string{"SendMessage"},
+	}
+	code, _ := addPermission(request)
+	assert.Equal(t, http.StatusOK, code)
+	policy := models.SyncQueues.Queues["unit-queue1"].Policy
+
+	code, response := addPermission(request)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, models.SqsErrors["PermissionLabelExists"].Message, response.(models.ErrorResponse).Result.Message)
+	assert.Equal(t, policy, models.SyncQueues.Queues["unit-queue1"].Policy)
+}
+
+func TestAddPermissionV1_error_invalid_request(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	queueUrl := fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1")
+	requests := []models.AddPermissionRequest{
+		{QueueUrl: queueUrl, AWSAccountIds: []string{"111122223333"}, Actions: []string{"SendMessage"}},
+		{QueueUrl: queueUrl, Label: "bad label", AWSAccountIds: []string{"111122223333"}, Actions: []string{"SendMessage"}},
+		{QueueUrl: queueUrl, Label: "send", Actions: []string{"SendMessage"}},
+		{QueueUrl: queueUrl, Label: "send", AWSAccountIds: []string{"111122223333"}},
+		{QueueUrl: queueUrl, Label: "send", AWSAccountIds: []string{"111122223333"}, Actions: []string{"CreateQueue"}},
+	}
+	for i, request := range requests {
+		code, _ := addPermission(request)
+		assert.Equal(t, http.StatusBadRequest, code, "case %d", i)
+	}
+	assert.Equal(t, "", models.SyncQueues.Queues["unit-queue1"].Policy)
+}
+
+func TestAddPermissionV1_error_queue_not_found(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	code, response := addPermission(models.AddPermissionRequest{
+		QueueUrl:      fmt.Sprintf("%s/%s", fixtures.BASE_URL, "missing-queue"),
+		Label:         "send",
+		AWSAccountIds: []string{"111122223333"},
+		Actions:       []string{"SendMessage"},
+	})
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, models.SqsErrors["QueueNotFound"].Code, response.(models.ErrorResponse).Result.Code)
+}
+
+func TestAddPermissionV1_request_transformer_error(t *testing.T) {
+	defer func() {
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := AddPermissionV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,44 @@
+package gosqs
+
+import (
+	"net/http"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+func CancelMessageMoveTaskV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewCancelMessageMoveTaskRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - CancelMessageMoveTaskV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+
+	messageMoveTasks.Lock()
+	defer messageMoveTasks.Unlock()
+	var task *messageMoveTask
+	for _, t := range messageMoveTasks.tasks {
+		if t.handle == requestBody.TaskHandle {
+			task = t
+			break
+		}
+	}
+	if task == nil {
+		return utils.CreateErrorResponseV1("ResourceNotFoundException", true)
+	}
+	if task.status != moveTaskRunning {
+		return utils.CreateErrorResponseV1("MessageMoveTaskNotRunning", true)
+	}
+	task.status = moveTaskCancelling
+	close(task.cancel)
+
+	respStruct := models.CancelMessageMoveTaskResponse{
+		Xmlns:    models.BaseXmlns,
+		Result:   models.CancelMessageMoveTaskResult{ApproximateNumberOfMessagesMoved: task.moved},
+		Metadata: models.BaseResponseMetadata,
+	}
+	return http.StatusOK, respStruct
+}
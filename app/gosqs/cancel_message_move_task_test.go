@@ -0,0 +1,77 @@
+package gosqs
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func cancelMessageMoveTask(t *testing.T, taskHandle string) (int, interfaces.AbstractResponseBody) {
+	defaultTransformer := utils.REQUEST_TRANSFORMER
+	defer func() {
+		utils.REQUEST_TRANSFORMER = defaultTransformer
+	}()
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.CancelMessageMoveTaskRequest)
+		*v = models.CancelMessageMoveTaskRequest{TaskHandle: taskHandle}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	return CancelMessageMoveTaskV1(r)
+}
+
+func TestCancelMessageMoveTaskV1_success(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	dlq := models.SyncQueues.Queues["dead-letter-queue1"]
+	dlq.Messages = []models.SqsMessage{
+		{Uuid: "1", DeadLetterSourceQueue: "unit-queue2"},
+		{Uuid: "2", DeadLetterSourceQueue: "unit-queue2"},
+		{Uuid: "3", DeadLetterSourceQueue: "unit-queue2"},
+	}
+	task, ok := startMessageMoveTask(dlq.Arn, "", 1, len(dlq.Messages))
+	assert.True(t, ok)
+
+	code, response := cancelMessageMoveTask(t, task.handle)
+
+	assert.Equal(t, http.StatusOK, code)
+	moved := response.(models.CancelMessageMoveTaskResponse).Result.ApproximateNumberOfMessagesMoved
+	assert.Less(t, moved, int64(3))
+	assert.Equal(t, moveTaskCancelled, waitForMoveTask(t, dlq.Arn))
+
+	// A task can only be cancelled once
+	code, _ = cancelMessageMoveTask(t, task.handle)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestCancelMessageMoveTaskV1_error_unknown_task(t *testing.T) {
+	code, response := cancelMessageMoveTask(t, "garbage")
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "ResourceNotFoundException", response.(models.ErrorResponse).Result.Code)
+}
+
+func TestCancelMessageMoveTaskV1_request_transformer_error(t *testing.T) {
+	defer func() {
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := CancelMessageMoveTaskV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
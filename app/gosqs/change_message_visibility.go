@@ -31,51 +31,52 @@ func ChangeMessageVisibilityV1(req *http.Request) (int, interfaces.AbstractRespo
 		queueName = uriSegments[len(uriSegments)-1]
 	}
 
-	receiptHandle := requestBody.ReceiptHandle
-
-	visibilityTimeout := requestBody.VisibilityTimeout
-	if visibilityTimeout > 43200 {
-		return utils.CreateErrorResponseV1("ValidationError", true)
-	}
-
 	if _, ok := models.SyncQueues.Queues[queueName]; !ok {
 		return utils.CreateErrorResponseV1("QueueNotFound", true)
 	}
 
 	models.SyncQueues.Lock()
-	messageFound := false
-	for i := 0; i < len(models.SyncQueues.Queues[queueName].Messages); i++ {
-		queue := models.SyncQueues.Queues[queueName]
+	errKey := changeMessageVisibility(models.SyncQueues.Queues[queueName], requestBody.ReceiptHandle, requestBody.VisibilityTimeout)
+	models.SyncQueues.Unlock()
+	if errKey != "" {
+		return utils.CreateErrorResponseV1(errKey, true)
+	}
+
+	respStruct := models.ChangeMessageVisibilityResult{
+		Xmlns:    models.BaseXmlns,
+		Metadata: models.BaseResponseMetadata,
+	}
+
+	return http.StatusOK, &respStruct
+}
+
+// changeMessageVisibility sets the visibility timeout of the in-flight message with the given receipt handle.  It
+// returns the key of the error to report, or "" on success.  The caller must hold the SyncQueues lock.
+func changeMessageVisibility(queue *models.Queue, receiptHandle string, visibilityTimeout int) string {
+	if visibilityTimeout < 0 || visibilityTimeout > 43200 {
+		return "InvalidVisibilityTimeout"
+	}
+
+	for i := 0; i < len(queue.Messages); i++ {
 		msgs := queue.Messages
 		if msgs[i].ReceiptHandle == receiptHandle {
-			timeout := models.SyncQueues.Queues[queueName].VisibilityTimeout
+			timeout := queue.VisibilityTimeout
 			if visibilityTimeout == 0 {
 				msgs[i].ReceiptTime = time.Now().UTC()
 				msgs[i].ReceiptHandle = ""
 				msgs[i].VisibilityTimeout = time.Now().Add(time.Duration(timeout) * time.Second)
-				msgs[i].Retry++
+				// The message is no longer in flight, so its FIFO group can be received from again
+				queue.UnlockGroup(msgs[i].GroupID)
 				if queue.MaxReceiveCount > 0 &&
 					queue.DeadLetterQueue != nil &&
-					msgs[i].Retry >= queue.MaxReceiveCount {
-					queue.DeadLetterQueue.Messages = append(queue.DeadLetterQueue.Messages, msgs[i])
-					queue.Messages = append(queue.Messages[:i], queue.Messages[i+1:]...)
+					msgs[i].NumberOfReceives >= queue.MaxReceiveCount {
+					queue.MoveToDeadLetterQueue(i)
 				}
 			} else {
 				msgs[i].VisibilityTimeout = time.Now().Add(time.Duration(visibilityTimeout) * time.Second)
 			}
-			messageFound = true
-			break
+			return ""
 		}
 	}
-	models.SyncQueues.Unlock()
-	if !messageFound {
-		return utils.CreateErrorResponseV1("MessageNotInFlight", true)
-	}
-
-	respStruct := models.ChangeMessageVisibilityResult{
-		Xmlns:    models.BaseXmlns,
-		Metadata: models.BaseResponseMetadata,
-	}
-
-	return http.StatusOK, &respStruct
+	return "MessageNotInFlight"
 }
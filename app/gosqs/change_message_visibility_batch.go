@@ -0,0 +1,84 @@
+package gosqs
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+func ChangeMessageVisibilityBatchV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewChangeMessageVisibilityBatchRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - ChangeMessageVisibilityBatchV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+
+	queueUrl := requestBody.QueueUrl
+
+	queueName := ""
+	if queueUrl == "" {
+		vars := mux.Vars(req)
+		queueName = vars["queueName"]
+	} else {
+		uriSegments := strings.Split(queueUrl, "/")
+		queueName = uriSegments[len(uriSegments)-1]
+	}
+
+	if _, ok := models.SyncQueues.Queues[queueName]; !ok {
+		return utils.CreateErrorResponseV1("QueueNotFound", true)
+	}
+
+	if len(requestBody.Entries) == 0 {
+		return utils.CreateErrorResponseV1("EmptyBatchRequest", true)
+	}
+
+	if len(requestBody.Entries) > 10 {
+		return utils.CreateErrorResponseV1("TooManyEntriesInBatchRequest", true)
+	}
+
+	ids := map[string]bool{}
+	for _, v := range requestBody.Entries {
+		if _, found := ids[v.Id]; found {
+			return utils.CreateErrorResponseV1("BatchEntryIdsNotDistinct", true)
+		}
+		ids[v.Id] = true
+	}
+
+	models.SyncQueues.Lock()
+	defer models.SyncQueues.Unlock()
+
+	queue := models.SyncQueues.Queues[queueName]
+	successfulEntries := make([]models.ChangeMessageVisibilityBatchResultEntry, 0)
+	failedEntries := make([]models.BatchResultErrorEntry, 0)
+	for _, entry := range requestBody.Entries {
+		errKey := changeMessageVisibility(queue, entry.ReceiptHandle, entry.VisibilityTimeout)
+		if errKey != "" {
+			er := models.SqsErrors[errKey]
+			failedEntries = append(failedEntries, models.BatchResultErrorEntry{
+				Code:        er.Code,
+				Id:          entry.Id,
+				Message:     er.Message,
+				SenderFault: true,
+			})
+			continue
+		}
+		successfulEntries = append(successfulEntries, models.ChangeMessageVisibilityBatchResultEntry{Id: entry.Id})
+	}
+
+	respStruct := models.ChangeMessageVisibilityBatchResponse{
+		Xmlns: models.BaseXmlns,
+		Result: models.ChangeMessageVisibilityBatchResult{
+			Successful: successfulEntries,
+			Failed:     failedEntries,
+		},
+		Metadata: models.BaseResponseMetadata,
+	}
+
+	return http.StatusOK, respStruct
+}
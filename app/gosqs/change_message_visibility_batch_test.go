@@ -0,0 +1,229 @@
+package gosqs
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/fixtures"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeMessageVisibilityBatchV1_success_all_messages(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	q := &models.Queue{
+		Name:              "testing",
+		VisibilityTimeout: 30,
+		Messages: []models.SqsMessage{
+			{MessageBody: "test1", ReceiptHandle: "handle1"},
+			{MessageBody: "test2", ReceiptHandle: "handle2", NumberOfReceives: 1},
+		},
+	}
+	models.SyncQueues.Queues["testing"] = q
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ChangeMessageVisibilityBatchRequest)
+		*v = models.ChangeMessageVisibilityBatchRequest{
+			Entries: []models.ChangeMessageVisibilityBatchRequestEntry{
+				{Id: "change-1", ReceiptHandle: "handle1", VisibilityTimeout: 600},
+				{Id: "change-2", ReceiptHandle: "handle2", VisibilityTimeout: 0},
+			},
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "testing"),
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, response := ChangeMessageVisibilityBatchV1(r)
+
+	result := response.(models.ChangeMessageVisibilityBatchResponse).Result
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []models.ChangeMessageVisibilityBatchResultEntry{{Id: "change-1"}, {Id: "change-2"}}, result.Successful)
+	assert.Empty(t, result.Failed)
+
+	assert.True(t, q.Messages[0].VisibilityTimeout.After(time.Now().Add(590*time.Second)))
+	assert.Equal(t, "handle1", q.Messages[0].ReceiptHandle)
+	// A timeout of 0 releases the message, the same as ChangeMessageVisibility
+	assert.Equal(t, "", q.Messages[1].ReceiptHandle)
+	assert.Equal(t, 1, q.Messages[1].NumberOfReceives)
+}
+
+func TestChangeMessageVisibilityBatchV1_success_with_failed_entries(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	q := &models.Queue{
+		Name: "testing",
+		Messages: []models.SqsMessage{
+			{MessageBody: "test1", ReceiptHandle: "handle1"},
+			{MessageBody: "test2", ReceiptHandle: "handle2"},
+		},
+	}
+	models.SyncQueues.Queues["testing"] = q
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ChangeMessageVisibilityBatchRequest)
+		*v = models.ChangeMessageVisibilityBatchRequest{
+			Entries: []models.ChangeMessageVisibilityBatchRequestEntry{
+				{Id: "change-1", ReceiptHandle: "handle1", VisibilityTimeout: 60},
+				{Id: "change-2", ReceiptHandle: "garbage", VisibilityTimeout: 60},
+				{Id: "change-3", ReceiptHandle: "handle2", VisibilityTimeout: 43201},
+			},
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "testing"),
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, response := ChangeMessageVisibilityBatchV1(r)
+
+	result := response.(models.ChangeMessageVisibilityBatchResponse).Result
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []models.ChangeMessageVisibilityBatchResultEntry{{Id: "change-1"}}, result.Successful)
+	assert.Equal(t, []models.BatchResultErrorEntry{
+		{
+			Code:        "AWS.SimpleQueueService.MessageNotInFlight",
+			Id:          "change-2",
+			Message:     "The message referred to isn't in flight.",
+			SenderFault: true,
+		},
+		{
+			Code:        "AWS.SimpleQueueService.ValidationError",
+			Id:          "change-3",
+			Message:     "The visibility timeout is incorrect",
+			SenderFault: true,
+		},
+	}, result.Failed)
+	assert.Zero(t, q.Messages[1].VisibilityTimeout)
+}
+
+func TestChangeMessageVisibilityBatchV1_error_not_found_queue(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ChangeMessageVisibilityBatchRequest)
+		*v = models.ChangeMessageVisibilityBatchRequest{
+			Entries: []models.ChangeMessageVisibilityBatchRequestEntry{
+				{Id: "change-1", ReceiptHandle: "handle1", VisibilityTimeout: 60},
+			},
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "not-exist-queue"),
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, _ := ChangeMessageVisibilityBatchV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestChangeMessageVisibilityBatchV1_error_no_entry(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ChangeMessageVisibilityBatchRequest)
+		*v = models.ChangeMessageVisibilityBatchRequest{
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, _ := ChangeMessageVisibilityBatchV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestChangeMessageVisibilityBatchV1_error_too_many_entries(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	entries := []models.ChangeMessageVisibilityBatchRequestEntry{}
+	for i := 1; i <= 11; i++ {
+		entries = append(entries, models.ChangeMessageVisibilityBatchRequestEntry{
+			Id:                fmt.Sprintf("change-%d", i),
+			ReceiptHandle:     fmt.Sprintf("handle%d", i),
+			VisibilityTimeout: 60,
+		})
+	}
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ChangeMessageVisibilityBatchRequest)
+		*v = models.ChangeMessageVisibilityBatchRequest{
+			Entries:  entries,
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, _ := ChangeMessageVisibilityBatchV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestChangeMessageVisibilityBatchV1_error_ids_not_distinct(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ChangeMessageVisibilityBatchRequest)
+		*v = models.ChangeMessageVisibilityBatchRequest{
+			Entries: []models.ChangeMessageVisibilityBatchRequestEntry{
+				{Id: "change-1", ReceiptHandle: "handle1", VisibilityTimeout: 60},
+				{Id: "change-1", ReceiptHandle: "handle2", VisibilityTimeout: 60},
+			},
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, _ := ChangeMessageVisibilityBatchV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestChangeMessageVisibilityBatchV1_Error_transformer(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, _ := ChangeMessageVisibilityBatchV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+}
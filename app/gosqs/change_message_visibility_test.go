@@ -3,6 +3,7 @@ package gosqs
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/Admiral-Piett/goaws/app/test"
 
@@ -21,8 +22,9 @@ func TestChangeMessageVisibility_success(t *testing.T) {
 	q := &models.Queue{
 		Name: "testing",
 		Messages: []models.SqsMessage{{
-			MessageBody:   "test1",
-			ReceiptHandle: "123",
+			MessageBody:      "test1",
+			ReceiptHandle:    "123",
+			NumberOfReceives: 1,
 		}},
 	}
 	models.SyncQueues.Queues["testing"] = q
@@ -47,7 +49,7 @@ func TestChangeMessageVisibility_success(t *testing.T) {
 	assert.NotZero(t, q.Messages[0].VisibilityTimeout)
 	assert.NotZero(t, q.Messages[0].ReceiptTime)
 	assert.Equal(t, "", q.Messages[0].ReceiptHandle)
-	assert.Equal(t, 1, q.Messages[0].Retry)
+	assert.Equal(t, 1, q.Messages[0].NumberOfReceives)
 }
 
 func TestChangeMessageVisibility_success_adds_to_existing_visibility_timeout(t *testing.T) {
@@ -55,7 +57,128 @@ func TestChangeMessageVisibility_success_adds_to_existing_visibility_timeout(t *
 }
 
 func TestChangeMessageVisibility_success_transfers_to_dead_letter_queue(t *testing.T) {
-	// TODO
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+	}()
+
+	dlq := &models.Queue{Name: "testing-dlq"}
+	q := &models.Queue{
+		Name:            "testing",
+		DeadLetterQueue: dlq,
+		MaxReceiveCount: 2,
+		Messages: []models.SqsMessage{
+			{MessageBody: "test1", ReceiptHandle: "123", NumberOfReceives: 1},
+			{MessageBody: "test2", ReceiptHandle: "456", NumberOfReceives: 2},
+		},
+	}
+	models.SyncQueues.Queues["testing"] = q
+	models.SyncQueues.Queues["testing-dlq"] = dlq
+
+	for _, receiptHandle := range []string{"123", "456"} {
+		_, r := test.GenerateRequestInfo("POST", "/", models.ChangeMessageVisibilityRequest{
+			QueueUrl:          "http://localhost:4100/queue/testing",
+			ReceiptHandle:     receiptHandle,
+			VisibilityTimeout: 0,
+		}, true)
+		status, _ := ChangeMessageVisibilityV1(r)
+		assert.Equal(t, http.StatusOK, status)
+	}
+
+	// Only the message received MaxReceiveCount times is dead-lettered
+	assert.Len(t, q.Messages, 1)
+	assert.Equal(t, "test1", q.Messages[0].MessageBody)
+	assert.Len(t, dlq.Messages, 1)
+	assert.Equal(t, "test2", dlq.Messages[0].MessageBody)
+	assert.Equal(t, 2, dlq.Messages[0].NumberOfReceives)
+}
+
+func TestChangeMessageVisibility_success_fifo_message_can_be_received_again(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+	}()
+
+	q := &models.Queue{
+		Name:                "testing.fifo",
+		VisibilityTimeout:   30,
+		IsFIFO:              true,
+		FIFOMessages:        map[string]int{},
+		FIFOSequenceNumbers: map[string]int{},
+		Duplicates:          map[string]time.Time{},
+		Messages: []models.SqsMessage{
+			{MessageBody: "test1", Uuid: "uuid-1", GroupID: "A"},
+			{MessageBody: "test2", Uuid: "uuid-2", GroupID: "B"},
+		},
+	}
+	models.SyncQueues.Queues["testing.fifo"] = q
+
+	_, r := test.GenerateRequestInfo("POST", "/", models.ReceiveMessageRequest{
+		QueueUrl:            "http://localhost:4100/queue/testing.fifo",
+		MaxNumberOfMessages: 1,
+	}, true)
+	_, resp := ReceiveMessageV1(r)
+	result := resp.GetResult().(models.ReceiveMessageResult)
+	if len(result.Messages) != 1 {
+		t.Fatalf("expected to receive the first FIFO message, got %d", len(result.Messages))
+	}
+	assert.Equal(t, "test1", result.Messages[0].Body)
+
+	_, r = test.GenerateRequestInfo("POST", "/", models.ChangeMessageVisibilityRequest{
+		QueueUrl:          "http://localhost:4100/queue/testing.fifo",
+		ReceiptHandle:     result.Messages[0].ReceiptHandle,
+		VisibilityTimeout: 0,
+	}, true)
+	status, _ := ChangeMessageVisibilityV1(r)
+	assert.Equal(t, http.StatusOK, status)
+	assert.False(t, q.IsLocked("A"))
+
+	// Locking another group must not keep group A locked
+	q.LockGroup("B")
+
+	_, r = test.GenerateRequestInfo("POST", "/", models.ReceiveMessageRequest{
+		QueueUrl:            "http://localhost:4100/queue/testing.fifo",
+		MaxNumberOfMessages: 1,
+	}, true)
+	_, resp = ReceiveMessageV1(r)
+	result = resp.GetResult().(models.ReceiveMessageResult)
+	if len(result.Messages) != 1 {
+		t.Fatalf("expected the FIFO message to be received again, got %d", len(result.Messages))
+	}
+	assert.Equal(t, "test1", result.Messages[0].Body)
+}
+
+func TestChangeMessageVisibility_success_transfers_fifo_message_to_dead_letter_queue_unlocks_group(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+	}()
+
+	dlq := &models.Queue{Name: "testing-dlq.fifo", IsFIFO: true}
+	q := &models.Queue{
+		Name:            "testing.fifo",
+		IsFIFO:          true,
+		DeadLetterQueue: dlq,
+		MaxReceiveCount: 1,
+		FIFOMessages:    map[string]int{"A": 0},
+		Messages: []models.SqsMessage{
+			{MessageBody: "test1", ReceiptHandle: "123", GroupID: "A", NumberOfReceives: 1},
+		},
+	}
+	models.SyncQueues.Queues["testing.fifo"] = q
+	models.SyncQueues.Queues["testing-dlq.fifo"] = dlq
+
+	_, r := test.GenerateRequestInfo("POST", "/", models.ChangeMessageVisibilityRequest{
+		QueueUrl:          "http://localhost:4100/queue/testing.fifo",
+		ReceiptHandle:     "123",
+		VisibilityTimeout: 0,
+	}, true)
+	status, _ := ChangeMessageVisibilityV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, q.Messages)
+	assert.Len(t, dlq.Messages, 1)
+	assert.False(t, q.IsLocked("A"))
 }
 
 func TestChangeMessageVisibility_request_transformer_error(t *testing.T) {
@@ -73,3 +196,29 @@ func TestChangeMessageVisibility_missing_queue(t *testing.T) {
 func TestChangeMessageVisibility_missing_message(t *testing.T) {
 	// TODO - mismatch receipt handle
 }
+
+func TestChangeMessageVisibility_invalid_visibility_timeout(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+	}()
+
+	q := &models.Queue{
+		Name: "testing",
+		Messages: []models.SqsMessage{{
+			MessageBody:   "test1",
+			ReceiptHandle: "123",
+		}},
+	}
+	models.SyncQueues.Queues["testing"] = q
+
+	_, r := test.GenerateRequestInfo("POST", "/", models.ChangeMessageVisibilityRequest{
+		QueueUrl:          "http://localhost:4100/queue/testing",
+		ReceiptHandle:     "123",
+		VisibilityTimeout: 43201,
+	}, true)
+	status, _ := ChangeMessageVisibilityV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Zero(t, q.Messages[0].VisibilityTimeout)
+}
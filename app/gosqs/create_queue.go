@@ -36,6 +36,7 @@ func CreateQueueV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
 			IsFIFO:           utils.HasFIFOQueueName(queueName),
 			EnableDuplicates: models.CurrentEnvironment.EnableDuplicates,
 			Duplicates:       make(map[string]time.Time),
+			Tags:             requestBody.Tags,
 		}
 		if err := setQueueAttributesV1(queue, requestBody.Attributes); err != nil {
 			return utils.CreateErrorResponseV1(err.Error(), true)
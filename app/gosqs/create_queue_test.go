@@ -85,7 +85,10 @@ func TestCreateQueueV1_success_with_redrive_policy(t *testing.T) {
 		MessageRetentionPeriod:        3,
 		DeadLetterQueue:               dlq,
 		MaxReceiveCount:               100,
+		RedriveAllowPolicy:            &models.RedriveAllowPolicy{RedrivePermission: "allowAll"},
+		Policy:                        `{"this-is":"the-policy"}`,
 		Duplicates:                    make(map[string]time.Time),
+		Tags:                          map[string]string{"my": "tag"},
 	}
 
 	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
@@ -163,6 +166,7 @@ func TestCreateQueueV1_success_with_no_request_attributes_falls_back_to_default(
 		MaximumMessageSize:            0,
 		MessageRetentionPeriod:        0,
 		Duplicates:                    make(map[string]time.Time),
+		Tags:                          map[string]string{"my": "tag"},
 	}
 
 	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
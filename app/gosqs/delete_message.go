@@ -46,7 +46,7 @@ func DeleteMessageV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
 				models.SyncQueues.Queues[queueName].UnlockGroup(msg.GroupID)
 				//Delete message from Q
 				models.SyncQueues.Queues[queueName].Messages = append(models.SyncQueues.Queues[queueName].Messages[:i], models.SyncQueues.Queues[queueName].Messages[i+1:]...)
-				delete(models.SyncQueues.Queues[queueName].Duplicates, msg.DeduplicationID)
+				models.SyncQueues.Queues[queueName].RemoveDuplicate(msg.GroupID, msg.DeduplicationID)
 
 				// Create, encode/xml and send response
 				respStruct := models.DeleteMessageResponse{
@@ -73,7 +73,7 @@ func DeleteMessageBatchV1(req *http.Request) (int, interfaces.AbstractResponseBo
 			// Unlock messages for the group
 			log.Debugf("FIFO Queue %s unlocking group %s:", queueName, message.GroupID)
 			models.SyncQueues.Queues[queueName].UnlockGroup(message.GroupID)
-			delete(models.SyncQueues.Queues[queueName].Duplicates, message.DeduplicationID)
+			models.SyncQueues.Queues[queueName].RemoveDuplicate(message.GroupID, message.DeduplicationID)
 			deleteEntry.Deleted = true
 			deletedEntries = append(deletedEntries, models.DeleteMessageBatchResultEntry{Id: deleteEntry.Id})
 		} else {
@@ -1,6 +1,7 @@
 package gosqs
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -112,15 +113,35 @@ func GetQueueAttributesV1(req *http.Request) (int, interfaces.AbstractResponseBo
 		attr := models.Attribute{Name: "QueueArn", Value: queue.Arn}
 		queueAttributes = append(queueAttributes, attr)
 	}
-	// TODO - implement
-	//if _, ok := includedAttributes["Policy"]; ok {
-	//	attr := models.Attribute{Name: "Policy", Value: ""}
-	//	queueAttributes = append(queueAttributes, attr)
-	//}
-	//if _, ok := includedAttributes["RedriveAllowPolicy"]; ok {
-	//	attr := models.Attribute{Name: "RedriveAllowPolicy", Value: ""}
-	//	queueAttributes = append(queueAttributes, attr)
-	//}
+	if _, ok := includedAttributes["Policy"]; ok && queue.Policy != "" {
+		attr := models.Attribute{Name: "Policy", Value: queue.Policy}
+		queueAttributes = append(queueAttributes, attr)
+	}
+	if _, ok := includedAttributes["RedriveAllowPolicy"]; ok && queue.RedriveAllowPolicy != nil {
+		policy, _ := json.Marshal(queue.RedriveAllowPolicy)
+		attr := models.Attribute{Name: "RedriveAllowPolicy", Value: string(policy)}
+		queueAttributes = append(queueAttributes, attr)
+	}
+	if _, ok := includedAttributes["ContentBasedDeduplication"]; ok && queue.IsFIFO {
+		attr := models.Attribute{Name: "ContentBasedDeduplication", Value: strconv.FormatBool(queue.ContentBasedDeduplication)}
+		queueAttributes = append(queueAttributes, attr)
+	}
+	if _, ok := includedAttributes["DeduplicationScope"]; ok && queue.IsFIFO {
+		deduplicationScope := queue.DeduplicationScope
+		if deduplicationScope == "" {
+			deduplicationScope = models.DeduplicationScopeQueue
+		}
+		attr := models.Attribute{Name: "DeduplicationScope", Value: deduplicationScope}
+		queueAttributes = append(queueAttributes, attr)
+	}
+	if _, ok := includedAttributes["FifoThroughputLimit"]; ok && queue.IsFIFO {
+		fifoThroughputLimit := queue.FifoThroughputLimit
+		if fifoThroughputLimit == "" {
+			fifoThroughputLimit = models.FifoThroughputLimitPerQueue
+		}
+		attr := models.Attribute{Name: "FifoThroughputLimit", Value: fifoThroughputLimit}
+		queueAttributes = append(queueAttributes, attr)
+	}
 	if _, ok := includedAttributes["RedrivePolicy"]; ok && queue.DeadLetterQueue != nil {
 		attr := models.Attribute{Name: "RedrivePolicy", Value: fmt.Sprintf(`{"maxReceiveCount":"%d", "deadLetterTargetArn":"%s"}`, queue.MaxReceiveCount, queue.DeadLetterQueue.Arn)}
 		queueAttributes = append(queueAttributes, attr)
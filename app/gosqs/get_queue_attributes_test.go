@@ -93,6 +93,144 @@ func TestGetQueueAttributesV1_success_all_with_redrive_queue(t *testing.T) {
 	assert.Equal(t, expectedResponse, response)
 }
 
+func TestGetQueueAttributesV1_success_redrive_allow_policy(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	models.SyncQueues.Queues["dead-letter-queue1"].RedriveAllowPolicy = &models.RedriveAllowPolicy{
+		RedrivePermission: "byQueue",
+		SourceQueueArns:   []string{fmt.Sprintf("%s:%s", fixtures.BASE_SQS_ARN, "unit-queue2")},
+	}
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.GetQueueAttributesRequest)
+		*v = models.GetQueueAttributesRequest{
+			QueueUrl:       "dead-letter-queue1",
+			AttributeNames: []string{"RedriveAllowPolicy"},
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := GetQueueAttributesV1(r)
+
+	expectedAttrs := []models.Attribute{
+		{
+			Name:  "RedriveAllowPolicy",
+			Value: fmt.Sprintf(`{"redrivePermission":"byQueue","sourceQueueArns":["%s:%s"]}`, fixtures.BASE_SQS_ARN, "unit-queue2"),
+		},
+	}
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expectedAttrs, response.(models.GetQueueAttributesResponse).Result.Attrs)
+}
+
+func TestGetQueueAttributesV1_success_policy(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	policy := `{"Version": "2012-10-17", "Statement": []}`
+	models.SyncQueues.Queues["unit-queue1"].Policy = policy
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.GetQueueAttributesRequest)
+		*v = models.GetQueueAttributesRequest{
+			QueueUrl:       "unit-queue1",
+			AttributeNames: []string{"Policy"},
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := GetQueueAttributesV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []models.Attribute{{Name: "Policy", Value: policy}}, response.(models.GetQueueAttributesResponse).Result.Attrs)
+}
+
+func TestGetQueueAttributesV1_success_content_based_deduplication_on_fifo_queues_only(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	models.SyncQueues.Queues["unit-queue1.fifo"] = &models.Queue{Name: "unit-queue1.fifo", IsFIFO: true, ContentBasedDeduplication: true}
+	queueUrl := "unit-queue1.fifo"
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.GetQueueAttributesRequest)
+		*v = models.GetQueueAttributesRequest{
+			QueueUrl:       queueUrl,
+			AttributeNames: []string{"ContentBasedDeduplication"},
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := GetQueueAttributesV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []models.Attribute{{Name: "ContentBasedDeduplication", Value: "true"}}, response.(models.GetQueueAttributesResponse).Result.Attrs)
+
+	queueUrl = "unit-queue1"
+	code, response = GetQueueAttributesV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, response.(models.GetQueueAttributesResponse).Result.Attrs)
+}
+
+func TestGetQueueAttributesV1_success_fifo_throughput_defaults(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	models.SyncQueues.Queues["unit-queue1.fifo"] = &models.Queue{Name: "unit-queue1.fifo", IsFIFO: true}
+	models.SyncQueues.Queues["unit-queue2.fifo"] = &models.Queue{
+		Name:                "unit-queue2.fifo",
+		IsFIFO:              true,
+		DeduplicationScope:  "messageGroup",
+		FifoThroughputLimit: "perMessageGroupId",
+	}
+	queueUrl := "unit-queue1.fifo"
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.GetQueueAttributesRequest)
+		*v = models.GetQueueAttributesRequest{
+			QueueUrl:       queueUrl,
+			AttributeNames: []string{"DeduplicationScope", "FifoThroughputLimit"},
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := GetQueueAttributesV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []models.Attribute{
+		{Name: "DeduplicationScope", Value: "queue"},
+		{Name: "FifoThroughputLimit", Value: "perQueue"},
+	}, response.(models.GetQueueAttributesResponse).Result.Attrs)
+
+	queueUrl = "unit-queue2.fifo"
+	code, response = GetQueueAttributesV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []models.Attribute{
+		{Name: "DeduplicationScope", Value: "messageGroup"},
+		{Name: "FifoThroughputLimit", Value: "perMessageGroupId"},
+	}, response.(models.GetQueueAttributesResponse).Result.Attrs)
+
+	queueUrl = "unit-queue1"
+	code, response = GetQueueAttributesV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, response.(models.GetQueueAttributesResponse).Result.Attrs)
+}
+
 func TestGetQueueAttributesV1_success_specific_fields(t *testing.T) {
 	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
 	defer func() {
@@ -30,22 +30,36 @@ func PeriodicTasks(d time.Duration, quit chan bool) {
 					}
 				}
 
+				for attemptId, attempt := range queue.ReceiveAttempts {
+					if time.Now().After(attempt.Created.Add(models.DeduplicationPeriod)) {
+						delete(queue.ReceiveAttempts, attemptId)
+					}
+				}
+
 				log.Debugf("Queue [%s] length [%d]", queue.Name, len(queue.Messages))
 				for i := 0; i < len(queue.Messages); i++ {
 					msg := &queue.Messages[i]
 
+					if msg.IsExpired(queue.MessageRetentionPeriod) {
+						log.Debugf("Message [%s] in queue [%s] exceeded retention period, deleting", msg.Uuid, queue.Name)
+						if msg.ReceiptHandle != "" {
+							queue.UnlockGroup(msg.GroupID)
+						}
+						queue.Messages = append(queue.Messages[:i], queue.Messages[i+1:]...)
+						i--
+						continue
+					}
+
 					if msg.ReceiptHandle != "" {
 						if msg.VisibilityTimeout.Before(time.Now()) {
 							log.Debugf("Making message visible again %s", msg.ReceiptHandle)
 							queue.UnlockGroup(msg.GroupID)
 							msg.ReceiptHandle = ""
 							msg.ReceiptTime = time.Now().UTC()
-							msg.Retry++
 							if queue.MaxReceiveCount > 0 &&
 								queue.DeadLetterQueue != nil &&
-								msg.Retry >= queue.MaxReceiveCount {
-								queue.DeadLetterQueue.Messages = append(queue.DeadLetterQueue.Messages, *msg)
-								queue.Messages = append(queue.Messages[:i], queue.Messages[i+1:]...)
+								msg.NumberOfReceives >= queue.MaxReceiveCount {
+								queue.MoveToDeadLetterQueue(i)
 								i--
 							}
 						}
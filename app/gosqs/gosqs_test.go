@@ -57,6 +57,40 @@ func Test_PeriodicTasks_deletes_deduplication_period_upon_expiration(t *testing.
 	assert.Eventually(t, assertions, 10*time.Second, 10*time.Millisecond)
 }
 
+func Test_PeriodicTasks_deletes_receive_attempts_upon_expiration(t *testing.T) {
+	models.DeduplicationPeriod = 20 * time.Millisecond
+	quit := make(chan bool)
+	defer func() {
+		models.ResetApp()
+		quit <- true
+		models.DeduplicationPeriod = 5 * time.Minute
+	}()
+
+	qName := "gosqs-receive-attempt-queue1.fifo"
+	mainQueue := &models.Queue{
+		Name:   qName,
+		URL:    fmt.Sprintf("%s/%s", fixtures.BASE_URL, qName),
+		Arn:    fmt.Sprintf("%s:%s", fixtures.BASE_SQS_ARN, qName),
+		IsFIFO: true,
+		ReceiveAttempts: map[string]models.ReceiveAttempt{
+			"attempt-1": {ReceiptHandles: []string{"receipt-1"}, Created: time.Now()},
+		},
+	}
+	models.SyncQueues.Lock()
+	models.SyncQueues.Queues[qName] = mainQueue
+	models.SyncQueues.Unlock()
+
+	go PeriodicTasks(10*time.Millisecond, quit)
+
+	assertions := func() bool {
+		models.SyncQueues.Lock()
+		defer models.SyncQueues.Unlock()
+
+		return 0 == len(mainQueue.ReceiveAttempts)
+	}
+	assert.Eventually(t, assertions, 10*time.Second, 10*time.Millisecond)
+}
+
 func Test_PeriodicTasks_VisibilityTimeout_expires(t *testing.T) {
 	quit := make(chan bool)
 	defer func() {
@@ -72,6 +106,7 @@ func Test_PeriodicTasks_VisibilityTimeout_expires(t *testing.T) {
 	mainQueue.Messages = append(mainQueue.Messages, models.SqsMessage{
 		MessageBody:       "1",
 		ReceiptHandle:     "12345",
+		NumberOfReceives:  1,
 		VisibilityTimeout: time.Now().Add(30 * time.Millisecond),
 	})
 
@@ -97,7 +132,8 @@ func Test_PeriodicTasks_VisibilityTimeout_expires(t *testing.T) {
 		if !ok {
 			return false
 		}
-		ok = 1 == mainQueue.Messages[0].Retry
+		// Only receiving the message counts, not it becoming visible again
+		ok = 1 == mainQueue.Messages[0].NumberOfReceives
 		if !ok {
 			return false
 		}
@@ -133,7 +169,7 @@ func Test_PeriodicTasks_moves_single_message_to_dead_letter_queue_upon_passing_r
 	models.SyncQueues.Lock()
 	mainQueue.Messages = append(mainQueue.Messages, models.SqsMessage{
 		MessageBody:       "1",
-		Retry:             100,
+		NumberOfReceives:  100,
 		ReceiptHandle:     "12345",
 		VisibilityTimeout: time.Now().Add(10 * time.Millisecond),
 	})
@@ -175,14 +211,14 @@ func Test_PeriodicTasks_moves_multiple_messages_to_dead_letter_queue_upon_passin
 
 	models.SyncQueues.Lock()
 	mainQueue.Messages = append(mainQueue.Messages, models.SqsMessage{
-		MessageBody:   "1",
-		Retry:         100,
-		ReceiptHandle: "12345",
+		MessageBody:      "1",
+		NumberOfReceives: 100,
+		ReceiptHandle:    "12345",
 	})
 	mainQueue.Messages = append(mainQueue.Messages, models.SqsMessage{
-		MessageBody:   "2",
-		Retry:         100,
-		ReceiptHandle: "23456",
+		MessageBody:      "2",
+		NumberOfReceives: 100,
+		ReceiptHandle:    "23456",
 	})
 	models.SyncQueues.Unlock()
 
@@ -207,6 +243,57 @@ func Test_PeriodicTasks_moves_multiple_messages_to_dead_letter_queue_upon_passin
 	assert.Eventually(t, assertions, 10*time.Second, 10*time.Millisecond)
 }
 
+func Test_PeriodicTasks_deletes_messages_past_retention_period(t *testing.T) {
+	quit := make(chan bool)
+	defer func() {
+		models.ResetApp()
+		quit <- true
+	}()
+
+	qName := "gosqs-retention-queue1"
+	mainQueue := &models.Queue{
+		Name:                   qName,
+		URL:                    fmt.Sprintf("%s/%s", fixtures.BASE_URL, qName),
+		Arn:                    fmt.Sprintf("%s:%s", fixtures.BASE_SQS_ARN, qName),
+		MessageRetentionPeriod: 60,
+	}
+	mainQueue.Messages = append(mainQueue.Messages, models.SqsMessage{
+		MessageBody: "expired",
+		SentTime:    time.Now().Add(-2 * time.Minute),
+	})
+	mainQueue.Messages = append(mainQueue.Messages, models.SqsMessage{
+		MessageBody:   "expired-in-flight",
+		ReceiptHandle: "12345",
+		SentTime:      time.Now().Add(-2 * time.Minute),
+	})
+	mainQueue.Messages = append(mainQueue.Messages, models.SqsMessage{
+		MessageBody: "fresh",
+		SentTime:    time.Now(),
+	})
+
+	models.SyncQueues.Lock()
+	models.SyncQueues.Queues[qName] = mainQueue
+	models.SyncQueues.Unlock()
+
+	go PeriodicTasks(10*time.Millisecond, quit)
+
+	assertions := func() bool {
+		models.SyncQueues.Lock()
+		defer models.SyncQueues.Unlock()
+
+		ok := 1 == len(mainQueue.Messages)
+		if !ok {
+			return false
+		}
+		ok = "fresh" == mainQueue.Messages[0].MessageBody
+		if !ok {
+			return false
+		}
+		return true
+	}
+	assert.Eventually(t, assertions, 10*time.Second, 10*time.Millisecond)
+}
+
 // TODO - I think all these below belong in handler tests, not in here.  Double check the relevant
 // handlers for coverage and delete.
 func TestSendingAndReceivingFromFIFOQueueReturnsSameMessageOnError(t *testing.T) {
@@ -241,6 +328,7 @@ func TestSendingAndReceivingFromFIFOQueueReturnsSameMessageOnError(t *testing.T)
 	form.Add("QueueUrl", "http://localhost:4100/queue/requeue-reset.fifo")
 	form.Add("MessageBody", "1")
 	form.Add("MessageGroupId", "GROUP-X")
+	form.Add("MessageDeduplicationId", "1")
 	form.Add("Version", "2012-11-05")
 	req.PostForm = form
 
@@ -261,6 +349,7 @@ func TestSendingAndReceivingFromFIFOQueueReturnsSameMessageOnError(t *testing.T)
 	form.Add("QueueUrl", "http://localhost:4100/queue/requeue-reset.fifo")
 	form.Add("MessageBody", "2")
 	form.Add("MessageGroupId", "GROUP-X")
+	form.Add("MessageDeduplicationId", "2")
 	form.Add("Version", "2012-11-05")
 	req.PostForm = form
 
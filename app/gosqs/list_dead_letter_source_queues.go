@@ -0,0 +1,80 @@
+package gosqs
+
+import (
+	"encoding/base64"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+// ListDeadLetterSourceQueuesV1 lists the queues whose RedrivePolicy sends messages to the given queue.  Results
+// come back sorted by URL, and the NextToken is the last URL of the page, so paging stays stable as queues are
+// created and deleted in between calls.
+//
+//	https://docs.aws.amazon.com/AWSSimpleQueueService/latest/APIReference/API_ListDeadLetterSourceQueues.html
+func ListDeadLetterSourceQueuesV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewListDeadLetterSourceQueuesRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - ListDeadLetterSourceQueuesV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+
+	queueName := ""
+	if requestBody.QueueUrl == "" {
+		vars := mux.Vars(req)
+		queueName = vars["queueName"]
+	} else {
+		uriSegments := strings.Split(requestBody.QueueUrl, "/")
+		queueName = uriSegments[len(uriSegments)-1]
+	}
+
+	if requestBody.MaxResults < 0 || requestBody.MaxResults > 1000 {
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+	after := ""
+	if requestBody.NextToken != "" {
+		decoded, err := base64.StdEncoding.DecodeString(requestBody.NextToken)
+		if err != nil {
+			return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+		}
+		after = string(decoded)
+	}
+
+	models.SyncQueues.RLock()
+	if _, ok := models.SyncQueues.Queues[queueName]; !ok {
+		models.SyncQueues.RUnlock()
+		return utils.CreateErrorResponseV1("QueueNotFound", true)
+	}
+	queueUrls := make([]string, 0)
+	for _, queue := range models.SyncQueues.Queues {
+		if queue.DeadLetterQueue != nil && queue.DeadLetterQueue.Name == queueName && queue.URL > after {
+			queueUrls = append(queueUrls, queue.URL)
+		}
+	}
+	models.SyncQueues.RUnlock()
+	sort.Strings(queueUrls)
+
+	nextToken := ""
+	if requestBody.MaxResults > 0 && len(queueUrls) > requestBody.MaxResults {
+		queueUrls = queueUrls[:requestBody.MaxResults]
+		nextToken = base64.StdEncoding.EncodeToString([]byte(queueUrls[len(queueUrls)-1]))
+	}
+
+	respStruct := models.ListDeadLetterSourceQueuesResponse{
+		Xmlns:    models.BaseXmlns,
+		Metadata: models.BaseResponseMetadata,
+		Result: models.ListDeadLetterSourceQueuesResult{
+			QueueUrls: queueUrls,
+			NextToken: nextToken,
+		},
+	}
+
+	return http.StatusOK, respStruct
+}
@@ -0,0 +1,185 @@
+package gosqs
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/fixtures"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListDeadLetterSourceQueuesV1_success(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListDeadLetterSourceQueuesRequest)
+		*v = models.ListDeadLetterSourceQueuesRequest{
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "dead-letter-queue1"),
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := ListDeadLetterSourceQueuesV1(r)
+	result := response.(models.ListDeadLetterSourceQueuesResponse).Result
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue2")}, result.QueueUrls)
+	assert.Equal(t, "", result.NextToken)
+}
+
+func TestListDeadLetterSourceQueuesV1_success_no_source_queues(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListDeadLetterSourceQueuesRequest)
+		*v = models.ListDeadLetterSourceQueuesRequest{
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := ListDeadLetterSourceQueuesV1(r)
+	result := response.(models.ListDeadLetterSourceQueuesResponse).Result
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{}, result.QueueUrls)
+}
+
+func TestListDeadLetterSourceQueuesV1_success_paginates(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	dlq := models.SyncQueues.Queues["dead-letter-queue1"]
+	models.SyncQueues.Queues["unit-queue1"].DeadLetterQueue = dlq
+	models.SyncQueues.Queues["subscribed-queue1"].DeadLetterQueue = dlq
+
+	request := models.ListDeadLetterSourceQueuesRequest{
+		QueueUrl:   fmt.Sprintf("%s/%s", fixtures.BASE_URL, "dead-letter-queue1"),
+		MaxResults: 2,
+	}
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListDeadLetterSourceQueuesRequest)
+		*v = request
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := ListDeadLetterSourceQueuesV1(r)
+	firstPage := response.(models.ListDeadLetterSourceQueuesResponse).Result
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{
+		fmt.Sprintf("%s/%s", fixtures.BASE_URL, "subscribed-queue1"),
+		fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+	}, firstPage.QueueUrls)
+	assert.NotEmpty(t, firstPage.NextToken)
+
+	request.NextToken = firstPage.NextToken
+	code, response = ListDeadLetterSourceQueuesV1(r)
+	secondPage := response.(models.ListDeadLetterSourceQueuesResponse).Result
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue2")}, secondPage.QueueUrls)
+	assert.Equal(t, "", secondPage.NextToken)
+}
+
+func TestListDeadLetterSourceQueuesV1_error_queue_not_found(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListDeadLetterSourceQueuesRequest)
+		*v = models.ListDeadLetterSourceQueuesRequest{
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "garbage"),
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := ListDeadLetterSourceQueuesV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestListDeadLetterSourceQueuesV1_error_invalid_max_results(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListDeadLetterSourceQueuesRequest)
+		*v = models.ListDeadLetterSourceQueuesRequest{
+			QueueUrl:   fmt.Sprintf("%s/%s", fixtures.BASE_URL, "dead-letter-queue1"),
+			MaxResults: 1001,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := ListDeadLetterSourceQueuesV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestListDeadLetterSourceQueuesV1_error_invalid_next_token(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListDeadLetterSourceQueuesRequest)
+		*v = models.ListDeadLetterSourceQueuesRequest{
+			QueueUrl:  fmt.Sprintf("%s/%s", fixtures.BASE_URL, "dead-letter-queue1"),
+			NextToken: "not base64!",
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := ListDeadLetterSourceQueuesV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestListDeadLetterSourceQueuesV1_request_transformer_error(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := ListDeadLetterSourceQueuesV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
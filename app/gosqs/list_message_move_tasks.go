@@ -0,0 +1,67 @@
+package gosqs
+
+import (
+	"net/http"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// ListMessageMoveTasksV1 lists the most recent message move tasks for a source queue, newest first.
+func ListMessageMoveTasksV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewListMessageMoveTasksRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - ListMessageMoveTasksV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+
+	maxResults := requestBody.MaxResults
+	if maxResults == 0 {
+		maxResults = 1
+	}
+	if maxResults < 1 || maxResults > 10 {
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+
+	models.SyncQueues.RLock()
+	_, ok = models.SyncQueues.Queues[queueNameFromArn(requestBody.SourceArn)]
+	models.SyncQueues.RUnlock()
+	if !ok {
+		return utils.CreateErrorResponseV1("ResourceNotFoundException", true)
+	}
+
+	results := make([]models.ListMessageMoveTasksResultEntry, 0)
+	messageMoveTasks.Lock()
+	for i := len(messageMoveTasks.tasks) - 1; i >= 0 && len(results) < maxResults; i-- {
+		task := messageMoveTasks.tasks[i]
+		if task.sourceArn != requestBody.SourceArn {
+			continue
+		}
+		entry := models.ListMessageMoveTasksResultEntry{
+			Status:                            task.status,
+			SourceArn:                         task.sourceArn,
+			DestinationArn:                    task.destinationArn,
+			MaxNumberOfMessagesPerSecond:      task.maxPerSecond,
+			ApproximateNumberOfMessagesMoved:  task.moved,
+			ApproximateNumberOfMessagesToMove: task.toMove,
+			FailureReason:                     task.failureReason,
+			StartedTimestamp:                  task.started.UnixMilli(),
+		}
+		// The handle is only good for cancelling, so it's only handed out while the task is running.
+		if task.status == moveTaskRunning {
+			entry.TaskHandle = task.handle
+		}
+		results = append(results, entry)
+	}
+	messageMoveTasks.Unlock()
+
+	respStruct := models.ListMessageMoveTasksResponse{
+		Xmlns:    models.BaseXmlns,
+		Result:   models.ListMessageMoveTasksResult{Results: results},
+		Metadata: models.BaseResponseMetadata,
+	}
+	return http.StatusOK, respStruct
+}
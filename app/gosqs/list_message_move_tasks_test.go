@@ -0,0 +1,149 @@
+package gosqs
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListMessageMoveTasksV1_success(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	dlq := models.SyncQueues.Queues["dead-letter-queue1"]
+	dlq.Messages = []models.SqsMessage{{Uuid: "1", DeadLetterSourceQueue: "unit-queue2"}}
+	_, ok := startMessageMoveTask(dlq.Arn, "", 0, 1)
+	assert.True(t, ok)
+	assert.Equal(t, moveTaskCompleted, waitForMoveTask(t, dlq.Arn))
+
+	dlq.Messages = []models.SqsMessage{
+		{Uuid: "2", DeadLetterSourceQueue: "unit-queue2"},
+		{Uuid: "3", DeadLetterSourceQueue: "unit-queue2"},
+	}
+	running, ok := startMessageMoveTask(dlq.Arn, "", 1, 2)
+	assert.True(t, ok)
+	defer func() {
+		cancelMessageMoveTask(t, running.handle)
+		waitForMoveTask(t, dlq.Arn)
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListMessageMoveTasksRequest)
+		*v = models.ListMessageMoveTasksRequest{
+			SourceArn:  dlq.Arn,
+			MaxResults: 10,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := ListMessageMoveTasksV1(r)
+	results := response.(models.ListMessageMoveTasksResponse).Result.Results
+
+	assert.Equal(t, http.StatusOK, code)
+	// Other tests may have run tasks on the same queue, but these two are the newest
+	assert.GreaterOrEqual(t, len(results), 2)
+	assert.Equal(t, moveTaskRunning, results[0].Status)
+	assert.Equal(t, running.handle, results[0].TaskHandle)
+	assert.Equal(t, 1, results[0].MaxNumberOfMessagesPerSecond)
+	assert.Equal(t, int64(2), results[0].ApproximateNumberOfMessagesToMove)
+	assert.Equal(t, dlq.Arn, results[0].SourceArn)
+	assert.NotZero(t, results[0].StartedTimestamp)
+
+	assert.Equal(t, moveTaskCompleted, results[1].Status)
+	assert.Equal(t, "", results[1].TaskHandle)
+	assert.Equal(t, int64(1), results[1].ApproximateNumberOfMessagesMoved)
+	assert.Equal(t, int64(1), results[1].ApproximateNumberOfMessagesToMove)
+}
+
+func TestListMessageMoveTasksV1_success_defaults_to_one_result(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	dlq := models.SyncQueues.Queues["dead-letter-queue1"]
+	for i := 0; i < 2; i++ {
+		_, ok := startMessageMoveTask(dlq.Arn, "", 0, 0)
+		assert.True(t, ok)
+		waitForMoveTask(t, dlq.Arn)
+	}
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListMessageMoveTasksRequest)
+		*v = models.ListMessageMoveTasksRequest{SourceArn: dlq.Arn}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := ListMessageMoveTasksV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, response.(models.ListMessageMoveTasksResponse).Result.Results, 1)
+}
+
+func TestListMessageMoveTasksV1_error_invalid_max_results(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListMessageMoveTasksRequest)
+		*v = models.ListMessageMoveTasksRequest{
+			SourceArn:  models.SyncQueues.Queues["dead-letter-queue1"].Arn,
+			MaxResults: 11,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := ListMessageMoveTasksV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestListMessageMoveTasksV1_error_queue_not_found(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListMessageMoveTasksRequest)
+		*v = models.ListMessageMoveTasksRequest{SourceArn: "arn:aws:sqs:region:accountID:garbage"}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := ListMessageMoveTasksV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestListMessageMoveTasksV1_request_transformer_error(t *testing.T) {
+	defer func() {
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := ListMessageMoveTasksV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,52 @@
+package gosqs
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func ListQueueTagsV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewListQueueTagsRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - ListQueueTagsV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+
+	uriSegments := strings.Split(requestBody.QueueUrl, "/")
+	queueName := uriSegments[len(uriSegments)-1]
+
+	models.SyncQueues.RLock()
+	defer models.SyncQueues.RUnlock()
+	queue, ok := models.SyncQueues.Queues[queueName]
+	if !ok {
+		log.Errorf("List Queue Tags: %s, queue does not exist!!!", queueName)
+		return utils.CreateErrorResponseV1("QueueNotFound", true)
+	}
+
+	// Sort the keys so the XML responses come back in a stable order
+	keys := make([]string, 0, len(queue.Tags))
+	for key := range queue.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tags := make([]models.QueueTag, 0, len(keys))
+	for _, key := range keys {
+		tags = append(tags, models.QueueTag{Key: key, Value: queue.Tags[key]})
+	}
+
+	respStruct := models.ListQueueTagsResponse{
+		Xmlns:    models.BaseXmlns,
+		Result:   models.ListQueueTagsResult{Tags: tags},
+		Metadata: models.BaseResponseMetadata,
+	}
+	return http.StatusOK, respStruct
+}
@@ -0,0 +1,108 @@
+package gosqs
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/fixtures"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListQueueTagsV1_success(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListQueueTagsRequest)
+		*v = models.ListQueueTagsRequest{
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+		}
+		return true
+	}
+
+	models.SyncQueues.Queues["unit-queue1"].Tags = map[string]string{"team": "platform", "env": "local"}
+
+	expectedResponse := models.ListQueueTagsResponse{
+		Xmlns: models.BaseXmlns,
+		Result: models.ListQueueTagsResult{Tags: []models.QueueTag{
+			{Key: "env", Value: "local"},
+			{Key: "team", Value: "platform"},
+		}},
+		Metadata: models.BaseResponseMetadata,
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := ListQueueTagsV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expectedResponse, response)
+}
+
+func TestListQueueTagsV1_success_no_tags(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListQueueTagsRequest)
+		*v = models.ListQueueTagsRequest{
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := ListQueueTagsV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, response.(models.ListQueueTagsResponse).Result.Tags)
+}
+
+func TestListQueueTagsV1_request_transformer_error(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := ListQueueTagsV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestListQueueTagsV1_requested_queue_does_not_exist(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.ListQueueTagsRequest)
+		*v = models.ListQueueTagsRequest{
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "garbage"),
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := ListQueueTagsV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
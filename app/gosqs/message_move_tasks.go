@@ -0,0 +1,170 @@
+package gosqs
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/google/uuid"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	moveTaskRunning    = "RUNNING"
+	moveTaskCompleted  = "COMPLETED"
+	moveTaskCancelling = "CANCELLING"
+	moveTaskCancelled  = "CANCELLED"
+	moveTaskFailed     = "FAILED"
+)
+
+// messageMoveTask moves messages out of a dead-letter queue, either back to the queues they came from or to a
+// single destination.
+// ref: https://docs.aws.amazon.com/AWSSimpleQueueService/latest/APIReference/API_StartMessageMoveTask.html
+type messageMoveTask struct {
+	handle         string
+	sourceArn      string
+	destinationArn string
+	maxPerSecond   int
+	status         string
+	moved          int64
+	toMove         int64
+	failureReason  string
+	started        time.Time
+	cancel         chan struct{}
+}
+
+// messageMoveTasks keeps every task that was started, oldest first, so finished tasks can still be listed.
+var messageMoveTasks = struct {
+	sync.Mutex
+	tasks []*messageMoveTask
+}{}
+
+func queueNameFromArn(arn string) string {
+	arnSegments := strings.Split(arn, ":")
+	return arnSegments[len(arnSegments)-1]
+}
+
+// startMessageMoveTask starts moving messages in the background.  It returns false if the source queue already has
+// a task running.
+func startMessageMoveTask(sourceArn string, destinationArn string, maxPerSecond int, toMove int) (*messageMoveTask, bool) {
+	messageMoveTasks.Lock()
+	defer messageMoveTasks.Unlock()
+	for _, task := range messageMoveTasks.tasks {
+		if task.sourceArn == sourceArn && (task.status == moveTaskRunning || task.status == moveTaskCancelling) {
+			return nil, false
+		}
+	}
+
+	taskId := uuid.NewString()
+	task := &messageMoveTask{
+		handle:         base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(`{"taskId":"%s","sourceArn":"%s"}`, taskId, sourceArn))),
+		sourceArn:      sourceArn,
+		destinationArn: destinationArn,
+		maxPerSecond:   maxPerSecond,
+		status:         moveTaskRunning,
+		toMove:         int64(toMove),
+		started:        time.Now(),
+		cancel:         make(chan struct{}),
+	}
+	messageMoveTasks.tasks = append(messageMoveTasks.tasks, task)
+	go task.run()
+	return task, true
+}
+
+func (t *messageMoveTask) run() {
+	var interval time.Duration
+	if t.maxPerSecond > 0 {
+		interval = time.Second / time.Duration(t.maxPerSecond)
+	}
+	sourceName := queueNameFromArn(t.sourceArn)
+	destinationName := ""
+	if t.destinationArn != "" {
+		destinationName = queueNameFromArn(t.destinationArn)
+	}
+
+	for {
+		select {
+		case <-t.cancel:
+			t.finish(moveTaskCancelled, "")
+			return
+		default:
+		}
+
+		moved, failureReason := moveNextMessage(sourceName, destinationName)
+		if failureReason != "" {
+			t.finish(moveTaskFailed, failureReason)
+			return
+		}
+		if !moved {
+			t.finish(moveTaskCompleted, "")
+			return
+		}
+
+		messageMoveTasks.Lock()
+		t.moved++
+		done := t.moved >= t.toMove
+		messageMoveTasks.Unlock()
+		// Only the messages that were there when the task started are moved, so a destination that dead-letters
+		// straight back into the source can't keep the task going forever.
+		if done {
+			t.finish(moveTaskCompleted, "")
+			return
+		}
+
+		if interval > 0 {
+			select {
+			case <-t.cancel:
+				t.finish(moveTaskCancelled, "")
+				return
+			case <-time.After(interval):
+			}
+		}
+	}
+}
+
+func (t *messageMoveTask) finish(status string, failureReason string) {
+	messageMoveTasks.Lock()
+	defer messageMoveTasks.Unlock()
+	t.status = status
+	t.failureReason = failureReason
+	log.Debugf("Message move task from %s finished - Status: %s, Moved: %d", t.sourceArn, status, t.moved)
+}
+
+// moveNextMessage moves the first message that isn't in flight out of the source queue.  It reports false when
+// there's nothing left to move, and the reason the task has to stop if the message has nowhere to go.
+func moveNextMessage(sourceName string, destinationName string) (bool, string) {
+	models.SyncQueues.Lock()
+	defer models.SyncQueues.Unlock()
+	source, ok := models.SyncQueues.Queues[sourceName]
+	if !ok {
+		return false, models.SqsErrors["QueueNotFound"].Code
+	}
+
+	for i, msg := range source.Messages {
+		if msg.ReceiptHandle != "" {
+			continue
+		}
+		targetName := destinationName
+		if targetName == "" {
+			targetName = msg.DeadLetterSourceQueue
+		}
+		destination, ok := models.SyncQueues.Queues[targetName]
+		if !ok {
+			return false, models.SqsErrors["QueueNotFound"].Code
+		}
+
+		msg.DeadLetterSourceQueue = ""
+		msg.NumberOfReceives = 0
+		msg.FirstReceiveTime = time.Time{}
+		msg.ReceiptTime = time.Time{}
+		msg.VisibilityTimeout = time.Time{}
+		source.Messages = append(source.Messages[:i], source.Messages[i+1:]...)
+		destination.Messages = append(destination.Messages, msg)
+		return true, ""
+	}
+	return false, ""
+}
@@ -1,6 +1,7 @@
 package gosqs
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -9,11 +10,47 @@ import (
 	"github.com/Admiral-Piett/goaws/app/models"
 )
 
-// TODO - Support:
-//   - attr.MessageRetentionPeriod
-//   - attr.Policy
-//   - attr.RedriveAllowPolicy
 func setQueueAttributesV1(q *models.Queue, attr models.QueueAttributes) error {
+	if attr.Policy != nil && *attr.Policy != "" && !json.Valid([]byte(*attr.Policy)) {
+		log.Error("Invalid Policy Attribute")
+		return fmt.Errorf("InvalidPolicy")
+	}
+	if attr.RedriveAllowPolicy != nil && !attr.RedriveAllowPolicy.IsValid() {
+		log.Error("Invalid RedriveAllowPolicy Attribute")
+		return fmt.Errorf("InvalidRedriveAllowPolicy")
+	}
+	fifoOnly := attr.ContentBasedDeduplication != nil || attr.DeduplicationScope != "" || attr.FifoThroughputLimit != ""
+	if fifoOnly && !q.IsFIFO {
+		log.Error("ContentBasedDeduplication, DeduplicationScope and FifoThroughputLimit are only supported by FIFO queues")
+		return fmt.Errorf("InvalidAttributeName")
+	}
+	deduplicationScope := q.DeduplicationScope
+	if attr.DeduplicationScope != "" {
+		deduplicationScope = attr.DeduplicationScope
+	}
+	fifoThroughputLimit := q.FifoThroughputLimit
+	if attr.FifoThroughputLimit != "" {
+		fifoThroughputLimit = attr.FifoThroughputLimit
+	}
+	if err := models.ValidateFifoThroughput(deduplicationScope, fifoThroughputLimit); err != nil {
+		log.Errorf("Invalid FIFO throughput attributes - DeduplicationScope: %s, FifoThroughputLimit: %s", deduplicationScope, fifoThroughputLimit)
+		return err
+	}
+	var deadLetterQueue *models.Queue
+	if attr.RedrivePolicy != (models.RedrivePolicy{}) {
+		arnArray := strings.Split(attr.RedrivePolicy.DeadLetterTargetArn, ":")
+		queueName := arnArray[len(arnArray)-1]
+		dlq, ok := models.SyncQueues.Queues[queueName]
+		if !ok {
+			log.Error("Invalid RedrivePolicy Attribute")
+			return fmt.Errorf("InvalidAttributeValue")
+		}
+		if dlq.RedriveAllowPolicy != nil && !dlq.RedriveAllowPolicy.Allows(q.Arn) {
+			log.Errorf("Dead-letter queue %s does not allow %s to redrive to it", dlq.Name, q.Name)
+			return fmt.Errorf("RedriveNotAllowed")
+		}
+		deadLetterQueue = dlq
+	}
 	// FIXME - are there better places to put these bottom-limit validations?
 	if attr.DelaySeconds >= 0 {
 		q.DelaySeconds = attr.DelaySeconds.Int()
@@ -32,16 +69,21 @@ func setQueueAttributesV1(q *models.Queue, attr models.QueueAttributes) error {
 	if attr.VisibilityTimeout >= 0 {
 		q.VisibilityTimeout = attr.VisibilityTimeout.Int()
 	}
-	if attr.RedrivePolicy != (models.RedrivePolicy{}) {
-		arnArray := strings.Split(attr.RedrivePolicy.DeadLetterTargetArn, ":")
-		queueName := arnArray[len(arnArray)-1]
-		deadLetterQueue, ok := models.SyncQueues.Queues[queueName]
-		if !ok {
-			log.Error("Invalid RedrivePolicy Attribute")
-			return fmt.Errorf("InvalidAttributeValue")
-		}
+	if deadLetterQueue != nil {
 		q.DeadLetterQueue = deadLetterQueue
 		q.MaxReceiveCount = attr.RedrivePolicy.MaxReceiveCount.Int()
 	}
+	if attr.RedriveAllowPolicy != nil {
+		q.RedriveAllowPolicy = attr.RedriveAllowPolicy
+	}
+	// An explicitly empty Policy removes the queue's policy
+	if attr.Policy != nil {
+		q.Policy = string(*attr.Policy)
+	}
+	if attr.ContentBasedDeduplication != nil {
+		q.ContentBasedDeduplication = attr.ContentBasedDeduplication.Bool()
+	}
+	q.DeduplicationScope = deduplicationScope
+	q.FifoThroughputLimit = fifoThroughputLimit
 	return nil
 }
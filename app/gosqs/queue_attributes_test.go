@@ -120,4 +120,169 @@ func TestSetQueueAttributesV1_error_redrive_policy_targets_missing_queue(t *test
 	err := setQueueAttributesV1(q, attrs)
 
 	assert.Error(t, err)
+	assert.Equal(t, &models.Queue{}, q)
+}
+
+func TestSetQueueAttributesV1_success_sets_redrive_allow_policy(t *testing.T) {
+	q := &models.Queue{}
+	policy := &models.RedriveAllowPolicy{RedrivePermission: "byQueue", SourceQueueArns: []string{"arn:aws:sqs:region:account-id:source-queue"}}
+	err := setQueueAttributesV1(q, models.QueueAttributes{RedriveAllowPolicy: policy})
+
+	assert.Nil(t, err)
+	assert.Equal(t, policy, q.RedriveAllowPolicy)
+}
+
+func TestSetQueueAttributesV1_error_invalid_redrive_allow_policy(t *testing.T) {
+	q := &models.Queue{}
+	err := setQueueAttributesV1(q, models.QueueAttributes{
+		RedriveAllowPolicy: &models.RedriveAllowPolicy{RedrivePermission: "byQueue"},
+	})
+
+	assert.Equal(t, "InvalidRedriveAllowPolicy", err.Error())
+	assert.Nil(t, q.RedriveAllowPolicy)
+}
+
+func TestSetQueueAttributesV1_redrive_allow_policy_of_dead_letter_queue(t *testing.T) {
+	defer func() {
+		models.ResetApp()
+	}()
+
+	existingQueueName := "existing-queue"
+	sourceQueueArn := "arn:aws:sqs:region:account-id:source-queue"
+	cases := []struct {
+		policy  *models.RedriveAllowPolicy
+		allowed bool
+	}{
+		{policy: nil, allowed: true},
+		{policy: &models.RedriveAllowPolicy{RedrivePermission: "allowAll"}, allowed: true},
+		{policy: &models.RedriveAllowPolicy{RedrivePermission: "denyAll"}, allowed: false},
+		{policy: &models.RedriveAllowPolicy{RedrivePermission: "byQueue", SourceQueueArns: []string{sourceQueueArn}}, allowed: true},
+		{policy: &models.RedriveAllowPolicy{RedrivePermission: "byQueue", SourceQueueArns: []string{"arn:aws:sqs:region:account-id:other-queue"}}, allowed: false},
+	}
+	for i, c := range cases {
+		existingQueue := &models.Queue{Name: existingQueueName, RedriveAllowPolicy: c.policy}
+		models.SyncQueues.Queues[existingQueueName] = existingQueue
+
+		q := &models.Queue{Name: "source-queue", Arn: sourceQueueArn, VisibilityTimeout: 30}
+		attrs := models.QueueAttributes{
+			VisibilityTimeout: 5,
+			RedrivePolicy: models.RedrivePolicy{
+				MaxReceiveCount:     10,
+				DeadLetterTargetArn: fmt.Sprintf("arn:aws:sqs:region:account-id:%s", existingQueueName),
+			},
+		}
+		err := setQueueAttributesV1(q, attrs)
+
+		if c.allowed {
+			assert.Nil(t, err, "case %d", i)
+			assert.Equal(t, existingQueue, q.DeadLetterQueue, "case %d", i)
+		} else {
+			assert.Equal(t, "RedriveNotAllowed", err.Error(), "case %d", i)
+			assert.Nil(t, q.DeadLetterQueue, "case %d", i)
+			assert.Equal(t, 30, q.VisibilityTimeout, "case %d", i)
+		}
+	}
+}
+
+func TestSetQueueAttributesV1_success_stores_policy_verbatim(t *testing.T) {
+	q := &models.Queue{}
+	policy := models.PolicyDocument(`{"Version": "2012-10-17", "Statement": []}`)
+	err := setQueueAttributesV1(q, models.QueueAttributes{Policy: &policy})
+
+	assert.Nil(t, err)
+	assert.Equal(t, string(policy), q.Policy)
+}
+
+func TestSetQueueAttributesV1_success_empty_policy_removes_policy(t *testing.T) {
+	q := &models.Queue{Policy: `{"Version": "2012-10-17", "Statement": []}`}
+	policy := models.PolicyDocument("")
+	err := setQueueAttributesV1(q, models.QueueAttributes{Policy: &policy})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", q.Policy)
+}
+
+func TestSetQueueAttributesV1_success_missing_policy_keeps_policy(t *testing.T) {
+	q := &models.Queue{Policy: `{"Version": "2012-10-17", "Statement": []}`}
+	err := setQueueAttributesV1(q, models.QueueAttributes{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"Version": "2012-10-17", "Statement": []}`, q.Policy)
+}
+
+func TestSetQueueAttributesV1_error_invalid_policy(t *testing.T) {
+	q := &models.Queue{}
+	policy := models.PolicyDocument("garbage")
+	err := setQueueAttributesV1(q, models.QueueAttributes{Policy: &policy})
+
+	assert.Equal(t, "InvalidPolicy", err.Error())
+	assert.Equal(t, "", q.Policy)
+}
+
+func TestSetQueueAttributesV1_success_content_based_deduplication(t *testing.T) {
+	q := &models.Queue{IsFIFO: true}
+	enabled := models.StringToBool(true)
+	err := setQueueAttributesV1(q, models.QueueAttributes{ContentBasedDeduplication: &enabled})
+
+	assert.Nil(t, err)
+	assert.True(t, q.ContentBasedDeduplication)
+
+	disabled := models.StringToBool(false)
+	err = setQueueAttributesV1(q, models.QueueAttributes{ContentBasedDeduplication: &disabled})
+
+	assert.Nil(t, err)
+	assert.False(t, q.ContentBasedDeduplication)
+}
+
+func TestSetQueueAttributesV1_error_content_based_deduplication_on_standard_queue(t *testing.T) {
+	q := &models.Queue{}
+	enabled := models.StringToBool(true)
+	err := setQueueAttributesV1(q, models.QueueAttributes{ContentBasedDeduplication: &enabled})
+
+	assert.Equal(t, "InvalidAttributeName", err.Error())
+	assert.False(t, q.ContentBasedDeduplication)
+}
+
+func TestSetQueueAttributesV1_success_fifo_throughput(t *testing.T) {
+	q := &models.Queue{IsFIFO: true}
+	err := setQueueAttributesV1(q, models.QueueAttributes{
+		DeduplicationScope:  "messageGroup",
+		FifoThroughputLimit: "perMessageGroupId",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "messageGroup", q.DeduplicationScope)
+	assert.Equal(t, "perMessageGroupId", q.FifoThroughputLimit)
+
+	// The scope stays when only the limit is changed
+	err = setQueueAttributesV1(q, models.QueueAttributes{FifoThroughputLimit: "perQueue"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "messageGroup", q.DeduplicationScope)
+	assert.Equal(t, "perQueue", q.FifoThroughputLimit)
+}
+
+func TestSetQueueAttributesV1_error_invalid_fifo_throughput(t *testing.T) {
+	q := &models.Queue{IsFIFO: true}
+	err := setQueueAttributesV1(q, models.QueueAttributes{DeduplicationScope: "garbage"})
+	assert.Equal(t, "InvalidDeduplicationScope", err.Error())
+
+	err = setQueueAttributesV1(q, models.QueueAttributes{FifoThroughputLimit: "garbage"})
+	assert.Equal(t, "InvalidFifoThroughputLimit", err.Error())
+
+	// Throughput per message group needs deduplication per message group
+	err = setQueueAttributesV1(q, models.QueueAttributes{FifoThroughputLimit: "perMessageGroupId"})
+	assert.Equal(t, "InvalidFifoThroughputLimit", err.Error())
+
+	assert.Equal(t, "", q.DeduplicationScope)
+	assert.Equal(t, "", q.FifoThroughputLimit)
+}
+
+func TestSetQueueAttributesV1_error_fifo_throughput_on_standard_queue(t *testing.T) {
+	q := &models.Queue{}
+	err := setQueueAttributesV1(q, models.QueueAttributes{DeduplicationScope: "messageGroup"})
+	assert.Equal(t, "InvalidAttributeName", err.Error())
+
+	err = setQueueAttributesV1(q, models.QueueAttributes{FifoThroughputLimit: "perQueue"})
+	assert.Equal(t, "InvalidAttributeName", err.Error())
 }
@@ -44,6 +44,21 @@ func ReceiveMessageV1(req *http.Request) (int, interfaces.AbstractResponseBody)
 		return utils.CreateErrorResponseV1("QueueNotFound", true)
 	}
 
+	// A retry of a FIFO receive gets the batch it already received, rather than locking more message groups
+	if models.SyncQueues.Queues[queueName].IsFIFO && requestBody.ReceiveRequestAttemptId != "" {
+		models.SyncQueues.Lock()
+		messages, retried := retryReceiveAttempt(models.SyncQueues.Queues[queueName], requestBody)
+		models.SyncQueues.Unlock()
+		if retried {
+			log.Debugf("Retried ReceiveRequestAttemptId [%s] on Queue: %s", requestBody.ReceiveRequestAttemptId, queueName)
+			return http.StatusOK, models.ReceiveMessageResponse{
+				Xmlns:    models.BaseXmlns,
+				Result:   models.ReceiveMessageResult{Messages: messages},
+				Metadata: models.BaseResponseMetadata,
+			}
+		}
+	}
+
 	var messages []*models.ResultMessage
 	respStruct := models.ReceiveMessageResponse{}
 
@@ -91,6 +106,7 @@ func ReceiveMessageV1(req *http.Request) (int, interfaces.AbstractResponseBody)
 	if len(models.SyncQueues.Queues[queueName].Messages) > 0 {
 		numMsg := 0
 		messages = make([]*models.ResultMessage, 0)
+		receiptHandles := []string{}
 		for i := range models.SyncQueues.Queues[queueName].Messages {
 			if numMsg >= maxNumberOfMessages {
 				break
@@ -117,6 +133,10 @@ func ReceiveMessageV1(req *http.Request) (int, interfaces.AbstractResponseBody)
 			randomId := uuid.NewString()
 			msg.ReceiptHandle = msg.Uuid + "#" + randomId
 			msg.ReceiptTime = time.Now().UTC()
+			msg.NumberOfReceives++
+			if msg.FirstReceiveTime.IsZero() {
+				msg.FirstReceiveTime = msg.ReceiptTime
+			}
 
 			if requestBody.VisibilityTimeout != 0 {
 				msg.VisibilityTimeout = time.Now().Add(time.Duration(requestBody.VisibilityTimeout) * time.Second)
@@ -124,17 +144,22 @@ func ReceiveMessageV1(req *http.Request) (int, interfaces.AbstractResponseBody)
 				msg.VisibilityTimeout = time.Now().Add(time.Duration(models.SyncQueues.Queues[queueName].VisibilityTimeout) * time.Second)
 			}
 
-			messages = append(messages, buildResultMessage(msg))
+			messages = append(messages, buildResultMessage(msg, requestBody))
+			receiptHandles = append(receiptHandles, msg.ReceiptHandle)
 
 			numMsg++
 		}
 
+		if models.SyncQueues.Queues[queueName].IsFIFO && len(messages) > 0 {
+			models.SyncQueues.Queues[queueName].SaveReceiveAttempt(requestBody.ReceiveRequestAttemptId, receiptHandles)
+		}
+
 		respStruct = models.ReceiveMessageResponse{
-			"http://queue.amazonaws.com/doc/2012-11-05/",
-			models.ReceiveMessageResult{
+			Xmlns: "http://queue.amazonaws.com/doc/2012-11-05/",
+			Result: models.ReceiveMessageResult{
 				Messages: messages,
 			},
-			models.ResponseMetadata{
+			Metadata: models.ResponseMetadata{
 				RequestId: "00000000-0000-0000-0000-000000000000",
 			},
 		}
@@ -146,19 +171,115 @@ func ReceiveMessageV1(req *http.Request) (int, interfaces.AbstractResponseBody)
 	return http.StatusOK, respStruct
 }
 
-func buildResultMessage(m *models.SqsMessage) *models.ResultMessage {
-	return &models.ResultMessage{
-		MessageId:              m.Uuid,
-		Body:                   m.MessageBody,
-		ReceiptHandle:          m.ReceiptHandle,
-		MD5OfBody:              utils.GetMD5Hash(m.MessageBody),
-		MD5OfMessageAttributes: m.MD5OfMessageAttributes,
-		MessageAttributes:      m.MessageAttributes,
-		Attributes: map[string]string{
-			"ApproximateFirstReceiveTimestamp": fmt.Sprintf("%d", m.ReceiptTime.UnixNano()/int64(time.Millisecond)),
-			"SenderId":                         models.CurrentEnvironment.AccountID,
-			"ApproximateReceiveCount":          fmt.Sprintf("%d", m.NumberOfReceives+1),
-			"SentTimestamp":                    fmt.Sprintf("%d", time.Now().UTC().UnixNano()/int64(time.Millisecond)),
-		},
+// retryReceiveAttempt finds the messages received with the request's ReceiveRequestAttemptId, and makes them
+// invisible again for another visibility timeout.  Messages deleted or made visible since then aren't returned.
+func retryReceiveAttempt(queue *models.Queue, requestBody *models.ReceiveMessageRequest) ([]*models.ResultMessage, bool) {
+	receiptHandles, ok := queue.RetriedReceiveAttempt(requestBody.ReceiveRequestAttemptId)
+	if !ok {
+		return nil, false
+	}
+
+	visibilityTimeout := queue.VisibilityTimeout
+	if requestBody.VisibilityTimeout != 0 {
+		visibilityTimeout = requestBody.VisibilityTimeout
+	}
+	messages := make([]*models.ResultMessage, 0)
+	for _, receiptHandle := range receiptHandles {
+		for i := range queue.Messages {
+			msg := &queue.Messages[i]
+			if msg.ReceiptHandle != receiptHandle {
+				continue
+			}
+			msg.VisibilityTimeout = time.Now().Add(time.Duration(visibilityTimeout) * time.Second)
+			messages = append(messages, buildResultMessage(msg, requestBody))
+			break
+		}
+	}
+	return messages, true
+}
+
+// buildResultMessage returns the message with only the system attributes and message attributes the request
+// selected - none unless they're asked for.
+func buildResultMessage(m *models.SqsMessage, requestBody *models.ReceiveMessageRequest) *models.ResultMessage {
+	attributeNames := append([]string{}, requestBody.AttributeNames...)
+	attributeNames = append(attributeNames, requestBody.MessageSystemAttributeNames...)
+	messageAttributes := selectMessageAttributes(m.MessageAttributes, requestBody.MessageAttributeNames)
+
+	result := &models.ResultMessage{
+		MessageId:         m.Uuid,
+		Body:              m.MessageBody,
+		ReceiptHandle:     m.ReceiptHandle,
+		MD5OfBody:         utils.GetMD5Hash(m.MessageBody),
+		MessageAttributes: messageAttributes,
+		Attributes:        selectSystemAttributes(systemAttributes(m), attributeNames),
+	}
+	if len(messageAttributes) > 0 {
+		result.MD5OfMessageAttributes = utils.HashAttributes(messageAttributes)
+	}
+	return result
+}
+
+// systemAttributes are every system attribute the message has.
+// ref: https://docs.aws.amazon.com/AWSSimpleQueueService/latest/APIReference/API_ReceiveMessage.html
+func systemAttributes(m *models.SqsMessage) map[string]string {
+	senderId := m.SenderId
+	if senderId == "" {
+		senderId = models.CurrentEnvironment.AccountID
+	}
+	attributes := map[string]string{
+		"ApproximateFirstReceiveTimestamp": fmt.Sprintf("%d", m.FirstReceiveTime.UnixNano()/int64(time.Millisecond)),
+		"SenderId":                         senderId,
+		"ApproximateReceiveCount":          fmt.Sprintf("%d", m.NumberOfReceives),
+		"SentTimestamp":                    fmt.Sprintf("%d", m.SentTime.UnixNano()/int64(time.Millisecond)),
+	}
+	if m.AWSTraceHeader != "" {
+		attributes["AWSTraceHeader"] = m.AWSTraceHeader
+	}
+	// Messages from FIFO queues
+	if m.GroupID != "" {
+		attributes["MessageGroupId"] = m.GroupID
+	}
+	if m.DeduplicationID != "" {
+		attributes["MessageDeduplicationId"] = m.DeduplicationID
+	}
+	if m.SequenceNumber != "" {
+		attributes["SequenceNumber"] = m.SequenceNumber
+	}
+	return attributes
+}
+
+func selectSystemAttributes(attributes map[string]string, names []string) map[string]string {
+	selected := map[string]string{}
+	for _, name := range names {
+		if name == "All" {
+			return attributes
+		}
+		if value, ok := attributes[name]; ok {
+			selected[name] = value
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+	return selected
+}
+
+// selectMessageAttributes picks the message attributes by name.  `All` or `.*` selects every one, and a name ending
+// in `.*` selects every one starting with that prefix.
+func selectMessageAttributes(attributes map[string]models.MessageAttribute, names []string) map[string]models.MessageAttribute {
+	selected := map[string]models.MessageAttribute{}
+	for _, name := range names {
+		if name == "All" || name == ".*" {
+			return attributes
+		}
+		for key, value := range attributes {
+			if key == name || strings.HasSuffix(name, ".*") && strings.HasPrefix(key, strings.TrimSuffix(name, "*")) {
+				selected[key] = value
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return nil
 	}
+	return selected
 }
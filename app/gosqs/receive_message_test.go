@@ -11,6 +11,7 @@ import (
 	"github.com/Admiral-Piett/goaws/app/test"
 
 	"github.com/Admiral-Piett/goaws/app/fixtures"
+	"github.com/Admiral-Piett/goaws/app/utils"
 	"github.com/Admiral-Piett/goaws/app/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -201,7 +202,11 @@ func TestReceiveMessageV1_with_MessageAttributes(t *testing.T) {
 	})
 
 	// receive message
-	_, r := test.GenerateRequestInfo("POST", "/", models.ReceiveMessageRequest{QueueUrl: "http://localhost:4100/queue/waiting-queue"}, true)
+	_, r := test.GenerateRequestInfo("POST", "/", models.ReceiveMessageRequest{
+		QueueUrl:              "http://localhost:4100/queue/waiting-queue",
+		AttributeNames:        []string{"All"},
+		MessageAttributeNames: []string{"All"},
+	}, true)
 	status, resp := ReceiveMessageV1(r)
 	result := resp.GetResult().(models.ReceiveMessageResult)
 
@@ -402,3 +407,291 @@ func TestReceiveMessageV1_FIFOSecondMessageAvailableAfterDelete(t *testing.T) {
 }
 
 // TODO - other tests
+
+func TestReceiveMessageV1_FIFO_retried_ReceiveRequestAttemptId_returns_same_batch(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+	}()
+
+	queueName := "fifo-attempt-queue.fifo"
+	queueURL := fmt.Sprintf("http://localhost:4100/queue/%s", queueName)
+	now := time.Now().Add(-1 * time.Minute)
+
+	q := &models.Queue{
+		Name:              queueName,
+		VisibilityTimeout: 30,
+		IsFIFO:            true,
+		FIFOMessages:      map[string]int{},
+		Messages: []models.SqsMessage{
+			{MessageBody: "first", Uuid: "first-uuid", GroupID: "group-1", SentTime: now},
+			{MessageBody: "second", Uuid: "second-uuid", GroupID: "group-1", SentTime: now},
+			{MessageBody: "third", Uuid: "third-uuid", GroupID: "group-2", SentTime: now},
+		},
+	}
+	models.SyncQueues.Queues[queueName] = q
+
+	receive := func() []*models.ResultMessage {
+		_, r := test.GenerateRequestInfo("POST", "/", models.ReceiveMessageRequest{
+			QueueUrl:                queueURL,
+			MaxNumberOfMessages:     10,
+			ReceiveRequestAttemptId: "attempt-1",
+		}, true)
+		status, resp := ReceiveMessageV1(r)
+		assert.Equal(t, http.StatusOK, status)
+		return resp.GetResult().(models.ReceiveMessageResult).Messages
+	}
+
+	messages := receive()
+	assert.Len(t, messages, 2)
+	assert.Equal(t, "first", messages[0].Body)
+	assert.Equal(t, "third", messages[1].Body)
+
+	// A message in a new group arrives before the retry, but it doesn't get received or locked
+	q.Messages = append(q.Messages, models.SqsMessage{MessageBody: "fourth", Uuid: "fourth-uuid", GroupID: "group-3", SentTime: now})
+
+	retried := receive()
+	assert.Len(t, retried, 2)
+	assert.Equal(t, messages[0].ReceiptHandle, retried[0].ReceiptHandle)
+	assert.Equal(t, messages[1].ReceiptHandle, retried[1].ReceiptHandle)
+	assert.False(t, q.IsLocked("group-3"))
+	assert.Equal(t, "", q.Messages[3].ReceiptHandle)
+
+	// Deleted messages drop out of the retried batch
+	_, deleteReq := test.GenerateRequestInfo("POST", "/", models.DeleteMessageRequest{
+		QueueUrl:      queueURL,
+		ReceiptHandle: messages[0].ReceiptHandle,
+	}, true)
+	deleteStatus, _ := DeleteMessageV1(deleteReq)
+	assert.Equal(t, http.StatusOK, deleteStatus)
+
+	retried = receive()
+	assert.Len(t, retried, 1)
+	assert.Equal(t, messages[1].ReceiptHandle, retried[0].ReceiptHandle)
+}
+
+func TestReceiveMessageV1_standard_queue_ignores_ReceiveRequestAttemptId(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+	}()
+
+	queueName := "attempt-queue"
+	q := &models.Queue{
+		Name:              queueName,
+		VisibilityTimeout: 30,
+		Messages: []models.SqsMessage{
+			{MessageBody: "first", Uuid: "first-uuid", SentTime: time.Now()},
+			{MessageBody: "second", Uuid: "second-uuid", SentTime: time.Now()},
+		},
+	}
+	models.SyncQueues.Queues[queueName] = q
+
+	for _, body := range []string{"first", "second"} {
+		_, r := test.GenerateRequestInfo("POST", "/", models.ReceiveMessageRequest{
+			QueueUrl:                fmt.Sprintf("http://localhost:4100/queue/%s", queueName),
+			ReceiveRequestAttemptId: "attempt-1",
+		}, true)
+		status, resp := ReceiveMessageV1(r)
+		assert.Equal(t, http.StatusOK, status)
+		messages := resp.GetResult().(models.ReceiveMessageResult).Messages
+		assert.Len(t, messages, 1)
+		assert.Equal(t, body, messages[0].Body)
+	}
+	assert.Nil(t, q.ReceiveAttempts)
+}
+
+func TestReceiveMessageV1_FIFO_returns_message_group_attributes(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+	}()
+
+	queueName := "fifo-attributes-queue.fifo"
+	models.SyncQueues.Queues[queueName] = &models.Queue{
+		Name:         queueName,
+		IsFIFO:       true,
+		FIFOMessages: map[string]int{},
+		Messages: []models.SqsMessage{
+			{
+				MessageBody:     "first",
+				Uuid:            "first-uuid",
+				GroupID:         "group-1",
+				DeduplicationID: "dedup-1",
+				SequenceNumber:  "1",
+				SentTime:        time.Now().Add(-1 * time.Minute),
+			},
+		},
+	}
+	models.SyncQueues.Queues["attributes-queue"] = &models.Queue{
+		Name: "attributes-queue",
+		Messages: []models.SqsMessage{
+			{MessageBody: "first", Uuid: "first-uuid", SentTime: time.Now().Add(-1 * time.Minute)},
+		},
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", models.ReceiveMessageRequest{
+		QueueUrl:       fmt.Sprintf("http://localhost:4100/queue/%s", queueName),
+		AttributeNames: []string{"All"},
+	}, true)
+	status, resp := ReceiveMessageV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	result := resp.GetResult().(models.ReceiveMessageResult)
+	assert.Len(t, result.Messages, 1)
+	assert.Equal(t, "group-1", result.Messages[0].Attributes["MessageGroupId"])
+	assert.Equal(t, "dedup-1", result.Messages[0].Attributes["MessageDeduplicationId"])
+	assert.Equal(t, "1", result.Messages[0].Attributes["SequenceNumber"])
+
+	_, r = test.GenerateRequestInfo("POST", "/", models.ReceiveMessageRequest{
+		QueueUrl:       "http://localhost:4100/queue/attributes-queue",
+		AttributeNames: []string{"All"},
+	}, true)
+	status, resp = ReceiveMessageV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	result = resp.GetResult().(models.ReceiveMessageResult)
+	assert.Len(t, result.Messages, 1)
+	assert.NotContains(t, result.Messages[0].Attributes, "MessageGroupId")
+	assert.NotContains(t, result.Messages[0].Attributes, "MessageDeduplicationId")
+	assert.NotContains(t, result.Messages[0].Attributes, "SequenceNumber")
+}
+
+func TestReceiveMessageV1_selects_requested_attributes(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+	}()
+
+	sentTime := time.Now().Add(-1 * time.Minute)
+	messageAttributes := map[string]models.MessageAttribute{
+		"attr1":        {DataType: "String", StringValue: "value1"},
+		"prefix.attr2": {DataType: "String", StringValue: "value2"},
+		"prefix.attr3": {DataType: "Number", StringValue: "3"},
+	}
+	q := &models.Queue{Name: "attributes-queue"}
+	models.SyncQueues.Queues["attributes-queue"] = q
+
+	receive := func(request models.ReceiveMessageRequest) *models.ResultMessage {
+		q.Messages = []models.SqsMessage{{
+			MessageBody:            "1",
+			Uuid:                   "1-uuid",
+			SentTime:               sentTime,
+			SenderId:               "200020002000",
+			AWSTraceHeader:         "Root=1-5759e988-bd862e3fe1be46a994272793",
+			MessageAttributes:      messageAttributes,
+			MD5OfMessageAttributes: utils.HashAttributes(messageAttributes),
+		}}
+		request.QueueUrl = "http://localhost:4100/queue/attributes-queue"
+		_, r := test.GenerateRequestInfo("POST", "/", request, true)
+		status, resp := ReceiveMessageV1(r)
+		assert.Equal(t, http.StatusOK, status)
+		result := resp.GetResult().(models.ReceiveMessageResult)
+		assert.Len(t, result.Messages, 1)
+		return result.Messages[0]
+	}
+
+	// Nothing is returned unless it's asked for
+	message := receive(models.ReceiveMessageRequest{})
+	assert.Nil(t, message.Attributes)
+	assert.Nil(t, message.MessageAttributes)
+	assert.Equal(t, "", message.MD5OfMessageAttributes)
+
+	message = receive(models.ReceiveMessageRequest{
+		AttributeNames:              []string{"SentTimestamp"},
+		MessageSystemAttributeNames: []string{"AWSTraceHeader", "SenderId", "Unknown"},
+		MessageAttributeNames:       []string{"attr1"},
+	})
+	assert.Equal(t, map[string]string{
+		"SentTimestamp":  fmt.Sprintf("%d", sentTime.UnixNano()/int64(time.Millisecond)),
+		"AWSTraceHeader": "Root=1-5759e988-bd862e3fe1be46a994272793",
+		"SenderId":       "200020002000",
+	}, message.Attributes)
+	assert.Equal(t, map[string]models.MessageAttribute{"attr1": messageAttributes["attr1"]}, message.MessageAttributes)
+	assert.Equal(t, utils.HashAttributes(map[string]models.MessageAttribute{"attr1": messageAttributes["attr1"]}), message.MD5OfMessageAttributes)
+
+	message = receive(models.ReceiveMessageRequest{MessageAttributeNames: []string{"prefix.*"}})
+	assert.Len(t, message.MessageAttributes, 2)
+	assert.Contains(t, message.MessageAttributes, "prefix.attr2")
+	assert.Contains(t, message.MessageAttributes, "prefix.attr3")
+
+	message = receive(models.ReceiveMessageRequest{AttributeNames: []string{"All"}, MessageAttributeNames: []string{".*"}})
+	assert.Len(t, message.Attributes, 5)
+	assert.Equal(t, messageAttributes, message.MessageAttributes)
+	assert.Equal(t, utils.HashAttributes(messageAttributes), message.MD5OfMessageAttributes)
+}
+
+func TestReceiveMessageV1_ApproximateFirstReceiveTimestamp_is_set_once(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+	}()
+
+	q := &models.Queue{
+		Name: "first-receive-queue",
+		Messages: []models.SqsMessage{
+			{MessageBody: "1", Uuid: "1-uuid", SentTime: time.Now().Add(-1 * time.Minute)},
+		},
+	}
+	models.SyncQueues.Queues["first-receive-queue"] = q
+
+	receive := func() string {
+		_, r := test.GenerateRequestInfo("POST", "/", models.ReceiveMessageRequest{
+			QueueUrl:       "http://localhost:4100/queue/first-receive-queue",
+			AttributeNames: []string{"ApproximateFirstReceiveTimestamp"},
+		}, true)
+		_, resp := ReceiveMessageV1(r)
+		return resp.GetResult().(models.ReceiveMessageResult).Messages[0].Attributes["ApproximateFirstReceiveTimestamp"]
+	}
+
+	first := receive()
+	firstReceiveTime := q.Messages[0].FirstReceiveTime
+	assert.False(t, firstReceiveTime.IsZero())
+
+	// Make the message visible again, like an expired visibility timeout does
+	time.Sleep(5 * time.Millisecond)
+	q.Messages[0].ReceiptHandle = ""
+
+	second := receive()
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, firstReceiveTime, q.Messages[0].FirstReceiveTime)
+}
+
+func TestReceiveMessageV1_counts_receives(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+	}()
+
+	q := &models.Queue{
+		Name:   "receive-count-queue.fifo",
+		IsFIFO: true,
+		Messages: []models.SqsMessage{
+			{MessageBody: "1", Uuid: "1-uuid", GroupID: "group-1", SentTime: time.Now().Add(-1 * time.Minute)},
+		},
+	}
+	models.SyncQueues.Queues["receive-count-queue.fifo"] = q
+
+	receive := func(attemptId string) string {
+		_, r := test.GenerateRequestInfo("POST", "/", models.ReceiveMessageRequest{
+			QueueUrl:                "http://localhost:4100/queue/receive-count-queue.fifo",
+			AttributeNames:          []string{"ApproximateReceiveCount"},
+			ReceiveRequestAttemptId: attemptId,
+		}, true)
+		_, resp := ReceiveMessageV1(r)
+		return resp.GetResult().(models.ReceiveMessageResult).Messages[0].Attributes["ApproximateReceiveCount"]
+	}
+
+	assert.Equal(t, "1", receive("attempt-1"))
+	// Retrying the same attempt isn't another receive
+	assert.Equal(t, "1", receive("attempt-1"))
+	assert.Equal(t, 1, q.Messages[0].NumberOfReceives)
+
+	// Make the message visible again, like an expired visibility timeout does
+	q.Messages[0].ReceiptHandle = ""
+	q.UnlockGroup("group-1")
+
+	assert.Equal(t, "2", receive("attempt-2"))
+	assert.Equal(t, 2, q.Messages[0].NumberOfReceives)
+}
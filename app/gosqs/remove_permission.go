@@ -0,0 +1,51 @@
+package gosqs
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// RemovePermissionV1 removes the statement AddPermission added under the given label from the queue's Policy.
+func RemovePermissionV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewRemovePermissionRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - RemovePermissionV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+	if requestBody.Label == "" {
+		log.Error("Missing Label - RemovePermissionV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+
+	uriSegments := strings.Split(requestBody.QueueUrl, "/")
+	queueName := uriSegments[len(uriSegments)-1]
+
+	models.SyncQueues.Lock()
+	defer models.SyncQueues.Unlock()
+	queue, ok := models.SyncQueues.Queues[queueName]
+	if !ok {
+		log.Errorf("Remove Permission: %s, queue does not exist!!!", queueName)
+		return utils.CreateErrorResponseV1("QueueNotFound", true)
+	}
+
+	policy, err := models.NewQueuePolicy(queue)
+	if err != nil || !policy.RemoveStatement(requestBody.Label) {
+		log.Errorf("Remove Permission: %s, label %s does not exist", queueName, requestBody.Label)
+		return utils.CreateErrorResponseV1("PermissionLabelNotFound", true)
+	}
+	queue.Policy = policy.Document()
+
+	log.Infof("Removed permission %s from queue: %s", requestBody.Label, queueName)
+	respStruct := models.RemovePermissionResponse{
+		Xmlns:    models.BaseXmlns,
+		Metadata: models.BaseResponseMetadata,
+	}
+	return http.StatusOK, respStruct
+}
@@ -0,0 +1,123 @@
+package gosqs
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/fixtures"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func removePermission(request models.RemovePermissionRequest) (int, interfaces.AbstractResponseBody) {
+	defaultTransformer := utils.REQUEST_TRANSFORMER
+	defer func() {
+		utils.REQUEST_TRANSFORMER = defaultTransformer
+	}()
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.RemovePermissionRequest)
+		*v = request
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	return RemovePermissionV1(r)
+}
+
+func TestRemovePermissionV1_success(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	queueUrl := fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1")
+	for _, label := range []string{"send", "receive"} {
+		code, _ := addPermission(models.AddPermissionRequest{
+			QueueUrl:      queueUrl,
+			Label:         label,
+			AWSAccountIds: []string{"111122223333"},
+			Actions:       []string{"SendMessage"},
+		})
+		assert.Equal(t, http.StatusOK, code)
+	}
+
+	code, response := removePermission(models.RemovePermissionRequest{QueueUrl: queueUrl, Label: "send"})
+
+	expectedResponse := models.RemovePermissionResponse{
+		Xmlns:    models.BaseXmlns,
+		Metadata: models.BaseResponseMetadata,
+	}
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expectedResponse, response)
+	policy, _ := models.NewQueuePolicy(models.SyncQueues.Queues["unit-queue1"])
+	assert.False(t, policy.HasStatement("send"))
+	assert.True(t, policy.HasStatement("receive"))
+
+	// Removing the last statement removes the policy
+	code, _ = removePermission(models.RemovePermissionRequest{QueueUrl: queueUrl, Label: "receive"})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "", models.SyncQueues.Queues["unit-queue1"].Policy)
+}
+
+func TestRemovePermissionV1_error_label_not_found(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	code, response := removePermission(models.RemovePermissionRequest{
+		QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+		Label:    "missing",
+	})
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, models.SqsErrors["PermissionLabelNotFound"].Message, response.(models.ErrorResponse).Result.Message)
+}
+
+func TestRemovePermissionV1_error_missing_label(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	code, _ := removePermission(models.RemovePermissionRequest{
+		QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+	})
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestRemovePermissionV1_error_queue_not_found(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+	}()
+
+	code, response := removePermission(models.RemovePermissionRequest{
+		QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "missing-queue"),
+		Label:    "send",
+	})
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, models.SqsErrors["QueueNotFound"].Code, response.(models.ErrorResponse).Result.Code)
+}
+
+func TestRemovePermissionV1_request_transformer_error(t *testing.T) {
+	defer func() {
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := RemovePermissionV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -40,10 +40,30 @@ func SendMessageV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
 		queueName = uriSegments[len(uriSegments)-1]
 	}
 
-	if _, ok := models.SyncQueues.Queues[queueName]; !ok {
+	accessKey := utils.GetAccessKeyId(req)
+	models.SyncQueues.RLock()
+	queue, ok := models.SyncQueues.Queues[queueName]
+	allowed := ok && models.CallerIsAllowed(accessKey, queue.Policy, "SQS:SendMessage", queue.Arn)
+	models.SyncQueues.RUnlock()
+	if !ok {
 		// Queue does not exist
 		return utils.CreateErrorResponseV1("QueueNotFound", true)
 	}
+	if !allowed {
+		return utils.CreateErrorResponseV1("AccessDenied", true)
+	}
+
+	if queue.IsFIFO {
+		messageDeduplicationID = queue.DeduplicationId(messageDeduplicationID, messageBody)
+		if messageDeduplicationID == "" {
+			return utils.CreateErrorResponseV1("MissingDeduplicationId", true)
+		}
+	}
+
+	traceHeader, err := models.TraceHeader(requestBody.MessageSystemAttributes)
+	if err != nil {
+		return utils.CreateErrorResponseV1(err.Error(), true)
+	}
 
 	if models.SyncQueues.Queues[queueName].MaximumMessageSize > 0 &&
 		len(messageBody) > models.SyncQueues.Queues[queueName].MaximumMessageSize {
@@ -67,6 +87,8 @@ func SendMessageV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
 	msg.GroupID = messageGroupID
 	msg.DeduplicationID = messageDeduplicationID
 	msg.SentTime = time.Now()
+	msg.SenderId = models.SenderId(accessKey)
+	msg.AWSTraceHeader = traceHeader
 	msg.DelaySecs = delaySecs
 
 	models.SyncQueues.Lock()
@@ -74,14 +96,15 @@ func SendMessageV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
 	if models.SyncQueues.Queues[queueName].IsFIFO {
 		fifoSeqNumber = models.SyncQueues.Queues[queueName].NextSequenceNumber(messageGroupID)
 	}
+	msg.SequenceNumber = fifoSeqNumber
 
-	if !models.SyncQueues.Queues[queueName].IsDuplicate(messageDeduplicationID) {
+	if !models.SyncQueues.Queues[queueName].IsDuplicate(messageGroupID, messageDeduplicationID) {
 		models.SyncQueues.Queues[queueName].Messages = append(models.SyncQueues.Queues[queueName].Messages, msg)
 	} else {
 		log.Debugf("Message with deduplicationId [%s] in queue [%s] is duplicate ", messageDeduplicationID, queueName)
 	}
 
-	models.SyncQueues.Queues[queueName].InitDuplicatation(messageDeduplicationID)
+	models.SyncQueues.Queues[queueName].InitDuplicatation(messageGroupID, messageDeduplicationID)
 	models.SyncQueues.Unlock()
 	log.Infof("%s: Queue: %s, Message: %s\n", time.Now().Format("2006-01-02 15:04:05"), queueName, msg.MessageBody)
 
@@ -34,9 +34,17 @@ func SendMessageBatchV1(req *http.Request) (int, interfaces.AbstractResponseBody
 		queueName = uriSegments[len(uriSegments)-1]
 	}
 
-	if _, ok := models.SyncQueues.Queues[queueName]; !ok {
+	accessKey := utils.GetAccessKeyId(req)
+	models.SyncQueues.RLock()
+	queue, ok := models.SyncQueues.Queues[queueName]
+	allowed := ok && models.CallerIsAllowed(accessKey, queue.Policy, "SQS:SendMessage", queue.Arn)
+	models.SyncQueues.RUnlock()
+	if !ok {
 		return utils.CreateErrorResponseV1("QueueNotFound", true)
 	}
+	if !allowed {
+		return utils.CreateErrorResponseV1("AccessDenied", true)
+	}
 
 	sendEntries := requestBody.Entries
 
@@ -48,16 +56,28 @@ func SendMessageBatchV1(req *http.Request) (int, interfaces.AbstractResponseBody
 		return utils.CreateErrorResponseV1("TooManyEntriesInBatchRequest", true)
 	}
 	ids := map[string]struct{}{}
-	for _, v := range sendEntries {
+	traceHeaders := make([]string, len(sendEntries))
+	for i, v := range sendEntries {
 		if _, ok := ids[v.Id]; ok {
 			return utils.CreateErrorResponseV1("BatchEntryIdsNotDistinct", true)
 		}
 		ids[v.Id] = struct{}{}
+		if queue.IsFIFO {
+			sendEntries[i].MessageDeduplicationId = queue.DeduplicationId(v.MessageDeduplicationId, v.MessageBody)
+			if sendEntries[i].MessageDeduplicationId == "" {
+				return utils.CreateErrorResponseV1("MissingDeduplicationId", true)
+			}
+		}
+		traceHeader, err := models.TraceHeader(v.MessageSystemAttributes)
+		if err != nil {
+			return utils.CreateErrorResponseV1(err.Error(), true)
+		}
+		traceHeaders[i] = traceHeader
 	}
 
 	sentEntries := make([]models.SendMessageBatchResultEntry, 0)
 	log.Debug("Putting Message in Queue:", queueName)
-	for _, sendEntry := range sendEntries {
+	for i, sendEntry := range sendEntries {
 		msg := models.SqsMessage{MessageBody: sendEntry.MessageBody}
 		if len(sendEntry.MessageAttributes) > 0 {
 			msg.MessageAttributes = sendEntry.MessageAttributes
@@ -68,19 +88,22 @@ func SendMessageBatchV1(req *http.Request) (int, interfaces.AbstractResponseBody
 		msg.DeduplicationID = sendEntry.MessageDeduplicationId
 		msg.Uuid = uuid.NewString()
 		msg.SentTime = time.Now()
+		msg.SenderId = models.SenderId(accessKey)
+		msg.AWSTraceHeader = traceHeaders[i]
 		models.SyncQueues.Lock()
 		fifoSeqNumber := ""
 		if models.SyncQueues.Queues[queueName].IsFIFO {
 			fifoSeqNumber = models.SyncQueues.Queues[queueName].NextSequenceNumber(sendEntry.MessageGroupId)
 		}
+		msg.SequenceNumber = fifoSeqNumber
 
-		if !models.SyncQueues.Queues[queueName].IsDuplicate(sendEntry.MessageDeduplicationId) {
+		if !models.SyncQueues.Queues[queueName].IsDuplicate(sendEntry.MessageGroupId, sendEntry.MessageDeduplicationId) {
 			models.SyncQueues.Queues[queueName].Messages = append(models.SyncQueues.Queues[queueName].Messages, msg)
 		} else {
 			log.Debugf("Message with deduplicationId [%s] in queue [%s] is duplicate ", sendEntry.MessageDeduplicationId, queueName)
 		}
 
-		models.SyncQueues.Queues[queueName].InitDuplicatation(sendEntry.MessageDeduplicationId)
+		models.SyncQueues.Queues[queueName].InitDuplicatation(sendEntry.MessageGroupId, sendEntry.MessageDeduplicationId)
 
 		models.SyncQueues.Unlock()
 		se := models.SendMessageBatchResultEntry{
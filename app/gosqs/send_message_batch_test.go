@@ -109,9 +109,10 @@ func TestSendMessageBatchV1_Success_Fifo_Queue(t *testing.T) {
 	}
 
 	q := &models.Queue{
-		Name:               "fifo-queue-1",
-		MaximumMessageSize: 1024,
-		IsFIFO:             true,
+		Name:                      "fifo-queue-1",
+		MaximumMessageSize:        1024,
+		IsFIFO:                    true,
+		ContentBasedDeduplication: true,
 	}
 	models.SyncQueues.Queues["fifo-queue-1"] = q
 
@@ -130,6 +131,10 @@ func TestSendMessageBatchV1_Success_Fifo_Queue(t *testing.T) {
 	assert.NotEmpty(t, resultEntry[1].SequenceNumber)
 	assert.Contains(t, resultEntry[2].Id, "test_msg_003")
 	assert.NotEmpty(t, resultEntry[2].SequenceNumber)
+
+	for i, entry := range resultEntry {
+		assert.Equal(t, entry.SequenceNumber, q.Messages[i].SequenceNumber)
+	}
 }
 
 func TestSendMessageBatchV1_Error_QueueNotFound(t *testing.T) {
@@ -176,6 +181,184 @@ func TestSendMessageBatchV1_Error_QueueNotFound(t *testing.T) {
 	assert.Equal(t, expected, errorResult)
 }
 
+func TestSendMessageBatchV1_Error_AccessDenied(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	models.CurrentEnvironment.EnforcePolicies = true
+	models.CurrentEnvironment.AccessKeys = map[string]string{"other-key": "arn:aws:iam::200020002000:user/other"}
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SendMessageBatchRequest)
+		*v = models.SendMessageBatchRequest{
+			Entries: []models.SendMessageBatchRequestEntry{
+				{
+					Id:          "test_msg_001",
+					MessageBody: "test%20message%20body%201",
+				},
+			},
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+		}
+		return true
+	}
+
+	expected := models.ErrorResult{
+		Type:    "AccessDenied",
+		Code:    "AccessDenied",
+		Message: "Access to the resource is denied.",
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	r.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=other-key/20240101/us-east-1/sqs/aws4_request, SignedHeaders=host, Signature=abc")
+	status, response := SendMessageBatchV1(r)
+	errorResult := response.GetResult().(models.ErrorResult)
+
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, expected, errorResult)
+	assert.Empty(t, models.SyncQueues.Queues["unit-queue1"].Messages)
+}
+
+func TestSendMessageBatchV1_Error_Fifo_Queue_missing_deduplication_id(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SendMessageBatchRequest)
+		*v = models.SendMessageBatchRequest{
+			Entries: []models.SendMessageBatchRequestEntry{
+				{
+					Id:                     "test_msg_001",
+					MessageBody:            "test%20message%20body%201",
+					MessageDeduplicationId: "1",
+				},
+				{
+					Id:          "test_msg_002",
+					MessageBody: "test%20message%20body%202",
+				},
+			},
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "fifo-queue-1"),
+		}
+		return true
+	}
+
+	q := &models.Queue{
+		Name:   "fifo-queue-1",
+		IsFIFO: true,
+	}
+	models.SyncQueues.Queues["fifo-queue-1"] = q
+
+	expected := models.ErrorResult{
+		Type:    "InvalidParameterValue",
+		Code:    "AWS.SimpleQueueService.InvalidParameterValue",
+		Message: "The queue should either have ContentBasedDeduplication enabled or MessageDeduplicationId provided explicitly.",
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, response := SendMessageBatchV1(r)
+	errorResult := response.GetResult().(models.ErrorResult)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, expected, errorResult)
+	assert.Empty(t, q.Messages)
+}
+
+func TestSendMessageBatchV1_Success_AWSTraceHeader(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SendMessageBatchRequest)
+		*v = models.SendMessageBatchRequest{
+			Entries: []models.SendMessageBatchRequestEntry{
+				{
+					Id:          "test_msg_001",
+					MessageBody: "test%20message%20body%201",
+					MessageSystemAttributes: map[string]models.MessageAttribute{
+						"AWSTraceHeader": {DataType: "String", StringValue: "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1"},
+					},
+				},
+				{
+					Id:          "test_msg_002",
+					MessageBody: "test%20message%20body%202",
+				},
+			},
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "trace-queue"),
+		}
+		return true
+	}
+
+	q := &models.Queue{
+		Name:               "trace-queue",
+		MaximumMessageSize: 1024,
+	}
+	models.SyncQueues.Queues["trace-queue"] = q
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, _ := SendMessageBatchV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, q.Messages, 2)
+	assert.Equal(t, "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1", q.Messages[0].AWSTraceHeader)
+	assert.Empty(t, q.Messages[1].AWSTraceHeader)
+}
+
+func TestSendMessageBatchV1_Error_invalid_message_system_attribute(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SendMessageBatchRequest)
+		*v = models.SendMessageBatchRequest{
+			Entries: []models.SendMessageBatchRequestEntry{
+				{
+					Id:          "test_msg_001",
+					MessageBody: "test%20message%20body%201",
+				},
+				{
+					Id:          "test_msg_002",
+					MessageBody: "test%20message%20body%202",
+					MessageSystemAttributes: map[string]models.MessageAttribute{
+						"AWSTraceHeader": {DataType: "Number", StringValue: "1"},
+					},
+				},
+			},
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "trace-queue"),
+		}
+		return true
+	}
+
+	q := &models.Queue{
+		Name:               "trace-queue",
+		MaximumMessageSize: 1024,
+	}
+	models.SyncQueues.Queues["trace-queue"] = q
+
+	expected := models.ErrorResult{
+		Type:    "InvalidParameterValue",
+		Code:    "AWS.SimpleQueueService.InvalidParameterValue",
+		Message: "The only supported message system attribute is AWSTraceHeader, of type String.",
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, response := SendMessageBatchV1(r)
+	errorResult := response.GetResult().(models.ErrorResult)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, expected, errorResult)
+	assert.Empty(t, q.Messages)
+}
+
 func TestSendMessageBatchV1_Error_NoEntry(t *testing.T) {
 	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
 	defer func() {
@@ -44,6 +44,7 @@ func TestSendMessageV1_Success(t *testing.T) {
 	assert.Equal(t, 1, len(q.Messages))
 	msg := q.Messages[0]
 	assert.Equal(t, "Test Message", string(msg.MessageBody))
+	assert.Equal(t, fixtures.LOCAL_ENVIRONMENT.AccountID, msg.SenderId)
 
 	// Check the response
 	assert.Equal(t, http.StatusOK, status)
@@ -62,8 +63,9 @@ func TestSendMessageV1_Success_FIFOQueue(t *testing.T) {
 	}()
 
 	sendMessageRequest_success := models.SendMessageRequest{
-		QueueUrl:    "http://localhost:4200/new-queue-1",
-		MessageBody: "Test Message",
+		QueueUrl:               "http://localhost:4200/new-queue-1",
+		MessageBody:            "Test Message",
+		MessageDeduplicationId: "1",
 	}
 	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
 		v := resultingStruct.(*models.SendMessageRequest)
@@ -93,6 +95,7 @@ func TestSendMessageV1_Success_FIFOQueue(t *testing.T) {
 	assert.NotEmpty(t, sendMessageResponse.Result.MD5OfMessageBody)
 	// Should have FIFO Sequence
 	assert.NotEmpty(t, sendMessageResponse.Result.SequenceNumber)
+	assert.Equal(t, sendMessageResponse.Result.SequenceNumber, q.Messages[0].SequenceNumber)
 }
 
 func TestSendMessageV1_Success_Deduplication(t *testing.T) {
@@ -138,6 +141,54 @@ func TestSendMessageV1_Success_Deduplication(t *testing.T) {
 	assert.Equal(t, 1, len(q.Messages))
 }
 
+func TestSendMessageV1_Success_Deduplication_per_message_group(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	groupId := "group-1"
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SendMessageRequest)
+		*v = models.SendMessageRequest{
+			QueueUrl:               "http://localhost:4200/new-queue-1.fifo",
+			MessageBody:            "Test Message",
+			MessageGroupId:         groupId,
+			MessageDeduplicationId: "1",
+		}
+		return true
+	}
+
+	q := &models.Queue{
+		Name:               "new-queue-1.fifo",
+		IsFIFO:             true,
+		EnableDuplicates:   true,
+		DeduplicationScope: "messageGroup",
+		Duplicates:         make(map[string]time.Time),
+	}
+	models.SyncQueues.Queues["new-queue-1.fifo"] = q
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, _ := SendMessageV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1, len(q.Messages))
+
+	// The same id in another group isn't a duplicate
+	groupId = "group-2"
+	status, _ = SendMessageV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 2, len(q.Messages))
+
+	// But it still is within the same group
+	status, _ = SendMessageV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 2, len(q.Messages))
+}
+
 func TestSendMessageV1_request_transformer_error(t *testing.T) {
 	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
 	defer func() {
@@ -218,3 +269,187 @@ func TestSendMessageV1_POST_QueueNonExistant(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, "Not Found", errorResponse.Result.Type)
 }
+
+func TestSendMessageV1_enforced_policy_denies_caller_from_other_account(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	models.CurrentEnvironment.EnforcePolicies = true
+	models.CurrentEnvironment.AccessKeys = map[string]string{"other-key": "arn:aws:iam::200020002000:user/other"}
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SendMessageRequest)
+		*v = models.SendMessageRequest{
+			QueueUrl:    "http://localhost:4200/new-queue-1",
+			MessageBody: "Test Message",
+		}
+		return true
+	}
+
+	q := &models.Queue{
+		Name:   "new-queue-1",
+		Arn:    "arn:aws:sqs:us-east-1:100010001000:new-queue-1",
+		Policy: `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::300030003000:root"},"Action":"SQS:SendMessage"}]}`,
+	}
+	models.SyncQueues.Queues["new-queue-1"] = q
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	r.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=other-key/20240101/us-east-1/sqs/aws4_request, SignedHeaders=host, Signature=abc")
+	status, response := SendMessageV1(r)
+
+	assert.Equal(t, http.StatusForbidden, status)
+	errorResponse, ok := response.(models.ErrorResponse)
+	assert.True(t, ok)
+	assert.Equal(t, "AccessDenied", errorResponse.Result.Code)
+	assert.Empty(t, q.Messages)
+
+	q.Policy = `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::200020002000:root"},"Action":"SQS:SendMessage"}]}`
+	status, _ = SendMessageV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1, len(q.Messages))
+}
+
+func TestSendMessageV1_Success_ContentBasedDeduplication(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SendMessageRequest)
+		*v = models.SendMessageRequest{
+			QueueUrl:       "http://localhost:4200/new-queue-1.fifo",
+			MessageBody:    "Test Message",
+			MessageGroupId: "group",
+		}
+		return true
+	}
+
+	q := &models.Queue{
+		Name:                      "new-queue-1.fifo",
+		IsFIFO:                    true,
+		ContentBasedDeduplication: true,
+		Duplicates:                make(map[string]time.Time),
+	}
+	models.SyncQueues.Queues["new-queue-1.fifo"] = q
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, _ := SendMessageV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1, len(q.Messages))
+	assert.Equal(t, "b67d1b3ab0d839eb8bc1156b8717bb441c897fcab323374e2ae530a40632feba", q.Messages[0].DeduplicationID)
+
+	// The same body is a duplicate, even without EnableDuplicates
+	status, _ = SendMessageV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1, len(q.Messages))
+}
+
+func TestSendMessageV1_FIFOQueue_missing_deduplication_id(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SendMessageRequest)
+		*v = models.SendMessageRequest{
+			QueueUrl:       "http://localhost:4200/new-queue-1.fifo",
+			MessageBody:    "Test Message",
+			MessageGroupId: "group",
+		}
+		return true
+	}
+
+	q := &models.Queue{
+		Name:   "new-queue-1.fifo",
+		IsFIFO: true,
+	}
+	models.SyncQueues.Queues["new-queue-1.fifo"] = q
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, response := SendMessageV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+	errorResponse, ok := response.(models.ErrorResponse)
+	assert.True(t, ok)
+	assert.Equal(t, "InvalidParameterValue", errorResponse.Result.Type)
+	assert.Equal(t, "The queue should either have ContentBasedDeduplication enabled or MessageDeduplicationId provided explicitly.", errorResponse.Result.Message)
+	assert.Empty(t, q.Messages)
+}
+
+func TestSendMessageV1_Success_AWSTraceHeader(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SendMessageRequest)
+		*v = models.SendMessageRequest{
+			QueueUrl:    "http://localhost:4200/new-queue-1",
+			MessageBody: "Test Message",
+			MessageSystemAttributes: map[string]models.MessageAttribute{
+				"AWSTraceHeader": {DataType: "String", StringValue: "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1"},
+			},
+		}
+		return true
+	}
+
+	q := &models.Queue{
+		Name:               "new-queue-1",
+		MaximumMessageSize: 1024,
+	}
+	models.SyncQueues.Queues["new-queue-1"] = q
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, _ := SendMessageV1(r)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1, len(q.Messages))
+	assert.Equal(t, "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1", q.Messages[0].AWSTraceHeader)
+}
+
+func TestSendMessageV1_invalid_message_system_attribute(t *testing.T) {
+	models.CurrentEnvironment = fixtures.LOCAL_ENVIRONMENT
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.SendMessageRequest)
+		*v = models.SendMessageRequest{
+			QueueUrl:    "http://localhost:4200/new-queue-1",
+			MessageBody: "Test Message",
+			MessageSystemAttributes: map[string]models.MessageAttribute{
+				"SomethingElse": {DataType: "String", StringValue: "value"},
+			},
+		}
+		return true
+	}
+
+	q := &models.Queue{
+		Name:               "new-queue-1",
+		MaximumMessageSize: 1024,
+	}
+	models.SyncQueues.Queues["new-queue-1"] = q
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	status, response := SendMessageV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+	errorResponse, ok := response.(models.ErrorResponse)
+	assert.True(t, ok)
+	assert.Equal(t, "InvalidParameterValue", errorResponse.Result.Type)
+	assert.Equal(t, "The only supported message system attribute is AWSTraceHeader, of type String.", errorResponse.Result.Message)
+	assert.Empty(t, q.Messages)
+}
@@ -0,0 +1,62 @@
+package gosqs
+
+import (
+	"net/http"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+func StartMessageMoveTaskV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewStartMessageMoveTaskRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - StartMessageMoveTaskV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+
+	if requestBody.SourceArn == "" || requestBody.MaxNumberOfMessagesPerSecond < 0 || requestBody.MaxNumberOfMessagesPerSecond > 500 {
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+
+	sourceName := queueNameFromArn(requestBody.SourceArn)
+	models.SyncQueues.RLock()
+	source, ok := models.SyncQueues.Queues[sourceName]
+	if !ok {
+		models.SyncQueues.RUnlock()
+		return utils.CreateErrorResponseV1("ResourceNotFoundException", true)
+	}
+	if requestBody.DestinationArn != "" {
+		if _, ok := models.SyncQueues.Queues[queueNameFromArn(requestBody.DestinationArn)]; !ok {
+			models.SyncQueues.RUnlock()
+			return utils.CreateErrorResponseV1("ResourceNotFoundException", true)
+		}
+	}
+	// Only a queue that's some other queue's dead-letter queue can be redriven.
+	isDeadLetterQueue := false
+	for _, queue := range models.SyncQueues.Queues {
+		if queue.DeadLetterQueue != nil && queue.DeadLetterQueue.Name == sourceName {
+			isDeadLetterQueue = true
+			break
+		}
+	}
+	toMove := len(source.Messages)
+	models.SyncQueues.RUnlock()
+	if !isDeadLetterQueue {
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+
+	task, ok := startMessageMoveTask(requestBody.SourceArn, requestBody.DestinationArn, requestBody.MaxNumberOfMessagesPerSecond, toMove)
+	if !ok {
+		return utils.CreateErrorResponseV1("MessageMoveTaskRunning", true)
+	}
+
+	respStruct := models.StartMessageMoveTaskResponse{
+		Xmlns:    models.BaseXmlns,
+		Result:   models.StartMessageMoveTaskResult{TaskHandle: task.handle},
+		Metadata: models.BaseResponseMetadata,
+	}
+	return http.StatusOK, respStruct
+}
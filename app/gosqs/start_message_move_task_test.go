@@ -0,0 +1,246 @@
+package gosqs
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitForMoveTask waits for the newest task on the source queue to finish and returns its status.
+func waitForMoveTask(t *testing.T, sourceArn string) string {
+	status := ""
+	assert.Eventually(t, func() bool {
+		messageMoveTasks.Lock()
+		defer messageMoveTasks.Unlock()
+		for i := len(messageMoveTasks.tasks) - 1; i >= 0; i-- {
+			if messageMoveTasks.tasks[i].sourceArn == sourceArn {
+				status = messageMoveTasks.tasks[i].status
+				break
+			}
+		}
+		return status != moveTaskRunning && status != moveTaskCancelling
+	}, 5*time.Second, 10*time.Millisecond)
+	return status
+}
+
+func TestStartMessageMoveTaskV1_success_returns_messages_to_source_queues(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	dlq := models.SyncQueues.Queues["dead-letter-queue1"]
+	dlq.Messages = []models.SqsMessage{
+		{Uuid: "1", MessageBody: "one", DeadLetterSourceQueue: "unit-queue2", NumberOfReceives: 1},
+		{Uuid: "2", MessageBody: "two", DeadLetterSourceQueue: "unit-queue1", NumberOfReceives: 1},
+	}
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.StartMessageMoveTaskRequest)
+		*v = models.StartMessageMoveTaskRequest{
+			SourceArn: dlq.Arn,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := StartMessageMoveTaskV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotEmpty(t, response.(models.StartMessageMoveTaskResponse).Result.TaskHandle)
+	assert.Equal(t, moveTaskCompleted, waitForMoveTask(t, dlq.Arn))
+
+	models.SyncQueues.Lock()
+	defer models.SyncQueues.Unlock()
+	assert.Empty(t, dlq.Messages)
+	assert.Equal(t, []models.SqsMessage{{Uuid: "1", MessageBody: "one"}}, models.SyncQueues.Queues["unit-queue2"].Messages)
+	assert.Equal(t, []models.SqsMessage{{Uuid: "2", MessageBody: "two"}}, models.SyncQueues.Queues["unit-queue1"].Messages)
+}
+
+func TestStartMessageMoveTaskV1_success_moves_to_destination(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	dlq := models.SyncQueues.Queues["dead-letter-queue1"]
+	dlq.Messages = []models.SqsMessage{
+		{Uuid: "1", MessageBody: "one", DeadLetterSourceQueue: "unit-queue2"},
+		{Uuid: "2", MessageBody: "in flight", DeadLetterSourceQueue: "unit-queue2", ReceiptHandle: "handle"},
+	}
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.StartMessageMoveTaskRequest)
+		*v = models.StartMessageMoveTaskRequest{
+			SourceArn:      dlq.Arn,
+			DestinationArn: models.SyncQueues.Queues["subscribed-queue1"].Arn,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := StartMessageMoveTaskV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, moveTaskCompleted, waitForMoveTask(t, dlq.Arn))
+
+	models.SyncQueues.Lock()
+	defer models.SyncQueues.Unlock()
+	// In flight messages stay where they are
+	assert.Len(t, dlq.Messages, 1)
+	assert.Equal(t, "in flight", dlq.Messages[0].MessageBody)
+	assert.Len(t, models.SyncQueues.Queues["subscribed-queue1"].Messages, 1)
+	assert.Empty(t, models.SyncQueues.Queues["unit-queue2"].Messages)
+}
+
+func TestStartMessageMoveTaskV1_fails_when_message_has_no_source_queue(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	dlq := models.SyncQueues.Queues["dead-letter-queue1"]
+	dlq.Messages = []models.SqsMessage{{Uuid: "1", MessageBody: "sent straight to the dlq"}}
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.StartMessageMoveTaskRequest)
+		*v = models.StartMessageMoveTaskRequest{
+			SourceArn: dlq.Arn,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := StartMessageMoveTaskV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, moveTaskFailed, waitForMoveTask(t, dlq.Arn))
+	assert.Len(t, dlq.Messages, 1)
+}
+
+func TestStartMessageMoveTaskV1_error_task_already_running(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	dlq := models.SyncQueues.Queues["dead-letter-queue1"]
+	dlq.Messages = []models.SqsMessage{
+		{Uuid: "1", DeadLetterSourceQueue: "unit-queue2"},
+		{Uuid: "2", DeadLetterSourceQueue: "unit-queue2"},
+	}
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.StartMessageMoveTaskRequest)
+		*v = models.StartMessageMoveTaskRequest{
+			SourceArn:                    dlq.Arn,
+			MaxNumberOfMessagesPerSecond: 1,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := StartMessageMoveTaskV1(r)
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = StartMessageMoveTaskV1(r)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	cancelMessageMoveTask(t, response.(models.StartMessageMoveTaskResponse).Result.TaskHandle)
+	assert.Equal(t, moveTaskCancelled, waitForMoveTask(t, dlq.Arn))
+}
+
+func TestStartMessageMoveTaskV1_error_source_not_a_dead_letter_queue(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.StartMessageMoveTaskRequest)
+		*v = models.StartMessageMoveTaskRequest{
+			SourceArn: models.SyncQueues.Queues["unit-queue1"].Arn,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := StartMessageMoveTaskV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestStartMessageMoveTaskV1_error_queues_not_found(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	requests := []models.StartMessageMoveTaskRequest{
+		{SourceArn: "arn:aws:sqs:region:accountID:garbage"},
+		{SourceArn: models.SyncQueues.Queues["dead-letter-queue1"].Arn, DestinationArn: "arn:aws:sqs:region:accountID:garbage"},
+	}
+	for _, request := range requests {
+		utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+			v := resultingStruct.(*models.StartMessageMoveTaskRequest)
+			*v = request
+			return true
+		}
+
+		_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+		code, response := StartMessageMoveTaskV1(r)
+
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "ResourceNotFoundException", response.(models.ErrorResponse).Result.Code)
+	}
+}
+
+func TestStartMessageMoveTaskV1_error_invalid_rate(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.StartMessageMoveTaskRequest)
+		*v = models.StartMessageMoveTaskRequest{
+			SourceArn:                    models.SyncQueues.Queues["dead-letter-queue1"].Arn,
+			MaxNumberOfMessagesPerSecond: 501,
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := StartMessageMoveTaskV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestStartMessageMoveTaskV1_request_transformer_error(t *testing.T) {
+	defer func() {
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := StartMessageMoveTaskV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
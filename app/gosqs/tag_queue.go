@@ -0,0 +1,50 @@
+package gosqs
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TagQueueV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewTagQueueRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - TagQueueV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+	if len(requestBody.Tags) == 0 {
+		log.Error("Missing Tags - TagQueueV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+
+	uriSegments := strings.Split(requestBody.QueueUrl, "/")
+	queueName := uriSegments[len(uriSegments)-1]
+
+	models.SyncQueues.Lock()
+	defer models.SyncQueues.Unlock()
+	queue, ok := models.SyncQueues.Queues[queueName]
+	if !ok {
+		log.Errorf("Tag Queue: %s, queue does not exist!!!", queueName)
+		return utils.CreateErrorResponseV1("QueueNotFound", true)
+	}
+
+	log.Infof("Tagging Queue: %s", queueName)
+	if queue.Tags == nil {
+		queue.Tags = make(map[string]string)
+	}
+	for key, value := range requestBody.Tags {
+		queue.Tags[key] = value
+	}
+
+	respStruct := models.TagQueueResponse{
+		Xmlns:    models.BaseXmlns,
+		Metadata: models.BaseResponseMetadata,
+	}
+	return http.StatusOK, respStruct
+}
@@ -0,0 +1,136 @@
+package gosqs
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/fixtures"
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/test"
+	"github.com/Admiral-Piett/goaws/app/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagQueueV1_success(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.TagQueueRequest)
+		*v = models.TagQueueRequest{
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+			Tags:     map[string]string{"team": "platform", "existing": "new-value"},
+		}
+		return true
+	}
+
+	targetQueue := models.SyncQueues.Queues["unit-queue1"]
+	targetQueue.Tags = map[string]string{"existing": "old-value", "untouched": "value"}
+
+	expectedResponse := models.TagQueueResponse{
+		Xmlns:    models.BaseXmlns,
+		Metadata: models.BaseResponseMetadata,
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, response := TagQueueV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expectedResponse, response)
+
+	expectedTags := map[string]string{
+		"team":      "platform",
+		"existing":  "new-value",
+		"untouched": "value",
+	}
+	assert.Equal(t, expectedTags, targetQueue.Tags)
+}
+
+func TestTagQueueV1_success_no_existing_tags(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.TagQueueRequest)
+		*v = models.TagQueueRequest{
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+			Tags:     map[string]string{"team": "platform"},
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := TagQueueV1(r)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]string{"team": "platform"}, models.SyncQueues.Queues["unit-queue1"].Tags)
+}
+
+func TestTagQueueV1_request_transformer_error(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		return false
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := TagQueueV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestTagQueueV1_missing_tags(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.TagQueueRequest)
+		*v = models.TagQueueRequest{
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "unit-queue1"),
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := TagQueueV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestTagQueueV1_requested_queue_does_not_exist(t *testing.T) {
+	conf.LoadYamlConfig("../conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		models.ResetApp()
+		utils.REQUEST_TRANSFORMER = utils.TransformRequest
+	}()
+
+	utils.REQUEST_TRANSFORMER = func(resultingStruct interfaces.AbstractRequestBody, req *http.Request, emptyRequestValid bool) (success bool) {
+		v := resultingStruct.(*models.TagQueueRequest)
+		*v = models.TagQueueRequest{
+			QueueUrl: fmt.Sprintf("%s/%s", fixtures.BASE_URL, "garbage"),
+			Tags:     map[string]string{"team": "platform"},
+		}
+		return true
+	}
+
+	_, r := test.GenerateRequestInfo("POST", "/", nil, true)
+	code, _ := TagQueueV1(r)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,47 @@
+package gosqs
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Admiral-Piett/goaws/app/interfaces"
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/Admiral-Piett/goaws/app/utils"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func UntagQueueV1(req *http.Request) (int, interfaces.AbstractResponseBody) {
+	requestBody := models.NewUntagQueueRequest()
+	ok := utils.REQUEST_TRANSFORMER(requestBody, req, false)
+	if !ok {
+		log.Error("Invalid Request - UntagQueueV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+	if len(requestBody.TagKeys) == 0 {
+		log.Error("Missing TagKeys - UntagQueueV1")
+		return utils.CreateErrorResponseV1("InvalidParameterValue", true)
+	}
+
+	uriSegments := strings.Split(requestBody.QueueUrl, "/")
+	queueName := uriSegments[len(uriSegments)-1]
+
+	models.SyncQueues.Lock()
+	defer models.SyncQueues.Unlock()
+	queue, ok := models.SyncQueues.Queues[queueName]
+	if !ok {
+		log.Errorf("Untag Queue: %s, queue does not exist!!!", queueName)
+		return utils.CreateErrorResponseV1("QueueNotFound", true)
+	}
+
+	log.Infof("Untagging Queue: %s", queueName)
+	for _, key := range requestBody.TagKeys {
+		delete(queue.Tags, key)
+	}
+
+	respStruct := models.UntagQueueResponse{
+		Xmlns:    models.BaseXmlns,
+		Metadata: models.BaseResponseMetadata,
+	}
+	return http.StatusOK, respStruct
+}
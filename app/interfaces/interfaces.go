@@ -25,4 +25,5 @@ type AbstractPublishEntry interface {
 	GetMessageAttributes() map[string]models.MessageAttribute
 	GetMessageStructure() string
 	GetSubject() string
+	GetAWSTraceHeader() string
 }
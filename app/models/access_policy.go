@@ -0,0 +1,225 @@
+package models
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// AccessRequest is a call checked against a queue or topic Policy when EnforcePolicies is on.  Callers are
+// identified by Principal, an IAM ARN, and AWS services like SNS by Service.
+type AccessRequest struct {
+	Principal string
+	Service   string
+	Action    string
+	Resource  string
+	SourceArn string
+}
+
+type accessStatement struct {
+	Effect    string                            `json:"Effect"`
+	Principal interface{}                       `json:"Principal"`
+	Action    interface{}                       `json:"Action"`
+	Resource  interface{}                       `json:"Resource"`
+	Condition map[string]map[string]interface{} `json:"Condition"`
+}
+
+// CallerIsAllowed reports whether the caller using the given access key may perform the action on the resource.
+// Only callers whose access key is mapped to a principal in another account are checked - everyone else is
+// treated as the owner of the resource, who doesn't need the policy's permission.
+func CallerIsAllowed(accessKey string, policy string, action string, resource string) bool {
+	if !CurrentEnvironment.EnforcePolicies {
+		return true
+	}
+	principal, ok := CurrentEnvironment.AccessKeys[accessKey]
+	if !ok || arnAccount(principal) == CurrentEnvironment.AccountID {
+		return true
+	}
+	allowed := PolicyAllows(policy, AccessRequest{Principal: principal, Action: action, Resource: resource})
+	if !allowed {
+		log.Warnf("Access denied - Principal: %s, Action: %s, Resource: %s", principal, action, resource)
+	}
+	return allowed
+}
+
+// SenderId is the ID SQS reports as a message's sender: the account of the principal the access key is mapped to,
+// or goaws's own account for everyone else.
+func SenderId(accessKey string) string {
+	if principal, ok := CurrentEnvironment.AccessKeys[accessKey]; ok && arnAccount(principal) != "" {
+		return arnAccount(principal)
+	}
+	return CurrentEnvironment.AccountID
+}
+
+// TopicCanDeliverTo reports whether SNS may deliver the topic's messages into the queue.  Unlike other callers,
+// SNS always needs the queue's permission, even for a topic in the same account.  The caller must hold SyncQueues.
+func TopicCanDeliverTo(queue *Queue, topicArn string) bool {
+	if !CurrentEnvironment.EnforcePolicies {
+		return true
+	}
+	allowed := PolicyAllows(queue.Policy, AccessRequest{
+		Service:   "sns.amazonaws.com",
+		Action:    "SQS:SendMessage",
+		Resource:  queue.Arn,
+		SourceArn: topicArn,
+	})
+	if !allowed {
+		log.Warnf("Access denied - Topic: %s, Action: SQS:SendMessage, Resource: %s", topicArn, queue.Arn)
+	}
+	return allowed
+}
+
+// PolicyAllows evaluates the policy document like IAM does for a resource policy: an explicit Deny wins, otherwise
+// there has to be an Allow.  Principal, Action, Resource and the aws:SourceArn and aws:SourceAccount conditions
+// are supported - a statement using anything else never matches.
+// ref: https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_evaluation-logic.html
+func PolicyAllows(policy string, request AccessRequest) bool {
+	if policy == "" {
+		return false
+	}
+	parsed, err := parsePolicy(policy)
+	if err != nil {
+		log.Debugf("Failed to parse policy: %s", err)
+		return false
+	}
+
+	allowed := false
+	for _, raw := range parsed.Statement {
+		statement := accessStatement{}
+		if err := json.Unmarshal(raw, &statement); err != nil || !statement.matches(request) {
+			continue
+		}
+		if statement.Effect == "Deny" {
+			return false
+		}
+		if statement.Effect == "Allow" {
+			allowed = true
+		}
+	}
+	return allowed
+}
+
+func (s accessStatement) matches(request AccessRequest) bool {
+	if !s.matchesPrincipal(request) {
+		return false
+	}
+	if !matchesAny(policyValues(s.Action), request.Action, true) {
+		return false
+	}
+	if s.Resource != nil && !matchesAny(policyValues(s.Resource), request.Resource, false) {
+		return false
+	}
+	return s.matchesConditions(request)
+}
+
+func (s accessStatement) matchesPrincipal(request AccessRequest) bool {
+	if principal, ok := s.Principal.(string); ok {
+		return principal == "*"
+	}
+	principals, ok := s.Principal.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, value := range policyValues(principals["AWS"]) {
+		if value == "*" {
+			return true
+		}
+		if request.Principal == "" {
+			continue
+		}
+		// An account ID, or the account's root user, stands for every principal in the account
+		if value == request.Principal || value == arnAccount(request.Principal) ||
+			value == "arn:aws:iam::"+arnAccount(request.Principal)+":root" {
+			return true
+		}
+	}
+	for _, value := range policyValues(principals["Service"]) {
+		if request.Service != "" && value == request.Service {
+			return true
+		}
+	}
+	return false
+}
+
+func (s accessStatement) matchesConditions(request AccessRequest) bool {
+	context := map[string]string{}
+	if request.SourceArn != "" {
+		context["aws:sourcearn"] = request.SourceArn
+		context["aws:sourceaccount"] = arnAccount(request.SourceArn)
+	}
+
+	for operator, conditions := range s.Condition {
+		for key, expected := range conditions {
+			actual, ok := context[strings.ToLower(key)]
+			if !ok {
+				return false
+			}
+			switch operator {
+			case "ArnEquals", "StringEquals":
+				if !containsValue(policyValues(expected), actual) {
+					return false
+				}
+			case "ArnLike", "StringLike":
+				if !matchesAny(policyValues(expected), actual, false) {
+					return false
+				}
+			default:
+				log.Debugf("Unsupported policy condition operator: %s", operator)
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// policyValues reads a policy element that can be either a single string or a list of them.
+func policyValues(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := []string{}
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				values = append(values, str)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny matches the value against patterns using the `*` and `?` wildcards.
+func matchesAny(patterns []string, value string, ignoreCase bool) bool {
+	for _, pattern := range patterns {
+		expression := regexp.QuoteMeta(pattern)
+		expression = strings.ReplaceAll(expression, `\*`, ".*")
+		expression = strings.ReplaceAll(expression, `\?`, ".")
+		if ignoreCase {
+			expression = "(?i)" + expression
+		}
+		if regexp.MustCompile("^" + expression + "$").MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func arnAccount(arn string) string {
+	arnSegments := strings.Split(arn, ":")
+	if len(arnSegments) < 5 {
+		return ""
+	}
+	return arnSegments[4]
+}
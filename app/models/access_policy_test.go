@@ -0,0 +1,160 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const accessTestQueueArn = "arn:aws:sqs:region:100010001000:queue"
+const accessTestTopicArn = "arn:aws:sns:region:100010001000:topic"
+
+func TestPolicyAllows_empty_policy_denies(t *testing.T) {
+	allowed := PolicyAllows("", AccessRequest{Principal: "arn:aws:iam::200020002000:root", Action: "SQS:SendMessage", Resource: accessTestQueueArn})
+
+	assert.False(t, allowed)
+}
+
+func TestPolicyAllows_invalid_policy_denies(t *testing.T) {
+	allowed := PolicyAllows("garbage", AccessRequest{Principal: "arn:aws:iam::200020002000:root", Action: "SQS:SendMessage", Resource: accessTestQueueArn})
+
+	assert.False(t, allowed)
+}
+
+func TestPolicyAllows_principals(t *testing.T) {
+	request := AccessRequest{Principal: "arn:aws:iam::200020002000:user/other", Action: "SQS:SendMessage", Resource: accessTestQueueArn}
+	cases := map[string]bool{
+		`"*"`:         true,
+		`{"AWS":"*"}`: true,
+		`{"AWS":"arn:aws:iam::200020002000:user/other"}`:        true,
+		`{"AWS":["123","arn:aws:iam::200020002000:root"]}`:      true,
+		`{"AWS":"200020002000"}`:                                true,
+		`{"AWS":"arn:aws:iam::200020002000:user/someone-else"}`: false,
+		`{"AWS":"arn:aws:iam::300030003000:root"}`:              false,
+		`{"Service":"sns.amazonaws.com"}`:                       false,
+	}
+	for principal, expected := range cases {
+		policy := `{"Statement":[{"Effect":"Allow","Principal":` + principal + `,"Action":"SQS:SendMessage","Resource":"` + accessTestQueueArn + `"}]}`
+
+		assert.Equal(t, expected, PolicyAllows(policy, request), principal)
+	}
+}
+
+func TestPolicyAllows_actions_and_resources(t *testing.T) {
+	request := AccessRequest{Principal: "arn:aws:iam::200020002000:root", Action: "SQS:SendMessage", Resource: accessTestQueueArn}
+	cases := map[string]bool{
+		`"Action":"sqs:sendmessage","Resource":"` + accessTestQueueArn + `"`:     true,
+		`"Action":["SQS:ReceiveMessage","SQS:*"],"Resource":"arn:aws:sqs:*:*:*"`: true,
+		`"Action":"SQS:Send*"`: true,
+		`"Action":"*","Resource":"arn:aws:sqs:region:100010001000:que?e"`:               true,
+		`"Action":"SQS:ReceiveMessage","Resource":"*"`:                                  false,
+		`"Action":"SQS:SendMessage","Resource":"arn:aws:sqs:region:100010001000:other"`: false,
+	}
+	for elements, expected := range cases {
+		policy := `{"Statement":[{"Effect":"Allow","Principal":"*",` + elements + `}]}`
+
+		assert.Equal(t, expected, PolicyAllows(policy, request), elements)
+	}
+}
+
+func TestPolicyAllows_source_arn_conditions(t *testing.T) {
+	request := AccessRequest{Service: "sns.amazonaws.com", Action: "SQS:SendMessage", Resource: accessTestQueueArn, SourceArn: accessTestTopicArn}
+	cases := map[string]bool{
+		`{"ArnEquals":{"aws:SourceArn":"` + accessTestTopicArn + `"}}`:                              true,
+		`{"ArnLike":{"aws:SourceArn":"arn:aws:sns:*:100010001000:*"}}`:                              true,
+		`{"StringEquals":{"aws:SourceAccount":["100010001000","200020002000"]}}`:                    true,
+		`{"ArnEquals":{"aws:SourceArn":"arn:aws:sns:region:100010001000:other"}}`:                   false,
+		`{"StringEquals":{"aws:SourceAccount":"200020002000"}}`:                                     false,
+		`{"ArnEquals":{"aws:SourceArn":"` + accessTestTopicArn + `","aws:PrincipalOrgID":"o-123"}}`: false,
+		`{"NumericEquals":{"aws:SourceArn":"1"}}`:                                                   false,
+	}
+	for condition, expected := range cases {
+		policy := `{"Statement":[{"Effect":"Allow","Principal":{"Service":"sns.amazonaws.com"},"Action":"SQS:SendMessage","Resource":"` + accessTestQueueArn + `","Condition":` + condition + `}]}`
+
+		assert.Equal(t, expected, PolicyAllows(policy, request), condition)
+	}
+}
+
+func TestPolicyAllows_source_arn_condition_without_source_denies(t *testing.T) {
+	policy := `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"SQS:SendMessage","Condition":{"ArnEquals":{"aws:SourceArn":"` + accessTestTopicArn + `"}}}]}`
+
+	allowed := PolicyAllows(policy, AccessRequest{Principal: "arn:aws:iam::200020002000:root", Action: "SQS:SendMessage", Resource: accessTestQueueArn})
+
+	assert.False(t, allowed)
+}
+
+func TestPolicyAllows_explicit_deny_wins(t *testing.T) {
+	policy := `{"Statement":[
+		{"Effect":"Allow","Principal":"*","Action":"SQS:*"},
+		{"Effect":"Deny","Principal":{"AWS":"200020002000"},"Action":"SQS:SendMessage"}
+	]}`
+
+	assert.False(t, PolicyAllows(policy, AccessRequest{Principal: "arn:aws:iam::200020002000:root", Action: "SQS:SendMessage", Resource: accessTestQueueArn}))
+	assert.True(t, PolicyAllows(policy, AccessRequest{Principal: "arn:aws:iam::300030003000:root", Action: "SQS:SendMessage", Resource: accessTestQueueArn}))
+}
+
+func TestPolicyAllows_single_statement_object(t *testing.T) {
+	policy := `{"Statement":{"Effect":"Allow","Principal":"*","Action":"SQS:SendMessage"}}`
+
+	assert.True(t, PolicyAllows(policy, AccessRequest{Principal: "arn:aws:iam::200020002000:root", Action: "SQS:SendMessage", Resource: accessTestQueueArn}))
+}
+
+func TestCallerIsAllowed(t *testing.T) {
+	original := CurrentEnvironment
+	defer func() {
+		CurrentEnvironment = original
+	}()
+	CurrentEnvironment = Environment{
+		AccountID:       "100010001000",
+		EnforcePolicies: true,
+		AccessKeys: map[string]string{
+			"owner": "arn:aws:iam::100010001000:user/owner",
+			"other": "arn:aws:iam::200020002000:user/other",
+		},
+	}
+	policy := `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::200020002000:user/other"},"Action":"SQS:SendMessage"}]}`
+
+	assert.True(t, CallerIsAllowed("unknown", "", "SQS:SendMessage", accessTestQueueArn))
+	assert.True(t, CallerIsAllowed("owner", "", "SQS:SendMessage", accessTestQueueArn))
+	assert.False(t, CallerIsAllowed("other", "", "SQS:SendMessage", accessTestQueueArn))
+	assert.True(t, CallerIsAllowed("other", policy, "SQS:SendMessage", accessTestQueueArn))
+	assert.False(t, CallerIsAllowed("other", policy, "SQS:DeleteMessage", accessTestQueueArn))
+
+	CurrentEnvironment.EnforcePolicies = false
+	assert.True(t, CallerIsAllowed("other", "", "SQS:SendMessage", accessTestQueueArn))
+}
+
+func TestSenderId(t *testing.T) {
+	original := CurrentEnvironment
+	defer func() {
+		CurrentEnvironment = original
+	}()
+	CurrentEnvironment = Environment{
+		AccountID: "100010001000",
+		AccessKeys: map[string]string{
+			"other": "arn:aws:iam::200020002000:user/other",
+		},
+	}
+
+	assert.Equal(t, "200020002000", SenderId("other"))
+	assert.Equal(t, "100010001000", SenderId("unknown"))
+	assert.Equal(t, "100010001000", SenderId(""))
+}
+
+func TestTopicCanDeliverTo(t *testing.T) {
+	original := CurrentEnvironment
+	defer func() {
+		CurrentEnvironment = original
+	}()
+	CurrentEnvironment = Environment{EnforcePolicies: true}
+	queue := &Queue{Arn: accessTestQueueArn}
+
+	assert.False(t, TopicCanDeliverTo(queue, accessTestTopicArn))
+
+	queue.Policy = `{"Statement":[{"Effect":"Allow","Principal":{"Service":"sns.amazonaws.com"},"Action":"SQS:SendMessage","Resource":"` + accessTestQueueArn + `","Condition":{"ArnEquals":{"aws:SourceArn":"` + accessTestTopicArn + `"}}}]}`
+	assert.True(t, TopicCanDeliverTo(queue, accessTestTopicArn))
+	assert.False(t, TopicCanDeliverTo(queue, "arn:aws:sns:region:100010001000:other"))
+
+	CurrentEnvironment.EnforcePolicies = false
+	assert.True(t, TopicCanDeliverTo(&Queue{Arn: accessTestQueueArn}, accessTestTopicArn))
+}
@@ -2,17 +2,20 @@ package models
 
 /*** config ***/
 type EnvSubsciption struct {
-	Protocol     string
-	EndPoint     string
-	TopicArn     string
-	QueueName    string
-	Raw          bool
-	FilterPolicy string
+	Protocol          string
+	EndPoint          string
+	TopicArn          string
+	QueueName         string
+	Raw               bool
+	FilterPolicy      string
+	FilterPolicyScope string
+	RedrivePolicy     string
 }
 
 type EnvTopic struct {
 	Name          string
 	Subscriptions []EnvSubsciption
+	Tags          map[string]string
 }
 
 type EnvQueue struct {
@@ -22,6 +25,11 @@ type EnvQueue struct {
 	MaximumMessageSize            int
 	VisibilityTimeout             int
 	MessageRetentionPeriod        int
+	Policy                        string
+	ContentBasedDeduplication     bool
+	DeduplicationScope            string
+	FifoThroughputLimit           string
+	Tags                          map[string]string
 }
 
 type EnvQueueAttributes struct {
@@ -45,6 +53,10 @@ type Environment struct {
 	Queues                 []EnvQueue
 	QueueAttributeDefaults EnvQueueAttributes
 	RandomLatency          RandomLatency
+	SigningCertFile        string
+	SigningKeyFile         string
+	EnforcePolicies        bool
+	AccessKeys             map[string]string // access key ID -> principal ARN
 }
 
 type RandomLatency struct {
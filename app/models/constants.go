@@ -9,6 +9,20 @@ var BaseResponseMetadata = ResponseMetadata{RequestId: "00000000-0000-0000-0000-
 
 var DeduplicationPeriod = 5 * time.Minute
 
+// The FIFO queue DeduplicationScope and FifoThroughputLimit values.  The first of each is the default.
+const (
+	DeduplicationScopeQueue              = "queue"
+	DeduplicationScopeMessageGroup       = "messageGroup"
+	FifoThroughputLimitPerQueue          = "perQueue"
+	FifoThroughputLimitPerMessageGroupId = "perMessageGroupId"
+)
+
+// MaxTopicTags is the most tags AWS allows on a single SNS topic.
+var MaxTopicTags = 50
+
+// DefaultEffectiveDeliveryPolicy is what AWS reports as a topic's EffectiveDeliveryPolicy when none has been set.
+var DefaultEffectiveDeliveryPolicy = `{"http":{"defaultHealthyRetryPolicy":{"minDelayTarget":20,"maxDelayTarget":20,"numRetries":3,"numMaxDelayRetries":0,"numNoDelayRetries":0,"numMinDelayRetries":0,"backoffFunction":"linear"},"disableSubscriptionOverrides":false,"defaultRequestPolicy":{"headerContentType":"text/plain; charset=UTF-8"}}}`
+
 var AvailableQueueAttributes = map[string]bool{
 	"DelaySeconds":                          true,
 	"MaximumMessageSize":                    true,
@@ -18,6 +32,9 @@ var AvailableQueueAttributes = map[string]bool{
 	"VisibilityTimeout":                     true,
 	"RedrivePolicy":                         true,
 	"RedriveAllowPolicy":                    true,
+	"ContentBasedDeduplication":             true,
+	"DeduplicationScope":                    true,
+	"FifoThroughputLimit":                   true,
 	"ApproximateNumberOfMessages":           true,
 	"ApproximateNumberOfMessagesDelayed":    true,
 	"ApproximateNumberOfMessagesNotVisible": true,
@@ -36,3 +53,14 @@ const (
 const (
 	MessageStructureJSON MessageStructure = "json"
 )
+
+const (
+	FilterPolicyScopeMessageAttributes = "MessageAttributes"
+	FilterPolicyScopeMessageBody       = "MessageBody"
+)
+
+// What AWS shows in place of the ARN of a subscription that hasn't been confirmed yet.
+const (
+	PendingConfirmationSubscriptionArn = "pending confirmation"
+	PendingConfirmationListedArn       = "PendingConfirmation"
+)
@@ -73,3 +73,39 @@ func TestStringToInt_int_returns_int_type(t *testing.T) {
 
 	assert.Equal(t, int(1), s.Int())
 }
+
+type StringToBoolStruct struct {
+	Field1 StringToBool `json:"Field1"`
+	Field2 StringToBool `json:"Field2"`
+}
+
+func TestStringToBool_unmarshalJSON_bool(t *testing.T) {
+	result := &StringToBoolStruct{}
+	err := json.Unmarshal([]byte(`{"Field1": true, "Field2": false}`), result)
+
+	assert.Nil(t, err)
+	assert.Equal(t, StringToBool(true), result.Field1)
+	assert.Equal(t, StringToBool(false), result.Field2)
+}
+
+func TestStringToBool_unmarshalJSON_string(t *testing.T) {
+	result := &StringToBoolStruct{}
+	err := json.Unmarshal([]byte(`{"Field1": "true", "Field2": "false"}`), result)
+
+	assert.Nil(t, err)
+	assert.Equal(t, StringToBool(true), result.Field1)
+	assert.Equal(t, StringToBool(false), result.Field2)
+}
+
+func TestStringToBool_unmarshalJSON_invalid_type_returns_error(t *testing.T) {
+	result := &StringToBoolStruct{}
+	err := json.Unmarshal([]byte(`{"Field1": "garbage"}`), result)
+
+	assert.Error(t, err)
+}
+
+func TestStringToBool_bool_returns_bool_type(t *testing.T) {
+	s := StringToBool(true)
+
+	assert.Equal(t, true, s.Bool())
+}
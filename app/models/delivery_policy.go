@@ -0,0 +1,133 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+)
+
+// DeliveryPolicy is the subscription level delivery policy for HTTP/S endpoints.
+// ref: https://docs.aws.amazon.com/sns/latest/dg/sns-message-delivery-retries.html
+type DeliveryPolicy struct {
+	HealthyRetryPolicy *RetryPolicy    `json:"healthyRetryPolicy,omitempty"`
+	ThrottlePolicy     *ThrottlePolicy `json:"throttlePolicy,omitempty"`
+}
+
+// SubscriptionRedrivePolicy names the SQS queue that messages go to when they can't be delivered to a subscription.
+// ref: https://docs.aws.amazon.com/sns/latest/dg/sns-dead-letter-queues.html
+type SubscriptionRedrivePolicy struct {
+	DeadLetterTargetArn string `json:"deadLetterTargetArn"`
+}
+
+// TopicDeliveryPolicy is the topic level delivery policy, which sets the defaults for each protocol.
+type TopicDeliveryPolicy struct {
+	HTTP *TopicHTTPDeliveryPolicy `json:"http,omitempty"`
+}
+
+type TopicHTTPDeliveryPolicy struct {
+	DefaultHealthyRetryPolicy    *RetryPolicy    `json:"defaultHealthyRetryPolicy,omitempty"`
+	DefaultThrottlePolicy        *ThrottlePolicy `json:"defaultThrottlePolicy,omitempty"`
+	DisableSubscriptionOverrides bool            `json:"disableSubscriptionOverrides"`
+}
+
+type RetryPolicy struct {
+	MinDelayTarget     int    `json:"minDelayTarget"`
+	MaxDelayTarget     int    `json:"maxDelayTarget"`
+	NumRetries         int    `json:"numRetries"`
+	NumNoDelayRetries  int    `json:"numNoDelayRetries"`
+	NumMinDelayRetries int    `json:"numMinDelayRetries"`
+	NumMaxDelayRetries int    `json:"numMaxDelayRetries"`
+	BackoffFunction    string `json:"backoffFunction"`
+}
+
+type ThrottlePolicy struct {
+	MaxReceivesPerSecond int `json:"maxReceivesPerSecond"`
+}
+
+// ResolveDeliveryPolicy works out the policy used to deliver to an HTTP/S subscription.  The subscription's own
+// policy wins unless the topic disables overrides, then the topic's defaults, and finally the AWS defaults.
+func ResolveDeliveryPolicy(topicDeliveryPolicy string, subscriptionPolicy *DeliveryPolicy) DeliveryPolicy {
+	result := DeliveryPolicy{}
+	for _, policy := range []string{DefaultEffectiveDeliveryPolicy, topicDeliveryPolicy} {
+		topicPolicy := TopicDeliveryPolicy{}
+		if policy == "" || json.Unmarshal([]byte(policy), &topicPolicy) != nil || topicPolicy.HTTP == nil {
+			continue
+		}
+		if topicPolicy.HTTP.DefaultHealthyRetryPolicy != nil {
+			result.HealthyRetryPolicy = topicPolicy.HTTP.DefaultHealthyRetryPolicy
+		}
+		if topicPolicy.HTTP.DefaultThrottlePolicy != nil {
+			result.ThrottlePolicy = topicPolicy.HTTP.DefaultThrottlePolicy
+		}
+		if topicPolicy.HTTP.DisableSubscriptionOverrides {
+			return result
+		}
+	}
+	if subscriptionPolicy != nil {
+		if subscriptionPolicy.HealthyRetryPolicy != nil {
+			result.HealthyRetryPolicy = subscriptionPolicy.HealthyRetryPolicy
+		}
+		if subscriptionPolicy.ThrottlePolicy != nil {
+			result.ThrottlePolicy = subscriptionPolicy.ThrottlePolicy
+		}
+	}
+	return result
+}
+
+// Delays returns how long to wait before each retry.  Like AWS, the retries go through four phases: immediate
+// retries, retries at the minimum delay, a backoff phase from the minimum to the maximum delay, and retries at
+// the maximum delay.
+func (p RetryPolicy) Delays() []time.Duration {
+	minDelay := time.Duration(p.MinDelayTarget) * time.Second
+	maxDelay := time.Duration(p.MaxDelayTarget) * time.Second
+	if maxDelay < minDelay {
+		maxDelay = minDelay
+	}
+
+	remaining := p.NumRetries
+	take := func(count int) int {
+		if count < 0 {
+			count = 0
+		}
+		if count > remaining {
+			count = remaining
+		}
+		remaining -= count
+		return count
+	}
+
+	delays := make([]time.Duration, 0, p.NumRetries)
+	for i := take(p.NumNoDelayRetries); i > 0; i-- {
+		delays = append(delays, 0)
+	}
+	for i := take(p.NumMinDelayRetries); i > 0; i-- {
+		delays = append(delays, minDelay)
+	}
+	numMaxDelayRetries := take(p.NumMaxDelayRetries)
+	numBackoffRetries := take(remaining)
+	for i := 1; i <= numBackoffRetries; i++ {
+		delays = append(delays, p.backoffDelay(i, numBackoffRetries, minDelay, maxDelay))
+	}
+	for i := numMaxDelayRetries; i > 0; i-- {
+		delays = append(delays, maxDelay)
+	}
+	return delays
+}
+
+// backoffDelay is the delay for the step'th of the steps backoff retries, growing from minDelay to maxDelay
+// along the policy's backoff function.
+func (p RetryPolicy) backoffDelay(step int, steps int, minDelay time.Duration, maxDelay time.Duration) time.Duration {
+	progress := float64(step) / float64(steps)
+	switch p.BackoffFunction {
+	case "arithmetic":
+		progress = float64(step*(step+1)) / float64(steps*(steps+1))
+	case "geometric":
+		if minDelay > 0 {
+			return time.Duration(float64(minDelay) * math.Pow(float64(maxDelay)/float64(minDelay), progress))
+		}
+		progress = math.Pow(progress, 2)
+	case "exponential":
+		progress = (math.Pow(2, float64(step)) - 1) / (math.Pow(2, float64(steps)) - 1)
+	}
+	return minDelay + time.Duration(float64(maxDelay-minDelay)*progress)
+}
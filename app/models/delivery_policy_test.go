@@ -0,0 +1,111 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveDeliveryPolicy_defaults(t *testing.T) {
+	result := ResolveDeliveryPolicy("", nil)
+
+	expected := DeliveryPolicy{
+		HealthyRetryPolicy: &RetryPolicy{MinDelayTarget: 20, MaxDelayTarget: 20, NumRetries: 3, BackoffFunction: "linear"},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestResolveDeliveryPolicy_topic_overrides_defaults(t *testing.T) {
+	topicPolicy := `{"http": {"defaultHealthyRetryPolicy": {"numRetries": 10, "minDelayTarget": 1, "maxDelayTarget": 5}, "defaultThrottlePolicy": {"maxReceivesPerSecond": 2}}}`
+
+	result := ResolveDeliveryPolicy(topicPolicy, nil)
+
+	expected := DeliveryPolicy{
+		HealthyRetryPolicy: &RetryPolicy{MinDelayTarget: 1, MaxDelayTarget: 5, NumRetries: 10},
+		ThrottlePolicy:     &ThrottlePolicy{MaxReceivesPerSecond: 2},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestResolveDeliveryPolicy_subscription_overrides_topic(t *testing.T) {
+	topicPolicy := `{"http": {"defaultHealthyRetryPolicy": {"numRetries": 10}, "defaultThrottlePolicy": {"maxReceivesPerSecond": 2}}}`
+	subscriptionPolicy := &DeliveryPolicy{HealthyRetryPolicy: &RetryPolicy{NumRetries: 1}}
+
+	result := ResolveDeliveryPolicy(topicPolicy, subscriptionPolicy)
+
+	expected := DeliveryPolicy{
+		HealthyRetryPolicy: &RetryPolicy{NumRetries: 1},
+		ThrottlePolicy:     &ThrottlePolicy{MaxReceivesPerSecond: 2},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestResolveDeliveryPolicy_topic_disables_subscription_overrides(t *testing.T) {
+	topicPolicy := `{"http": {"defaultHealthyRetryPolicy": {"numRetries": 10}, "disableSubscriptionOverrides": true}}`
+	subscriptionPolicy := &DeliveryPolicy{HealthyRetryPolicy: &RetryPolicy{NumRetries: 1}}
+
+	result := ResolveDeliveryPolicy(topicPolicy, subscriptionPolicy)
+
+	assert.Equal(t, &RetryPolicy{NumRetries: 10}, result.HealthyRetryPolicy)
+}
+
+func TestResolveDeliveryPolicy_ignores_invalid_topic_policy(t *testing.T) {
+	result := ResolveDeliveryPolicy("garbage", nil)
+
+	assert.Equal(t, 3, result.HealthyRetryPolicy.NumRetries)
+}
+
+func TestRetryPolicy_Delays_phases(t *testing.T) {
+	policy := RetryPolicy{
+		NumRetries:         8,
+		NumNoDelayRetries:  2,
+		NumMinDelayRetries: 1,
+		NumMaxDelayRetries: 2,
+		MinDelayTarget:     1,
+		MaxDelayTarget:     4,
+		BackoffFunction:    "linear",
+	}
+
+	expected := []time.Duration{
+		0, 0,
+		time.Second,
+		2 * time.Second, 3 * time.Second, 4 * time.Second,
+		4 * time.Second, 4 * time.Second,
+	}
+	assert.Equal(t, expected, policy.Delays())
+}
+
+func TestRetryPolicy_Delays_phases_capped_by_num_retries(t *testing.T) {
+	policy := RetryPolicy{
+		NumRetries:         3,
+		NumNoDelayRetries:  2,
+		NumMinDelayRetries: 5,
+		MinDelayTarget:     1,
+		MaxDelayTarget:     4,
+	}
+
+	assert.Equal(t, []time.Duration{0, 0, time.Second}, policy.Delays())
+}
+
+func TestRetryPolicy_Delays_no_retries(t *testing.T) {
+	assert.Empty(t, RetryPolicy{}.Delays())
+}
+
+func TestRetryPolicy_Delays_backoff_functions(t *testing.T) {
+	cases := map[string][]time.Duration{
+		"linear":      {4 * time.Second, 7 * time.Second, 10 * time.Second},
+		"arithmetic":  {2500 * time.Millisecond, 5500 * time.Millisecond, 10 * time.Second},
+		"geometric":   {2154434690, 4641588834, 10 * time.Second},
+		"exponential": {2285714285, 4857142857, 10 * time.Second},
+	}
+	for backoffFunction, expected := range cases {
+		policy := RetryPolicy{NumRetries: 3, MinDelayTarget: 1, MaxDelayTarget: 10, BackoffFunction: backoffFunction}
+		delays := policy.Delays()
+
+		assert.Len(t, delays, len(expected), backoffFunction)
+		for i := range expected {
+			assert.InDelta(t, float64(expected[i]), float64(delays[i]), float64(time.Millisecond), backoffFunction)
+		}
+	}
+}
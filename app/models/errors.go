@@ -16,6 +16,20 @@ func init() {
 		"MessageTooBig":                {HttpError: http.StatusBadRequest, Type: "MessageTooBig", Code: "InvalidParameterValue", Message: "The message size exceeds the limit."},
 		"InvalidParameterValue":        {HttpError: http.StatusBadRequest, Type: "InvalidParameterValue", Code: "AWS.SimpleQueueService.InvalidParameterValue", Message: "An invalid or out-of-range value was supplied for the input parameter."},
 		"InvalidAttributeValue":        {HttpError: http.StatusBadRequest, Type: "InvalidAttributeValue", Code: "AWS.SimpleQueueService.InvalidAttributeValue", Message: "Invalid Value for the parameter RedrivePolicy."},
+		"InvalidPolicy":                {HttpError: http.StatusBadRequest, Type: "InvalidAttributeValue", Code: "AWS.SimpleQueueService.InvalidAttributeValue", Message: "Invalid value for the parameter Policy."},
+		"InvalidRedriveAllowPolicy":    {HttpError: http.StatusBadRequest, Type: "InvalidAttributeValue", Code: "AWS.SimpleQueueService.InvalidAttributeValue", Message: "Invalid value for the parameter RedriveAllowPolicy."},
+		"RedriveNotAllowed":            {HttpError: http.StatusBadRequest, Type: "InvalidParameterValue", Code: "AWS.SimpleQueueService.InvalidParameterValue", Message: "Value for parameter RedrivePolicy is invalid. Reason: The dead-letter queue's RedriveAllowPolicy does not allow this source queue."},
+		"PermissionLabelExists":        {HttpError: http.StatusBadRequest, Type: "InvalidParameterValue", Code: "AWS.SimpleQueueService.InvalidParameterValue", Message: "Value for parameter Label is invalid. Reason: Already exists."},
+		"PermissionLabelNotFound":      {HttpError: http.StatusBadRequest, Type: "InvalidParameterValue", Code: "AWS.SimpleQueueService.InvalidParameterValue", Message: "Value for parameter Label is invalid. Reason: can't find label."},
+		"ResourceNotFoundException":    {HttpError: http.StatusBadRequest, Type: "ResourceNotFoundException", Code: "ResourceNotFoundException", Message: "One or more specified resources don't exist."},
+		"MessageMoveTaskRunning":       {HttpError: http.StatusBadRequest, Type: "UnsupportedOperation", Code: "AWS.SimpleQueueService.UnsupportedOperation", Message: "A message move task is already running for the source queue."},
+		"MessageMoveTaskNotRunning":    {HttpError: http.StatusBadRequest, Type: "UnsupportedOperation", Code: "AWS.SimpleQueueService.UnsupportedOperation", Message: "Only a running message move task can be cancelled."},
+		"InvalidDeduplicationScope":    {HttpError: http.StatusBadRequest, Type: "InvalidAttributeValue", Code: "AWS.SimpleQueueService.InvalidAttributeValue", Message: "Invalid value for the parameter DeduplicationScope."},
+		"InvalidFifoThroughputLimit":   {HttpError: http.StatusBadRequest, Type: "InvalidAttributeValue", Code: "AWS.SimpleQueueService.InvalidAttributeValue", Message: "Invalid value for the parameter FifoThroughputLimit. Reason: perMessageGroupId is only allowed when DeduplicationScope is messageGroup."},
+		"MissingDeduplicationId":       {HttpError: http.StatusBadRequest, Type: "InvalidParameterValue", Code: "AWS.SimpleQueueService.InvalidParameterValue", Message: "The queue should either have ContentBasedDeduplication enabled or MessageDeduplicationId provided explicitly."},
+		"InvalidAttributeName":         {HttpError: http.StatusBadRequest, Type: "InvalidAttributeName", Code: "AWS.SimpleQueueService.InvalidAttributeName", Message: "Unknown Attribute. This attribute is only supported by FIFO queues."},
+		"AccessDenied":                 {HttpError: http.StatusForbidden, Type: "AccessDenied", Code: "AccessDenied", Message: "Access to the resource is denied."},
+		"InvalidSystemAttribute":       {HttpError: http.StatusBadRequest, Type: "InvalidParameterValue", Code: "AWS.SimpleQueueService.InvalidParameterValue", Message: "The only supported message system attribute is AWSTraceHeader, of type String."},
 	}
 	SnsErrors = map[string]SnsErrorType{
 		"InvalidParameterValue":        {HttpError: http.StatusBadRequest, Type: "InvalidParameterValue", Code: "AWS.SimpleNotificationService.InvalidParameterValue", Message: "An invalid or out-of-range value was supplied for the input parameter."},
@@ -23,10 +37,14 @@ func init() {
 		"SubscriptionNotFound":         {HttpError: http.StatusNotFound, Type: "Not Found", Code: "AWS.SimpleNotificationService.NonExistentSubscription", Message: "The specified subscription does not exist for this wsdl version."},
 		"TopicExists":                  {HttpError: http.StatusBadRequest, Type: "Duplicate", Code: "AWS.SimpleNotificationService.TopicAlreadyExists", Message: "The specified topic already exists."},
 		"ValidationError":              {HttpError: http.StatusBadRequest, Type: "InvalidParameter", Code: "AWS.SimpleNotificationService.ValidationError", Message: "The input fails to satisfy the constraints specified by an AWS service."},
+		"InvalidParameter":             {HttpError: http.StatusBadRequest, Type: "InvalidParameter", Code: "AWS.SimpleNotificationService.InvalidParameter", Message: "Invalid parameter: Attributes. The FilterPolicy or FilterPolicyScope is invalid."},
 		"BatchEntryIdsNotDistinct":     {HttpError: http.StatusBadRequest, Type: "BatchEntryIdsNotDistinct", Code: "AWS.SimpleNotificationService.BatchEntryIdsNotDistinct", Message: "Two or more batch entries in the request have the same Id."},
 		"EmptyBatchRequest":            {HttpError: http.StatusBadRequest, Type: "EmptyBatchRequest", Code: "AWS.SimpleNotificationService.EmptyBatchRequest", Message: "The batch request doesn't contain any entries."},
 		"TooManyEntriesInBatchRequest": {HttpError: http.StatusBadRequest, Type: "TooManyEntriesInBatchRequest", Code: "AWS.SimpleNotificationService.TooManyEntriesInBatchRequest", Message: "Maximum number of entries per request are 10."},
 		"MalformedInput":               {HttpError: http.StatusBadRequest, Type: "Sender", Code: "AWS.SimpleNotificationService.MalformedInput", Message: "Invalid Base64 encoding"},
+		"ResourceNotFound":             {HttpError: http.StatusNotFound, Type: "Not Found", Code: "AWS.SimpleNotificationService.ResourceNotFound", Message: "Can't find the requested resource."},
+		"TagLimitExceeded":             {HttpError: http.StatusBadRequest, Type: "TagLimitExceeded", Code: "AWS.SimpleNotificationService.TagLimitExceeded", Message: "Can't add more than 50 tags to a topic."},
+		"AuthorizationError":           {HttpError: http.StatusForbidden, Type: "AuthorizationError", Code: "AuthorizationError", Message: "You are not authorized to perform this action."},
 	}
 }
 
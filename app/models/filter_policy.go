@@ -0,0 +1,362 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// FilterPolicy is a decoded SNS subscription filter policy.  Each key maps either to a list of conditions (any of
+// which may match) or, for message body policies, to a nested policy.  The special `$or` key holds a list of
+// policies, any of which may match.
+// ref: https://docs.aws.amazon.com/sns/latest/dg/sns-subscription-filter-policies.html
+type FilterPolicy map[string]interface{}
+
+// IsSatisfiedByBody checks the published message body against the FilterPolicy, for subscriptions with a
+// MessageBody FilterPolicyScope.  Messages that aren't JSON objects never match a non-empty policy.
+func (fp *FilterPolicy) IsSatisfiedByBody(body string) bool {
+	if len(*fp) == 0 {
+		return true
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &values); err != nil {
+		return false
+	}
+	return matchesPolicy(*fp, values)
+}
+
+// unfilterable stands in for attributes that are present on a message but can't be compared against anything
+// (e.g. Binary attributes).  They still satisfy an `exists` condition.
+type unfilterable struct{}
+
+// IsSatisfiedBy checks if the MessageAttributes passed to a Topic satisfy the FilterPolicy set by the subscription
+func (fp *FilterPolicy) IsSatisfiedBy(msgAttrs map[string]MessageAttribute) bool {
+	values := make(map[string]interface{}, len(msgAttrs))
+	for name, attr := range msgAttrs {
+		values[name] = attr.filterValue()
+	}
+	return matchesPolicy(*fp, values)
+}
+
+// filterValue converts the attribute into the same shape as a decoded JSON value, so it can be checked with the
+// same rules as a message body.
+func (a MessageAttribute) filterValue() interface{} {
+	switch {
+	case a.DataType == "String.Array":
+		var values []interface{}
+		if err := json.Unmarshal([]byte(a.StringValue), &values); err != nil {
+			return a.StringValue
+		}
+		return values
+	case strings.HasPrefix(a.DataType, "Number"):
+		number, err := strconv.ParseFloat(a.StringValue, 64)
+		if err != nil {
+			return unfilterable{}
+		}
+		return number
+	case strings.HasPrefix(a.DataType, "String"):
+		return a.StringValue
+	}
+	return unfilterable{}
+}
+
+// Validate reports whether the policy only uses the rules and operators that SNS accepts.
+func (fp *FilterPolicy) Validate() error {
+	return validatePolicy(*fp)
+}
+
+func validatePolicy(policy map[string]interface{}) error {
+	for key, rule := range policy {
+		switch r := rule.(type) {
+		case map[string]interface{}:
+			if err := validatePolicy(r); err != nil {
+				return err
+			}
+		case []interface{}:
+			if key == "$or" {
+				for _, subPolicy := range r {
+					p, ok := subPolicy.(map[string]interface{})
+					if !ok {
+						return fmt.Errorf("$or must be a list of policies")
+					}
+					if err := validatePolicy(p); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			for _, condition := range r {
+				if err := validateCondition(condition); err != nil {
+					return fmt.Errorf("%s: %s", key, err)
+				}
+			}
+		default:
+			return fmt.Errorf("%s: rules must be a list or an object", key)
+		}
+	}
+	return nil
+}
+
+func validateCondition(condition interface{}) error {
+	c, ok := condition.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if len(c) != 1 {
+		return fmt.Errorf("only one operator is allowed per condition")
+	}
+	for operator, operand := range c {
+		switch operator {
+		case "prefix", "suffix", "equals-ignore-case":
+			if _, ok := operand.(string); !ok {
+				return fmt.Errorf("%s must be a string", operator)
+			}
+		case "exists":
+			if _, ok := operand.(bool); !ok {
+				return fmt.Errorf("exists must be a boolean")
+			}
+		case "cidr":
+			s, ok := operand.(string)
+			if !ok {
+				return fmt.Errorf("cidr must be a string")
+			}
+			if _, _, err := net.ParseCIDR(s); err != nil {
+				return fmt.Errorf("invalid cidr: %s", s)
+			}
+		case "numeric":
+			if _, err := parseNumericRange(operand); err != nil {
+				return err
+			}
+		case "anything-but":
+			switch a := operand.(type) {
+			case string, float64:
+			case []interface{}:
+				for _, value := range a {
+					switch value.(type) {
+					case string, float64:
+					default:
+						return fmt.Errorf("anything-but lists may only hold strings or numbers")
+					}
+				}
+			case map[string]interface{}:
+				if _, ok := a["prefix"]; !ok {
+					if _, ok := a["suffix"]; !ok {
+						return fmt.Errorf("anything-but only supports prefix and suffix operators")
+					}
+				}
+				if err := validateCondition(a); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("invalid anything-but value")
+			}
+		default:
+			return fmt.Errorf("unrecognized operator: %s", operator)
+		}
+	}
+	return nil
+}
+
+type numericCondition struct {
+	operator string
+	operand  float64
+}
+
+func parseNumericRange(operand interface{}) ([]numericCondition, error) {
+	values, ok := operand.([]interface{})
+	if !ok || len(values) == 0 || len(values)%2 != 0 {
+		return nil, fmt.Errorf("numeric must be a list of operator and value pairs")
+	}
+	result := make([]numericCondition, 0, len(values)/2)
+	for i := 0; i < len(values); i += 2 {
+		operator, ok := values[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("numeric operators must be strings")
+		}
+		switch operator {
+		case "=", "<", "<=", ">", ">=":
+		default:
+			return nil, fmt.Errorf("unrecognized numeric operator: %s", operator)
+		}
+		number, ok := values[i+1].(float64)
+		if !ok {
+			return nil, fmt.Errorf("numeric values must be numbers")
+		}
+		result = append(result, numericCondition{operator: operator, operand: number})
+	}
+	return result, nil
+}
+
+// matchesPolicy checks the values (decoded message attributes or message body) against every key of the policy.
+func matchesPolicy(policy map[string]interface{}, values map[string]interface{}) bool {
+	for key, rule := range policy {
+		if key == "$or" {
+			if subPolicies, ok := rule.([]interface{}); ok {
+				if !matchesAnyPolicy(subPolicies, values) {
+					return false
+				}
+				continue
+			}
+		}
+
+		value, present := values[key]
+		switch r := rule.(type) {
+		case map[string]interface{}:
+			nested, ok := value.(map[string]interface{})
+			if !ok || !matchesPolicy(r, nested) {
+				return false
+			}
+		case []interface{}:
+			if !matchesAnyCondition(r, value, present) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func matchesAnyPolicy(policies []interface{}, values map[string]interface{}) bool {
+	for _, subPolicy := range policies {
+		p, ok := subPolicy.(map[string]interface{})
+		if ok && matchesPolicy(p, values) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyCondition(conditions []interface{}, value interface{}, present bool) bool {
+	for _, condition := range conditions {
+		if matchesCondition(condition, value, present) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesCondition(condition interface{}, value interface{}, present bool) bool {
+	if c, ok := condition.(map[string]interface{}); ok {
+		if exists, ok := c["exists"].(bool); ok {
+			return exists == present
+		}
+	}
+	if !present {
+		return false
+	}
+	// Arrays match if any one of their elements does
+	if values, ok := value.([]interface{}); ok {
+		for _, v := range values {
+			if matchesValue(condition, v) {
+				return true
+			}
+		}
+		return false
+	}
+	return matchesValue(condition, value)
+}
+
+func matchesValue(condition interface{}, value interface{}) bool {
+	switch c := condition.(type) {
+	case string:
+		s, ok := value.(string)
+		return ok && s == c
+	case float64:
+		n, ok := value.(float64)
+		return ok && n == c
+	case bool:
+		b, ok := value.(bool)
+		return ok && b == c
+	case nil:
+		return value == nil
+	case map[string]interface{}:
+		for operator, operand := range c {
+			if !matchesOperator(operator, operand, value) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func matchesOperator(operator string, operand interface{}, value interface{}) bool {
+	switch operator {
+	case "prefix":
+		s, ok := value.(string)
+		o, _ := operand.(string)
+		return ok && strings.HasPrefix(s, o)
+	case "suffix":
+		s, ok := value.(string)
+		o, _ := operand.(string)
+		return ok && strings.HasSuffix(s, o)
+	case "equals-ignore-case":
+		s, ok := value.(string)
+		o, _ := operand.(string)
+		return ok && strings.EqualFold(s, o)
+	case "cidr":
+		s, ok := value.(string)
+		o, _ := operand.(string)
+		if !ok {
+			return false
+		}
+		_, network, err := net.ParseCIDR(o)
+		ip := net.ParseIP(s)
+		return err == nil && ip != nil && network.Contains(ip)
+	case "numeric":
+		n, ok := value.(float64)
+		if !ok {
+			return false
+		}
+		conditions, err := parseNumericRange(operand)
+		if err != nil {
+			return false
+		}
+		for _, c := range conditions {
+			if !c.matches(n) {
+				return false
+			}
+		}
+		return true
+	case "anything-but":
+		if _, ok := value.(unfilterable); ok {
+			return false
+		}
+		switch o := operand.(type) {
+		case []interface{}:
+			for _, excluded := range o {
+				if matchesValue(excluded, value) {
+					return false
+				}
+			}
+			return true
+		case map[string]interface{}:
+			if _, ok := value.(string); !ok {
+				return false
+			}
+			return !matchesValue(o, value)
+		default:
+			return !matchesValue(o, value)
+		}
+	}
+	return false
+}
+
+func (c numericCondition) matches(n float64) bool {
+	switch c.operator {
+	case "=":
+		return n == c.operand
+	case "<":
+		return n < c.operand
+	case "<=":
+		return n <= c.operand
+	case ">":
+		return n > c.operand
+	case ">=":
+		return n >= c.operand
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterPolicy_IsSatisfiedBy(t *testing.T) {
+	var tests = []struct {
+		filterPolicy      *FilterPolicy
+		messageAttributes map[string]MessageAttribute
+		expected          bool
+	}{
+		{
+			&FilterPolicy{"foo": []interface{}{"bar"}},
+			map[string]MessageAttribute{"foo": {DataType: "String", StringValue: "bar"}},
+			true,
+		},
+		{
+			&FilterPolicy{"foo": []interface{}{"bar", "xyz"}},
+			map[string]MessageAttribute{"foo": {DataType: "String", StringValue: "xyz"}},
+			true,
+		},
+		{
+			&FilterPolicy{"foo": []interface{}{"bar", "xyz"}, "abc": []interface{}{"def"}},
+			map[string]MessageAttribute{"foo": {DataType: "String", StringValue: "xyz"},
+				"abc": {DataType: "String", StringValue: "def"}},
+			true,
+		},
+		{
+			&FilterPolicy{"foo": []interface{}{"bar"}},
+			map[string]MessageAttribute{"foo": {DataType: "String", StringValue: "baz"}},
+			false,
+		},
+		{
+			&FilterPolicy{"foo": []interface{}{"bar"}},
+			map[string]MessageAttribute{},
+			false,
+		},
+		{
+			&FilterPolicy{"foo": []interface{}{"bar"}, "abc": []interface{}{"def"}},
+			map[string]MessageAttribute{"foo": {DataType: "String", StringValue: "bar"}},
+			false,
+		},
+		{
+			&FilterPolicy{"foo": []interface{}{"bar"}},
+			map[string]MessageAttribute{"foo": {DataType: "Binary", BinaryValue: "bar"}},
+			false,
+		},
+	}
+
+	for i, tt := range tests {
+		actual := tt.filterPolicy.IsSatisfiedBy(tt.messageAttributes)
+		if tt.filterPolicy.IsSatisfiedBy(tt.messageAttributes) != tt.expected {
+			t.Errorf("#%d FilterPolicy: expected %t, actual %t", i, tt.expected, actual)
+		}
+	}
+
+}
+
+func TestFilterPolicy_IsSatisfiedBy_operators(t *testing.T) {
+	var tests = []struct {
+		name              string
+		filterPolicy      string
+		messageAttributes map[string]MessageAttribute
+		expected          bool
+	}{
+		{"numeric equals", `{"price": [{"numeric": ["=", 100]}]}`, map[string]MessageAttribute{"price": {DataType: "Number", StringValue: "100"}}, true},
+		{"numeric exact number", `{"price": [100]}`, map[string]MessageAttribute{"price": {DataType: "Number", StringValue: "100.0"}}, true},
+		{"numeric range", `{"price": [{"numeric": [">", 0, "<=", 150]}]}`, map[string]MessageAttribute{"price": {DataType: "Number", StringValue: "150"}}, true},
+		{"numeric out of range", `{"price": [{"numeric": [">", 0, "<", 150]}]}`, map[string]MessageAttribute{"price": {DataType: "Number", StringValue: "150"}}, false},
+		{"numeric on string attribute", `{"price": [{"numeric": [">", 0]}]}`, map[string]MessageAttribute{"price": {DataType: "String", StringValue: "10"}}, false},
+		{"number custom type", `{"price": [{"numeric": [">=", 1.5]}]}`, map[string]MessageAttribute{"price": {DataType: "Number.float", StringValue: "1.5"}}, true},
+		{"prefix", `{"color": [{"prefix": "bl"}]}`, map[string]MessageAttribute{"color": {DataType: "String", StringValue: "blue"}}, true},
+		{"prefix mismatch", `{"color": [{"prefix": "bl"}]}`, map[string]MessageAttribute{"color": {DataType: "String", StringValue: "red"}}, false},
+		{"suffix", `{"file": [{"suffix": ".png"}]}`, map[string]MessageAttribute{"file": {DataType: "String", StringValue: "image.png"}}, true},
+		{"equals-ignore-case", `{"color": [{"equals-ignore-case": "BLUE"}]}`, map[string]MessageAttribute{"color": {DataType: "String", StringValue: "Blue"}}, true},
+		{"anything-but string", `{"color": [{"anything-but": "red"}]}`, map[string]MessageAttribute{"color": {DataType: "String", StringValue: "blue"}}, true},
+		{"anything-but string excluded", `{"color": [{"anything-but": "red"}]}`, map[string]MessageAttribute{"color": {DataType: "String", StringValue: "red"}}, false},
+		{"anything-but list", `{"color": [{"anything-but": ["red", "green"]}]}`, map[string]MessageAttribute{"color": {DataType: "String", StringValue: "green"}}, false},
+		{"anything-but number", `{"price": [{"anything-but": [100, 200]}]}`, map[string]MessageAttribute{"price": {DataType: "Number", StringValue: "300"}}, true},
+		{"anything-but prefix", `{"color": [{"anything-but": {"prefix": "re"}}]}`, map[string]MessageAttribute{"color": {DataType: "String", StringValue: "red"}}, false},
+		{"anything-but prefix not matched", `{"color": [{"anything-but": {"prefix": "re"}}]}`, map[string]MessageAttribute{"color": {DataType: "String", StringValue: "blue"}}, true},
+		{"anything-but missing attribute", `{"color": [{"anything-but": "red"}]}`, map[string]MessageAttribute{}, false},
+		{"exists true", `{"color": [{"exists": true}]}`, map[string]MessageAttribute{"color": {DataType: "Binary", BinaryValue: "Ymx1ZQ=="}}, true},
+		{"exists true missing", `{"color": [{"exists": true}]}`, map[string]MessageAttribute{}, false},
+		{"exists false", `{"color": [{"exists": false}]}`, map[string]MessageAttribute{}, true},
+		{"exists false present", `{"color": [{"exists": false}]}`, map[string]MessageAttribute{"color": {DataType: "String", StringValue: "red"}}, false},
+		{"cidr", `{"source_ip": [{"cidr": "10.0.0.0/24"}]}`, map[string]MessageAttribute{"source_ip": {DataType: "String", StringValue: "10.0.0.255"}}, true},
+		{"cidr outside", `{"source_ip": [{"cidr": "10.0.0.0/24"}]}`, map[string]MessageAttribute{"source_ip": {DataType: "String", StringValue: "10.0.1.1"}}, false},
+		{"string array", `{"colors": ["red"]}`, map[string]MessageAttribute{"colors": {DataType: "String.Array", StringValue: `["blue", "red"]`}}, true},
+		{"string array mismatch", `{"colors": ["green"]}`, map[string]MessageAttribute{"colors": {DataType: "String.Array", StringValue: `["blue", "red"]`}}, false},
+		{"string array numbers", `{"sizes": [{"numeric": [">", 10]}]}`, map[string]MessageAttribute{"sizes": {DataType: "String.Array", StringValue: `[1, 20]`}}, true},
+		{"mixed conditions", `{"color": ["red", {"prefix": "bl"}]}`, map[string]MessageAttribute{"color": {DataType: "String", StringValue: "black"}}, true},
+		{"$or first branch", `{"source": ["aws.cloudwatch"], "$or": [{"metricName": ["CPUUtilization"]}, {"namespace": ["AWS/EC2"]}]}`, map[string]MessageAttribute{"source": {DataType: "String", StringValue: "aws.cloudwatch"}, "metricName": {DataType: "String", StringValue: "CPUUtilization"}}, true},
+		{"$or second branch", `{"source": ["aws.cloudwatch"], "$or": [{"metricName": ["CPUUtilization"]}, {"namespace": ["AWS/EC2"]}]}`, map[string]MessageAttribute{"source": {DataType: "String", StringValue: "aws.cloudwatch"}, "namespace": {DataType: "String", StringValue: "AWS/EC2"}}, true},
+		{"$or no branch", `{"source": ["aws.cloudwatch"], "$or": [{"metricName": ["CPUUtilization"]}, {"namespace": ["AWS/EC2"]}]}`, map[string]MessageAttribute{"source": {DataType: "String", StringValue: "aws.cloudwatch"}}, false},
+	}
+
+	for _, tt := range tests {
+		filterPolicy := FilterPolicy{}
+		err := json.Unmarshal([]byte(tt.filterPolicy), &filterPolicy)
+		assert.Nil(t, err, tt.name)
+		assert.Nil(t, filterPolicy.Validate(), tt.name)
+
+		actual := filterPolicy.IsSatisfiedBy(tt.messageAttributes)
+		assert.Equal(t, tt.expected, actual, tt.name)
+	}
+}
+
+func TestFilterPolicy_Validate_rejects_invalid_policies(t *testing.T) {
+	var tests = []string{
+		`{"color": "red"}`,
+		`{"color": [{"unknown": "red"}]}`,
+		`{"color": [{"prefix": 1}]}`,
+		`{"color": [{"exists": "yes"}]}`,
+		`{"price": [{"numeric": [">"]}]}`,
+		`{"price": [{"numeric": ["!=", 1]}]}`,
+		`{"source_ip": [{"cidr": "not-a-cidr"}]}`,
+		`{"color": [{"anything-but": {"numeric": [">", 1]}}]}`,
+		`{"color": [{"prefix": "a", "suffix": "b"}]}`,
+		`{"$or": ["red"]}`,
+	}
+
+	for _, policy := range tests {
+		filterPolicy := FilterPolicy{}
+		err := json.Unmarshal([]byte(policy), &filterPolicy)
+		assert.Nil(t, err, policy)
+		assert.NotNil(t, filterPolicy.Validate(), policy)
+	}
+}
+
+func TestFilterPolicy_IsSatisfiedByBody(t *testing.T) {
+	var tests = []struct {
+		name         string
+		filterPolicy string
+		body         string
+		expected     bool
+	}{
+		{"empty policy", `{}`, "not json", true},
+		{"top level match", `{"event": ["created"]}`, `{"event": "created"}`, true},
+		{"nested match", `{"store": {"city": ["Seattle"], "size": [{"numeric": [">", 10]}]}}`, `{"store": {"city": "Seattle", "size": 20}}`, true},
+		{"nested mismatch", `{"store": {"city": ["Seattle"]}}`, `{"store": {"city": "Portland"}}`, false},
+		{"nested not an object", `{"store": {"city": ["Seattle"]}}`, `{"store": "Seattle"}`, false},
+		{"array in body", `{"tags": ["blue"]}`, `{"tags": ["red", "blue"]}`, true},
+		{"boolean in body", `{"active": [true]}`, `{"active": true}`, true},
+		{"null in body", `{"owner": [null]}`, `{"owner": null}`, true},
+		{"exists false", `{"owner": [{"exists": false}]}`, `{"store": "Seattle"}`, true},
+		{"$or in nested body", `{"store": {"$or": [{"city": ["Seattle"]}, {"zip": [{"prefix": "98"}]}]}}`, `{"store": {"zip": "98101"}}`, true},
+		{"body not json", `{"event": ["created"]}`, "created", false},
+	}
+
+	for _, tt := range tests {
+		filterPolicy := FilterPolicy{}
+		err := json.Unmarshal([]byte(tt.filterPolicy), &filterPolicy)
+		assert.Nil(t, err, tt.name)
+
+		actual := filterPolicy.IsSatisfiedByBody(tt.body)
+		assert.Equal(t, tt.expected, actual, tt.name)
+	}
+}
@@ -22,15 +22,6 @@ func ResetResources() {
 	SyncTopics.Unlock()
 }
 
-func stringInSlice(a string, list []string) bool {
-	for _, b := range list {
-		if b == a {
-			return true
-		}
-	}
-	return false
-}
-
 func generateRandomLatency() (time.Duration, error) {
 	min := CurrentEnvironment.RandomLatency.Min
 	max := CurrentEnvironment.RandomLatency.Max
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -41,38 +44,22 @@ type Subscription struct {
 	EndPoint        string
 	Raw             bool
 	FilterPolicy    *FilterPolicy
+	// FilterPolicyScope is either MessageAttributes (the default) or MessageBody
+	FilterPolicyScope string
+	DeliveryPolicy    *DeliveryPolicy
+	RedrivePolicy     *SubscriptionRedrivePolicy
+	// PendingConfirmation is set on HTTP/S subscriptions until their endpoint confirms them with ConfirmSubscription
+	PendingConfirmation          bool
+	ConfirmationWasAuthenticated bool
 }
 
 type Topic struct {
-	Name          string
-	Arn           string
-	Subscriptions []*Subscription
-}
-
-// only simple "ExactMatch" string policy is supported at the moment
-type FilterPolicy map[string][]string
-
-// Function checks if MessageAttributes passed to Topic satisfy FilterPolicy set by subscription
-func (fp *FilterPolicy) IsSatisfiedBy(msgAttrs map[string]MessageAttribute) bool {
-	for policyAttrName, policyAttrValues := range *fp {
-		attrValue, ok := msgAttrs[policyAttrName]
-		if !ok {
-			return false // the attribute has to be present in the message
-		}
-
-		// String, String.Array, Number data-types are allowed by SNS filter policies
-		// however go-AWS currently only supports String filter policies. That feature can be added here
-		// ref: https://docs.aws.amazon.com/sns/latest/dg/message-filtering.html
-		if attrValue.DataType != "String" {
-			return false
-		}
-
-		if !stringInSlice(attrValue.StringValue, policyAttrValues) {
-			return false // the attribute value has to be among filtered ones
-		}
-	}
-
-	return true
+	Name                 string
+	Arn                  string
+	Subscriptions        []*Subscription
+	Tags                 map[string]string
+	Attributes           map[string]string
+	SubscriptionsDeleted int
 }
 
 type SqsMessage struct {
@@ -84,12 +71,17 @@ type SqsMessage struct {
 	ReceiptTime            time.Time
 	VisibilityTimeout      time.Time
 	NumberOfReceives       int
-	Retry                  int
 	MessageAttributes      map[string]MessageAttribute
 	GroupID                string
 	DeduplicationID        string
+	SequenceNumber         string
+	SenderId               string
+	AWSTraceHeader         string
 	SentTime               time.Time
+	FirstReceiveTime       time.Time
 	DelaySecs              int
+	// DeadLetterSourceQueue is the queue a dead-lettered message came from, where a message move task returns it.
+	DeadLetterSourceQueue string
 }
 
 func (m *SqsMessage) IsReadyForReceipt() bool {
@@ -102,6 +94,15 @@ func (m *SqsMessage) IsReadyForReceipt() bool {
 	return showAt.Before(time.Now())
 }
 
+// IsExpired reports whether the message has outlived the given retention period (in seconds).  A zero
+// retention period, or a message with no recorded sent time, never expires.
+func (m *SqsMessage) IsExpired(retentionPeriod int) bool {
+	if retentionPeriod <= 0 || m.SentTime.IsZero() {
+		return false
+	}
+	return time.Now().After(m.SentTime.Add(time.Duration(retentionPeriod) * time.Second))
+}
+
 type Queue struct {
 	Name                          string
 	URL                           string
@@ -110,38 +111,79 @@ type Queue struct {
 	ReceiveMessageWaitTimeSeconds int
 	DelaySeconds                  int
 	MaximumMessageSize            int
-	MessageRetentionPeriod        int // seconds  // TODO - not used in the code yet
+	MessageRetentionPeriod        int // seconds
 	Messages                      []SqsMessage
 	DeadLetterQueue               *Queue
 	MaxReceiveCount               int
+	RedriveAllowPolicy            *RedriveAllowPolicy
+	Policy                        string
 	IsFIFO                        bool
+	ContentBasedDeduplication     bool
+	DeduplicationScope            string
+	FifoThroughputLimit           string
 	FIFOMessages                  map[string]int
 	FIFOSequenceNumbers           map[string]int
 	EnableDuplicates              bool
 	Duplicates                    map[string]time.Time
+	ReceiveAttempts               map[string]ReceiveAttempt
+	Tags                          map[string]string
+}
+
+// ReceiveAttempt is the batch a FIFO ReceiveMessage returned for a ReceiveRequestAttemptId, so a retry of the
+// same call gets the same messages back.
+type ReceiveAttempt struct {
+	ReceiptHandles []string
+	Created        time.Time
 }
 
 func (q *Queue) NextSequenceNumber(groupId string) string {
-	if _, ok := q.FIFOSequenceNumbers[groupId]; !ok {
-		q.FIFOSequenceNumbers = map[string]int{
-			groupId: 0,
-		}
+	if q.FIFOSequenceNumbers == nil {
+		q.FIFOSequenceNumbers = map[string]int{}
 	}
 
 	q.FIFOSequenceNumbers[groupId]++
 	return strconv.Itoa(q.FIFOSequenceNumbers[groupId])
 }
 
+// RetriedReceiveAttempt returns the receipt handles of the batch received with the ReceiveRequestAttemptId, if it
+// was within the deduplication period.
+func (q *Queue) RetriedReceiveAttempt(attemptId string) ([]string, bool) {
+	attempt, ok := q.ReceiveAttempts[attemptId]
+	if !ok || attemptId == "" || time.Now().After(attempt.Created.Add(DeduplicationPeriod)) {
+		return nil, false
+	}
+	return attempt.ReceiptHandles, true
+}
+
+func (q *Queue) SaveReceiveAttempt(attemptId string, receiptHandles []string) {
+	if attemptId == "" {
+		return
+	}
+	if q.ReceiveAttempts == nil {
+		q.ReceiveAttempts = map[string]ReceiveAttempt{}
+	}
+	q.ReceiveAttempts[attemptId] = ReceiveAttempt{ReceiptHandles: receiptHandles, Created: time.Now()}
+}
+
+// MoveToDeadLetterQueue moves the message at index i to the queue's dead-letter queue.
+func (q *Queue) MoveToDeadLetterQueue(i int) {
+	msg := q.Messages[i]
+	msg.DeadLetterSourceQueue = q.Name
+	q.DeadLetterQueue.Messages = append(q.DeadLetterQueue.Messages, msg)
+	q.Messages = append(q.Messages[:i], q.Messages[i+1:]...)
+}
+
 func (q *Queue) IsLocked(groupId string) bool {
 	_, ok := q.FIFOMessages[groupId]
 	return ok
 }
 
 func (q *Queue) LockGroup(groupId string) {
+	if q.FIFOMessages == nil {
+		q.FIFOMessages = map[string]int{}
+	}
 	if _, ok := q.FIFOMessages[groupId]; !ok {
-		q.FIFOMessages = map[string]int{
-			groupId: 0,
-		}
+		q.FIFOMessages[groupId] = 0
 	}
 }
 
@@ -151,22 +193,77 @@ func (q *Queue) UnlockGroup(groupId string) {
 	}
 }
 
-func (q *Queue) IsDuplicate(deduplicationId string) bool {
-	if !q.EnableDuplicates || !q.IsFIFO || deduplicationId == "" {
+// DeduplicationId returns the ID a message sent to a FIFO queue is deduplicated by - the one it was sent with or,
+// for queues with ContentBasedDeduplication, the SHA-256 hash of its body.  It's empty if there's neither.
+func (q *Queue) DeduplicationId(deduplicationId string, messageBody string) string {
+	if deduplicationId != "" || !q.ContentBasedDeduplication {
+		return deduplicationId
+	}
+	hash := sha256.Sum256([]byte(messageBody))
+	return hex.EncodeToString(hash[:])
+}
+
+func (q *Queue) IsDuplicate(groupId string, deduplicationId string) bool {
+	if !q.deduplicates() || deduplicationId == "" {
 		return false
 	}
 
-	_, ok := q.Duplicates[deduplicationId]
+	_, ok := q.Duplicates[q.duplicateKey(groupId, deduplicationId)]
 
 	return ok
 }
 
-func (q *Queue) InitDuplicatation(deduplicationId string) {
-	if !q.EnableDuplicates || !q.IsFIFO || deduplicationId == "" {
+func (q *Queue) InitDuplicatation(groupId string, deduplicationId string) {
+	if !q.deduplicates() || deduplicationId == "" {
 		return
 	}
 
-	if _, ok := q.Duplicates[deduplicationId]; !ok {
-		q.Duplicates[deduplicationId] = time.Now()
+	if q.Duplicates == nil {
+		q.Duplicates = make(map[string]time.Time)
+	}
+	key := q.duplicateKey(groupId, deduplicationId)
+	if _, ok := q.Duplicates[key]; !ok {
+		q.Duplicates[key] = time.Now()
+	}
+}
+
+func (q *Queue) RemoveDuplicate(groupId string, deduplicationId string) {
+	delete(q.Duplicates, q.duplicateKey(groupId, deduplicationId))
+}
+
+// duplicateKey is what a deduplication ID is tracked by in Duplicates.  With a messageGroup DeduplicationScope the
+// same ID can be sent to different groups, so the group is part of the key - separated by a character that isn't
+// allowed in either ID.
+func (q *Queue) duplicateKey(groupId string, deduplicationId string) string {
+	if q.DeduplicationScope == DeduplicationScopeMessageGroup {
+		return groupId + "\n" + deduplicationId
+	}
+	return deduplicationId
+}
+
+// deduplicates reports whether messages with the same deduplication ID are dropped.  Content-based deduplication
+// turns it on for the queue even without EnableDuplicates.
+func (q *Queue) deduplicates() bool {
+	return q.IsFIFO && (q.EnableDuplicates || q.ContentBasedDeduplication)
+}
+
+// ValidateFifoThroughput checks a FIFO queue's DeduplicationScope and FifoThroughputLimit, empty meaning the default.
+// High throughput mode needs both - deduplicating per queue doesn't allow a perMessageGroupId limit.
+// ref: https://docs.aws.amazon.com/AWSSimpleQueueService/latest/SQSDeveloperGuide/high-throughput-fifo.html
+func ValidateFifoThroughput(deduplicationScope string, fifoThroughputLimit string) error {
+	switch deduplicationScope {
+	case "", DeduplicationScopeQueue, DeduplicationScopeMessageGroup:
+	default:
+		return fmt.Errorf("InvalidDeduplicationScope")
+	}
+	switch fifoThroughputLimit {
+	case "", FifoThroughputLimitPerQueue:
+	case FifoThroughputLimitPerMessageGroupId:
+		if deduplicationScope != DeduplicationScopeMessageGroup {
+			return fmt.Errorf("InvalidFifoThroughputLimit")
+		}
+	default:
+		return fmt.Errorf("InvalidFifoThroughputLimit")
 	}
+	return nil
 }
@@ -7,59 +7,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestFilterPolicy_IsSatisfiedBy(t *testing.T) {
-	var tests = []struct {
-		filterPolicy      *FilterPolicy
-		messageAttributes map[string]MessageAttribute
-		expected          bool
-	}{
-		{
-			&FilterPolicy{"foo": {"bar"}},
-			map[string]MessageAttribute{"foo": {DataType: "String", StringValue: "bar"}},
-			true,
-		},
-		{
-			&FilterPolicy{"foo": {"bar", "xyz"}},
-			map[string]MessageAttribute{"foo": {DataType: "String", StringValue: "xyz"}},
-			true,
-		},
-		{
-			&FilterPolicy{"foo": {"bar", "xyz"}, "abc": {"def"}},
-			map[string]MessageAttribute{"foo": {DataType: "String", StringValue: "xyz"},
-				"abc": {DataType: "String", StringValue: "def"}},
-			true,
-		},
-		{
-			&FilterPolicy{"foo": {"bar"}},
-			map[string]MessageAttribute{"foo": {DataType: "String", StringValue: "baz"}},
-			false,
-		},
-		{
-			&FilterPolicy{"foo": {"bar"}},
-			map[string]MessageAttribute{},
-			false,
-		},
-		{
-			&FilterPolicy{"foo": {"bar"}, "abc": {"def"}},
-			map[string]MessageAttribute{"foo": {DataType: "String", StringValue: "bar"}},
-			false,
-		},
-		{
-			&FilterPolicy{"foo": {"bar"}},
-			map[string]MessageAttribute{"foo": {DataType: "Binary", BinaryValue: "bar"}},
-			false,
-		},
-	}
-
-	for i, tt := range tests {
-		actual := tt.filterPolicy.IsSatisfiedBy(tt.messageAttributes)
-		if tt.filterPolicy.IsSatisfiedBy(tt.messageAttributes) != tt.expected {
-			t.Errorf("#%d FilterPolicy: expected %t, actual %t", i, tt.expected, actual)
-		}
-	}
-
-}
-
 func TestMessage_IsReadyForReceipt(t *testing.T) {
 	CurrentEnvironment.RandomLatency.Min = 100
 	CurrentEnvironment.RandomLatency.Max = 100
@@ -71,3 +18,134 @@ func TestMessage_IsReadyForReceipt(t *testing.T) {
 	time.Sleep(duration)
 	assert.True(t, msg.IsReadyForReceipt())
 }
+
+func TestMessage_IsExpired(t *testing.T) {
+	msg := SqsMessage{
+		SentTime: time.Now().Add(-2 * time.Second),
+	}
+	assert.True(t, msg.IsExpired(1))
+	assert.False(t, msg.IsExpired(60))
+	assert.False(t, msg.IsExpired(0))
+}
+
+func TestMessage_IsExpired_without_sent_time(t *testing.T) {
+	msg := SqsMessage{}
+	assert.False(t, msg.IsExpired(1))
+}
+
+func TestQueue_MoveToDeadLetterQueue(t *testing.T) {
+	dlq := &Queue{Name: "dead-letter-queue"}
+	q := &Queue{
+		Name:            "source-queue",
+		DeadLetterQueue: dlq,
+		Messages:        []SqsMessage{{Uuid: "1"}, {Uuid: "2"}, {Uuid: "3"}},
+	}
+
+	q.MoveToDeadLetterQueue(1)
+
+	assert.Equal(t, []SqsMessage{{Uuid: "1"}, {Uuid: "3"}}, q.Messages)
+	assert.Equal(t, []SqsMessage{{Uuid: "2", DeadLetterSourceQueue: "source-queue"}}, dlq.Messages)
+}
+
+func TestQueue_DeduplicationId(t *testing.T) {
+	q := &Queue{IsFIFO: true}
+
+	assert.Equal(t, "explicit", q.DeduplicationId("explicit", "body"))
+	assert.Equal(t, "", q.DeduplicationId("", "body"))
+
+	q.ContentBasedDeduplication = true
+	assert.Equal(t, "explicit", q.DeduplicationId("explicit", "body"))
+	assert.Equal(t, "230d8358dc8e8890b4c58deeb62912ee2f20357ae92a5cc861b98e68fe31acb5", q.DeduplicationId("", "body"))
+}
+
+func TestQueue_IsDuplicate_content_based_deduplication(t *testing.T) {
+	q := &Queue{IsFIFO: true}
+	q.InitDuplicatation("group", "1")
+	assert.False(t, q.IsDuplicate("group", "1"))
+
+	q.ContentBasedDeduplication = true
+	q.InitDuplicatation("group", "1")
+	assert.True(t, q.IsDuplicate("group", "1"))
+	assert.False(t, q.IsDuplicate("group", "2"))
+
+	standard := &Queue{ContentBasedDeduplication: true, EnableDuplicates: true}
+	standard.InitDuplicatation("group", "1")
+	assert.False(t, standard.IsDuplicate("group", "1"))
+}
+
+func TestQueue_IsDuplicate_message_group_deduplication_scope(t *testing.T) {
+	q := &Queue{IsFIFO: true, EnableDuplicates: true}
+	q.InitDuplicatation("group-1", "1")
+	assert.True(t, q.IsDuplicate("group-1", "1"))
+	assert.True(t, q.IsDuplicate("group-2", "1"))
+
+	q = &Queue{IsFIFO: true, EnableDuplicates: true, DeduplicationScope: DeduplicationScopeMessageGroup}
+	q.InitDuplicatation("group-1", "1")
+	assert.True(t, q.IsDuplicate("group-1", "1"))
+	assert.False(t, q.IsDuplicate("group-2", "1"))
+
+	q.RemoveDuplicate("group-1", "1")
+	assert.False(t, q.IsDuplicate("group-1", "1"))
+}
+
+func TestQueue_LockGroup_keeps_other_groups_locked(t *testing.T) {
+	q := &Queue{IsFIFO: true}
+	q.LockGroup("group-1")
+	q.LockGroup("group-2")
+
+	assert.True(t, q.IsLocked("group-1"))
+	assert.True(t, q.IsLocked("group-2"))
+
+	q.UnlockGroup("group-1")
+	assert.False(t, q.IsLocked("group-1"))
+	assert.True(t, q.IsLocked("group-2"))
+}
+
+func TestValidateFifoThroughput(t *testing.T) {
+	assert.Nil(t, ValidateFifoThroughput("", ""))
+	assert.Nil(t, ValidateFifoThroughput("queue", "perQueue"))
+	assert.Nil(t, ValidateFifoThroughput("messageGroup", "perQueue"))
+	assert.Nil(t, ValidateFifoThroughput("messageGroup", "perMessageGroupId"))
+
+	assert.EqualError(t, ValidateFifoThroughput("garbage", ""), "InvalidDeduplicationScope")
+	assert.EqualError(t, ValidateFifoThroughput("", "garbage"), "InvalidFifoThroughputLimit")
+	assert.EqualError(t, ValidateFifoThroughput("queue", "perMessageGroupId"), "InvalidFifoThroughputLimit")
+	assert.EqualError(t, ValidateFifoThroughput("", "perMessageGroupId"), "InvalidFifoThroughputLimit")
+}
+
+func TestQueue_RetriedReceiveAttempt(t *testing.T) {
+	q := &Queue{IsFIFO: true}
+	_, ok := q.RetriedReceiveAttempt("attempt-1")
+	assert.False(t, ok)
+
+	q.SaveReceiveAttempt("attempt-1", []string{"receipt-1", "receipt-2"})
+	receiptHandles, ok := q.RetriedReceiveAttempt("attempt-1")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"receipt-1", "receipt-2"}, receiptHandles)
+
+	_, ok = q.RetriedReceiveAttempt("attempt-2")
+	assert.False(t, ok)
+
+	// Without an attempt ID nothing is saved
+	q.SaveReceiveAttempt("", []string{"receipt-3"})
+	_, ok = q.RetriedReceiveAttempt("")
+	assert.False(t, ok)
+
+	// Retries after the deduplication period are new attempts
+	q.ReceiveAttempts["attempt-1"] = ReceiveAttempt{
+		ReceiptHandles: []string{"receipt-1"},
+		Created:        time.Now().Add(-DeduplicationPeriod - time.Second),
+	}
+	_, ok = q.RetriedReceiveAttempt("attempt-1")
+	assert.False(t, ok)
+}
+
+func TestQueue_NextSequenceNumber_counts_each_group(t *testing.T) {
+	q := &Queue{IsFIFO: true}
+
+	assert.Equal(t, "1", q.NextSequenceNumber("group-1"))
+	assert.Equal(t, "2", q.NextSequenceNumber("group-1"))
+	assert.Equal(t, "1", q.NextSequenceNumber("group-2"))
+	assert.Equal(t, "3", q.NextSequenceNumber("group-1"))
+	assert.Equal(t, "2", q.NextSequenceNumber("group-2"))
+}
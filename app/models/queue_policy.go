@@ -0,0 +1,122 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// PolicyDocument is a queue's access policy exactly as it was set, so it can be returned verbatim.  The JSON protocol
+// sends it as an escaped string, but a regular json document is accepted too.
+type PolicyDocument string
+
+func (p *PolicyDocument) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*p = PolicyDocument(str)
+		return nil
+	}
+
+	if !json.Valid(data) {
+		return fmt.Errorf("invalid policy document")
+	}
+	compacted := &bytes.Buffer{}
+	json.Compact(compacted, data)
+	*p = PolicyDocument(compacted.String())
+	return nil
+}
+
+// QueuePolicy is the access policy document that AddPermission and RemovePermission edit.  Statements are kept as
+// raw JSON so the ones they didn't write keep every field, even ones goaws doesn't know about.
+// ref: https://docs.aws.amazon.com/AWSSimpleQueueService/latest/SQSDeveloperGuide/sqs-basic-examples-of-sqs-policies.html
+type QueuePolicy struct {
+	Version   string            `json:"Version"`
+	Id        string            `json:"Id,omitempty"`
+	Statement []json.RawMessage `json:"Statement"`
+}
+
+// PermissionStatement is the statement AddPermission writes for a label.
+type PermissionStatement struct {
+	Sid       string                 `json:"Sid"`
+	Effect    string                 `json:"Effect"`
+	Principal map[string]interface{} `json:"Principal"`
+	Action    interface{}            `json:"Action"`
+	Resource  string                 `json:"Resource"`
+}
+
+// NewQueuePolicy parses the queue's current policy, or starts the default one AWS creates for the queue if it
+// doesn't have one yet.
+func NewQueuePolicy(queue *Queue) (*QueuePolicy, error) {
+	if queue.Policy == "" {
+		return &QueuePolicy{
+			Version: "2012-10-17",
+			Id:      fmt.Sprintf("%s/SQSDefaultPolicy", queue.Arn),
+		}, nil
+	}
+	return parsePolicy(queue.Policy)
+}
+
+func parsePolicy(document string) (*QueuePolicy, error) {
+	var decoded struct {
+		Version   string          `json:"Version"`
+		Id        string          `json:"Id,omitempty"`
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(document), &decoded); err != nil {
+		return nil, err
+	}
+	policy := &QueuePolicy{}
+	policy.Version = decoded.Version
+	policy.Id = decoded.Id
+	// A policy with a single statement doesn't have to wrap it in a list
+	statement := bytes.TrimSpace(decoded.Statement)
+	if len(statement) > 0 && statement[0] == '{' {
+		policy.Statement = []json.RawMessage{statement}
+	} else if len(statement) > 0 {
+		if err := json.Unmarshal(statement, &policy.Statement); err != nil {
+			return nil, err
+		}
+	}
+	return policy, nil
+}
+
+// HasStatement reports whether there's a statement with the given Sid.
+func (p *QueuePolicy) HasStatement(sid string) bool {
+	return p.statementIndex(sid) >= 0
+}
+
+func (p *QueuePolicy) AddStatement(statement PermissionStatement) {
+	raw, _ := json.Marshal(statement)
+	p.Statement = append(p.Statement, raw)
+}
+
+// RemoveStatement removes the statement with the given Sid, and reports whether there was one.
+func (p *QueuePolicy) RemoveStatement(sid string) bool {
+	i := p.statementIndex(sid)
+	if i < 0 {
+		return false
+	}
+	p.Statement = append(p.Statement[:i], p.Statement[i+1:]...)
+	return true
+}
+
+// Document renders the policy for storing on the queue.  A policy without statements is removed altogether.
+func (p *QueuePolicy) Document() string {
+	if len(p.Statement) == 0 {
+		return ""
+	}
+	document, _ := json.Marshal(p)
+	return string(document)
+}
+
+func (p *QueuePolicy) statementIndex(sid string) int {
+	for i, raw := range p.Statement {
+		var statement struct {
+			Sid string `json:"Sid"`
+		}
+		if json.Unmarshal(raw, &statement) == nil && statement.Sid == sid {
+			return i
+		}
+	}
+	return -1
+}
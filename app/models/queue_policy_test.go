@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyDocument_UnmarshalJSON_handles_escaped_string(t *testing.T) {
+	var p PolicyDocument
+	err := json.Unmarshal([]byte(`"{\"Version\": \"2012-10-17\"}"`), &p)
+
+	assert.Nil(t, err)
+	assert.Equal(t, PolicyDocument(`{"Version": "2012-10-17"}`), p)
+}
+
+func TestPolicyDocument_UnmarshalJSON_handles_nested_json(t *testing.T) {
+	var p PolicyDocument
+	err := json.Unmarshal([]byte(`{"Version": "2012-10-17"}`), &p)
+
+	assert.Nil(t, err)
+	assert.Equal(t, PolicyDocument(`{"Version":"2012-10-17"}`), p)
+}
+
+func TestPolicyDocument_UnmarshalJSON_invalid_type_returns_error(t *testing.T) {
+	var p PolicyDocument
+	err := p.UnmarshalJSON([]byte(`garbage`))
+
+	assert.Error(t, err)
+	assert.Equal(t, PolicyDocument(""), p)
+}
+
+func TestNewQueuePolicy_default_policy(t *testing.T) {
+	policy, err := NewQueuePolicy(&Queue{Arn: "arn:aws:sqs:region:accountID:queue"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "2012-10-17", policy.Version)
+	assert.Equal(t, "arn:aws:sqs:region:accountID:queue/SQSDefaultPolicy", policy.Id)
+	assert.Empty(t, policy.Statement)
+	assert.Equal(t, "", policy.Document())
+}
+
+func TestNewQueuePolicy_keeps_existing_statements(t *testing.T) {
+	queue := &Queue{Policy: `{"Version":"2012-10-17","Id":"my-policy","Statement":{"Sid":"existing","Effect":"Deny","NotAction":"SQS:*"}}`}
+
+	policy, err := NewQueuePolicy(queue)
+	assert.Nil(t, err)
+	assert.True(t, policy.HasStatement("existing"))
+	assert.False(t, policy.HasStatement("added"))
+
+	policy.AddStatement(PermissionStatement{
+		Sid:       "added",
+		Effect:    "Allow",
+		Principal: map[string]interface{}{"AWS": "arn:aws:iam::123456789012:root"},
+		Action:    "SQS:SendMessage",
+		Resource:  "arn:aws:sqs:region:accountID:queue",
+	})
+
+	expected := `{"Version":"2012-10-17","Id":"my-policy","Statement":[` +
+		`{"Sid":"existing","Effect":"Deny","NotAction":"SQS:*"},` +
+		`{"Sid":"added","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"SQS:SendMessage","Resource":"arn:aws:sqs:region:accountID:queue"}]}`
+	assert.Equal(t, expected, policy.Document())
+
+	assert.True(t, policy.RemoveStatement("existing"))
+	assert.False(t, policy.RemoveStatement("existing"))
+	assert.True(t, policy.RemoveStatement("added"))
+	assert.Equal(t, "", policy.Document())
+}
+
+func TestNewQueuePolicy_invalid_policy_returns_error(t *testing.T) {
+	_, err := NewQueuePolicy(&Queue{Policy: `{"Statement": "garbage"}`})
+
+	assert.Error(t, err)
+}
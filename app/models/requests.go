@@ -53,13 +53,23 @@ func (r *CreateQueueRequest) SetAttributesFromForm(values url.Values) {
 			}
 			r.Attributes.MessageRetentionPeriod = StringToInt(tmp)
 		case "Policy":
-			var tmp map[string]interface{}
-			err := json.Unmarshal([]byte(attrValue), &tmp)
+			if !json.Valid([]byte(attrValue)) {
+				log.Debugf("Failed to parse form attribute - %s: %s", attrName, attrValue)
+				continue
+			}
+			tmp := PolicyDocument(attrValue)
+			r.Attributes.Policy = &tmp
+		case "ContentBasedDeduplication":
+			tmp, err := strconv.ParseBool(attrValue)
 			if err != nil {
 				log.Debugf("Failed to parse form attribute - %s: %s", attrName, attrValue)
 				continue
 			}
-			r.Attributes.Policy = tmp
+			r.Attributes.ContentBasedDeduplication = (*StringToBool)(&tmp)
+		case "DeduplicationScope":
+			r.Attributes.DeduplicationScope = attrValue
+		case "FifoThroughputLimit":
+			r.Attributes.FifoThroughputLimit = attrValue
 		case "ReceiveMessageWaitTimeSeconds":
 			tmp, err := strconv.Atoi(attrValue)
 			if err != nil {
@@ -106,8 +116,8 @@ func (r *CreateQueueRequest) SetAttributesFromForm(values url.Values) {
 			tmp.DeadLetterTargetArn = decodedPolicy.DeadLetterTargetArn
 			r.Attributes.RedrivePolicy = tmp
 		case "RedriveAllowPolicy":
-			var tmp map[string]interface{}
-			err := json.Unmarshal([]byte(attrValue), &tmp)
+			tmp := &RedriveAllowPolicy{}
+			err := json.Unmarshal([]byte(attrValue), tmp)
 			if err != nil {
 				log.Debugf("Failed to parse form attribute - %s: %s", attrName, attrValue)
 				continue
@@ -115,9 +125,29 @@ func (r *CreateQueueRequest) SetAttributesFromForm(values url.Values) {
 			r.Attributes.RedriveAllowPolicy = tmp
 		}
 	}
+	if tags := parseTags(values, "Tag"); tags != nil {
+		r.Tags = tags
+	}
 	return
 }
 
+// parseTags reads the `<keyPrefix>.N.Key` and `<keyPrefix>.N.Value` pairs that the query protocol uses
+// to send tags.
+func parseTags(values url.Values, keyPrefix string) map[string]string {
+	result := map[string]string{}
+	for i := 1; true; i++ {
+		key := values.Get(fmt.Sprintf("%s.%d.Key", keyPrefix, i))
+		if key == "" {
+			break
+		}
+		result[key] = values.Get(fmt.Sprintf("%s.%d.Value", keyPrefix, i))
+	}
+	if len(result) > 0 {
+		return result
+	}
+	return nil
+}
+
 func NewListQueuesRequest() *ListQueueRequest {
 	return &ListQueueRequest{}
 }
@@ -137,6 +167,18 @@ func (r *ListQueueRequest) SetAttributesFromForm(values url.Values) {
 	r.QueueNamePrefix = values.Get("QueueNamePrefix")
 }
 
+func NewListDeadLetterSourceQueuesRequest() *ListDeadLetterSourceQueuesRequest {
+	return &ListDeadLetterSourceQueuesRequest{}
+}
+
+type ListDeadLetterSourceQueuesRequest struct {
+	QueueUrl   string `json:"QueueUrl" schema:"QueueUrl"`
+	MaxResults int    `json:"MaxResults" schema:"MaxResults"`
+	NextToken  string `json:"NextToken" schema:"NextToken"`
+}
+
+func (r *ListDeadLetterSourceQueuesRequest) SetAttributesFromForm(values url.Values) {}
+
 func NewGetQueueAttributesRequest() *GetQueueAttributesRequest {
 	return &GetQueueAttributesRequest{}
 }
@@ -176,9 +218,8 @@ type SendMessageRequest struct {
 	MessageGroupId         string                      `json:"MessageGroupId" schema:"MessageGroupId"`
 	// MessageSystemAttributes is custom attributes for AWS services.
 	// Please see: https://docs.aws.amazon.com/AWSSimpleQueueService/latest/APIReference/API_SendMessage.html#SQS-SendMessage-request-MessageSystemAttributes
-	// On AWS, the only supported attribute is "AWSTraceHeader" that is for AWS X-Ray.
-	// Goaws does not contains X-Ray emulation, so currently MessageSystemAttributes is unsupported.
-	// TODO: Replace with a struct with known attributes "AWSTraceHeader".
+	// On AWS, the only supported attribute is "AWSTraceHeader" that is for AWS X-Ray.  Goaws doesn't emulate X-Ray,
+	// it just stores the header and returns it on ReceiveMessage.
 	MessageSystemAttributes map[string]MessageAttribute `json:"MessageSystemAttributes" schema:"MessageSystemAttributes"`
 	QueueUrl                string                      `json:"QueueUrl" schema:"QueueUrl"`
 }
@@ -216,8 +257,22 @@ func parseMessageAttributes(values url.Values, keyPrefix string) map[string]Mess
 	return nil
 }
 
+// TraceHeader reads the AWSTraceHeader out of MessageSystemAttributes.  It's the only system attribute SQS accepts,
+// and only as a String.
+func TraceHeader(messageSystemAttributes map[string]MessageAttribute) (string, error) {
+	traceHeader := ""
+	for name, attribute := range messageSystemAttributes {
+		if name != "AWSTraceHeader" || attribute.DataType != "String" {
+			return "", fmt.Errorf("InvalidSystemAttribute")
+		}
+		traceHeader = attribute.StringValue
+	}
+	return traceHeader, nil
+}
+
 func (r *SendMessageRequest) SetAttributesFromForm(values url.Values) {
 	r.MessageAttributes = parseMessageAttributes(values, "MessageAttribute")
+	r.MessageSystemAttributes = parseMessageAttributes(values, "MessageSystemAttribute")
 }
 
 func NewSendMessageBatchRequest() *SendMessageBatchRequest {
@@ -232,6 +287,7 @@ type SendMessageBatchRequest struct {
 func (r *SendMessageBatchRequest) SetAttributesFromForm(values url.Values) {
 	for entryIndex := range r.Entries {
 		r.Entries[entryIndex].MessageAttributes = parseMessageAttributes(values, fmt.Sprintf("Entries.%d.MessageAttributes", entryIndex))
+		r.Entries[entryIndex].MessageSystemAttributes = parseMessageAttributes(values, fmt.Sprintf("Entries.%d.MessageSystemAttributes", entryIndex))
 	}
 }
 
@@ -242,7 +298,7 @@ type SendMessageBatchRequestEntry struct {
 	MessageAttributes       map[string]MessageAttribute `json:"MessageAttributes" schema:"MessageAttributes"`
 	MessageDeduplicationId  string                      `json:"MessageDeduplicationId" schema:"MessageDeduplicationId"`
 	MessageGroupId          string                      `json:"MessageGroupId" schema:"MessageGroupId"`
-	MessageSystemAttributes map[string]MessageAttribute `json:"MessageSystemAttributes" schema:"MessageSystemAttributes"` // NOTE: not implemented
+	MessageSystemAttributes map[string]MessageAttribute `json:"MessageSystemAttributes" schema:"MessageSystemAttributes"`
 }
 
 // Get Queue Url Request
@@ -278,7 +334,8 @@ func (r *SetQueueAttributesRequest) SetAttributesFromForm(values url.Values) {
 
 		valueKey := fmt.Sprintf("Attribute.%d.Value", i)
 		attrValue := values.Get(valueKey)
-		if attrValue == "" {
+		// An empty Policy removes the queue's policy, so it's kept rather than skipped
+		if attrValue == "" && attrName != "Policy" {
 			continue
 		}
 		switch attrName {
@@ -304,13 +361,23 @@ func (r *SetQueueAttributesRequest) SetAttributesFromForm(values url.Values) {
 			}
 			r.Attributes.MessageRetentionPeriod = StringToInt(tmp)
 		case "Policy":
-			var tmp map[string]interface{}
-			err := json.Unmarshal([]byte(attrValue), &tmp)
+			if attrValue != "" && !json.Valid([]byte(attrValue)) {
+				log.Debugf("Failed to parse form attribute - %s: %s", attrName, attrValue)
+				continue
+			}
+			tmp := PolicyDocument(attrValue)
+			r.Attributes.Policy = &tmp
+		case "ContentBasedDeduplication":
+			tmp, err := strconv.ParseBool(attrValue)
 			if err != nil {
 				log.Debugf("Failed to parse form attribute - %s: %s", attrName, attrValue)
 				continue
 			}
-			r.Attributes.Policy = tmp
+			r.Attributes.ContentBasedDeduplication = (*StringToBool)(&tmp)
+		case "DeduplicationScope":
+			r.Attributes.DeduplicationScope = attrValue
+		case "FifoThroughputLimit":
+			r.Attributes.FifoThroughputLimit = attrValue
 		case "ReceiveMessageWaitTimeSeconds":
 			tmp, err := strconv.Atoi(attrValue)
 			if err != nil {
@@ -357,8 +424,8 @@ func (r *SetQueueAttributesRequest) SetAttributesFromForm(values url.Values) {
 			tmp.DeadLetterTargetArn = decodedPolicy.DeadLetterTargetArn
 			r.Attributes.RedrivePolicy = tmp
 		case "RedriveAllowPolicy":
-			var tmp map[string]interface{}
-			err := json.Unmarshal([]byte(attrValue), &tmp)
+			tmp := &RedriveAllowPolicy{}
+			err := json.Unmarshal([]byte(attrValue), tmp)
 			if err != nil {
 				log.Debugf("Failed to parse form attribute - %s: %s", attrName, attrValue)
 				continue
@@ -373,15 +440,19 @@ func (r *SetQueueAttributesRequest) SetAttributesFromForm(values url.Values) {
 // QueueAttributes - SQS QueueAttributes Available in create/set attributes requests.
 // https://docs.aws.amazon.com/AWSSimpleQueueService/latest/APIReference/API_CreateQueue.html#SQS-CreateQueue-request-attributes
 type QueueAttributes struct {
-	DelaySeconds                  StringToInt            `json:"DelaySeconds"`
-	MaximumMessageSize            StringToInt            `json:"MaximumMessageSize"`
-	MessageRetentionPeriod        StringToInt            `json:"MessageRetentionPeriod"` // NOTE: not implemented
-	Policy                        map[string]interface{} `json:"Policy"`                 // NOTE: not implemented
-	ReceiveMessageWaitTimeSeconds StringToInt            `json:"ReceiveMessageWaitTimeSeconds"`
-	VisibilityTimeout             StringToInt            `json:"VisibilityTimeout"`
+	DelaySeconds                  StringToInt     `json:"DelaySeconds"`
+	MaximumMessageSize            StringToInt     `json:"MaximumMessageSize"`
+	MessageRetentionPeriod        StringToInt     `json:"MessageRetentionPeriod"`
+	Policy                        *PolicyDocument `json:"Policy"`
+	ReceiveMessageWaitTimeSeconds StringToInt     `json:"ReceiveMessageWaitTimeSeconds"`
+	VisibilityTimeout             StringToInt     `json:"VisibilityTimeout"`
+	// FIFO Queues Only
+	ContentBasedDeduplication *StringToBool `json:"ContentBasedDeduplication"`
+	DeduplicationScope        string        `json:"DeduplicationScope"`
+	FifoThroughputLimit       string        `json:"FifoThroughputLimit"`
 	// Dead Letter Queues Only
-	RedrivePolicy      RedrivePolicy          `json:"RedrivePolicy"`
-	RedriveAllowPolicy map[string]interface{} `json:"RedriveAllowPolicy"` // NOTE: not implemented
+	RedrivePolicy      RedrivePolicy       `json:"RedrivePolicy"`
+	RedriveAllowPolicy *RedriveAllowPolicy `json:"RedriveAllowPolicy"`
 }
 
 type RedrivePolicy struct {
@@ -407,6 +478,59 @@ func (r *RedrivePolicy) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// RedriveAllowPolicy controls which source queues may use a queue as their dead-letter queue.
+// ref: https://docs.aws.amazon.com/AWSSimpleQueueService/latest/APIReference/API_SetQueueAttributes.html
+type RedriveAllowPolicy struct {
+	RedrivePermission string   `json:"redrivePermission"`
+	SourceQueueArns   []string `json:"sourceQueueArns,omitempty"`
+}
+
+// UnmarshalJSON accepts the policy as either a JSON string (escaped characters and all) or a regular json document,
+// the same as RedrivePolicy.
+func (r *RedriveAllowPolicy) UnmarshalJSON(data []byte) error {
+	type basicRequest RedriveAllowPolicy
+
+	err := json.Unmarshal(data, (*basicRequest)(r))
+	if err == nil {
+		return nil
+	}
+
+	tmp, _ := strconv.Unquote(string(data))
+	err = json.Unmarshal([]byte(tmp), (*basicRequest)(r))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsValid reports whether the policy is one AWS would accept - `byQueue` needs between 1 and 10 source queues, and
+// the other permissions can't name any.
+func (r *RedriveAllowPolicy) IsValid() bool {
+	switch r.RedrivePermission {
+	case "allowAll", "denyAll":
+		return len(r.SourceQueueArns) == 0
+	case "byQueue":
+		return len(r.SourceQueueArns) > 0 && len(r.SourceQueueArns) <= 10
+	}
+	return false
+}
+
+// Allows reports whether the queue with the given ARN may send its dead letters to a queue with this policy.
+func (r *RedriveAllowPolicy) Allows(sourceArn string) bool {
+	switch r.RedrivePermission {
+	case "denyAll":
+		return false
+	case "byQueue":
+		for _, arn := range r.SourceQueueArns {
+			if arn == sourceArn {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
 func NewReceiveMessageRequest() *ReceiveMessageRequest {
 	return &ReceiveMessageRequest{}
 }
@@ -422,7 +546,24 @@ type ReceiveMessageRequest struct {
 	ReceiveRequestAttemptId     string   `json:"ReceiveRequestAttemptId" schema:"ReceiveRequestAttemptId"`
 }
 
-func (r *ReceiveMessageRequest) SetAttributesFromForm(values url.Values) {}
+func (r *ReceiveMessageRequest) SetAttributesFromForm(values url.Values) {
+	r.AttributeNames = append(r.AttributeNames, formList(values, "AttributeName")...)
+	r.MessageSystemAttributeNames = append(r.MessageSystemAttributeNames, formList(values, "MessageSystemAttributeName")...)
+	r.MessageAttributeNames = append(r.MessageAttributeNames, formList(values, "MessageAttributeName")...)
+}
+
+// formList reads a list sent as numbered form values, like `AttributeName.1`, `AttributeName.2`...
+func formList(values url.Values, name string) []string {
+	list := []string{}
+	for i := 1; true; i++ {
+		value := values.Get(fmt.Sprintf("%s.%d", name, i))
+		if value == "" {
+			break
+		}
+		list = append(list, value)
+	}
+	return list
+}
 
 func NewCreateQueueRequest() *CreateQueueRequest {
 	return &CreateQueueRequest{
@@ -514,55 +655,284 @@ func (r *DeleteMessageBatchRequest) SetAttributesFromForm(values url.Values) {
 	}
 }
 
+type ChangeMessageVisibilityBatchRequestEntry struct {
+	Id                string `json:"Id" schema:"Id"`
+	ReceiptHandle     string `json:"ReceiptHandle" schema:"ReceiptHandle"`
+	VisibilityTimeout int    `json:"VisibilityTimeout" schema:"VisibilityTimeout"`
+}
+
+type ChangeMessageVisibilityBatchRequest struct {
+	Entries  []ChangeMessageVisibilityBatchRequestEntry `json:"Entries"`
+	QueueUrl string                                     `json:"QueueUrl" schema:"QueueUrl"`
+}
+
+func NewChangeMessageVisibilityBatchRequest() *ChangeMessageVisibilityBatchRequest {
+	return &ChangeMessageVisibilityBatchRequest{}
+}
+
+func (r *ChangeMessageVisibilityBatchRequest) SetAttributesFromForm(values url.Values) {
+	entries := []ChangeMessageVisibilityBatchRequestEntry{}
+	for i := 1; true; i++ {
+		idKey := fmt.Sprintf("ChangeMessageVisibilityBatchRequestEntry.%d.Id", i)
+		receiptHandleKey := fmt.Sprintf("ChangeMessageVisibilityBatchRequestEntry.%d.ReceiptHandle", i)
+		visibilityTimeoutKey := fmt.Sprintf("ChangeMessageVisibilityBatchRequestEntry.%d.VisibilityTimeout", i)
+
+		id := values.Get(idKey)
+		receiptHandle := values.Get(receiptHandleKey)
+		if id == "" || receiptHandle == "" {
+			break
+		}
+		// A missing or garbled timeout is left as -1 so it fails validation instead of hiding the message for 0s.
+		visibilityTimeout := -1
+		if v, err := strconv.Atoi(values.Get(visibilityTimeoutKey)); err == nil {
+			visibilityTimeout = v
+		}
+		entries = append(entries, ChangeMessageVisibilityBatchRequestEntry{
+			Id:                id,
+			ReceiptHandle:     receiptHandle,
+			VisibilityTimeout: visibilityTimeout,
+		})
+	}
+	if len(entries) > 0 {
+		r.Entries = entries
+	}
+}
+
+func NewStartMessageMoveTaskRequest() *StartMessageMoveTaskRequest {
+	return &StartMessageMoveTaskRequest{}
+}
+
+type StartMessageMoveTaskRequest struct {
+	SourceArn                    string `json:"SourceArn" schema:"SourceArn"`
+	DestinationArn               string `json:"DestinationArn" schema:"DestinationArn"`
+	MaxNumberOfMessagesPerSecond int    `json:"MaxNumberOfMessagesPerSecond" schema:"MaxNumberOfMessagesPerSecond"`
+}
+
+func (r *StartMessageMoveTaskRequest) SetAttributesFromForm(values url.Values) {}
+
+func NewListMessageMoveTasksRequest() *ListMessageMoveTasksRequest {
+	return &ListMessageMoveTasksRequest{}
+}
+
+type ListMessageMoveTasksRequest struct {
+	SourceArn  string `json:"SourceArn" schema:"SourceArn"`
+	MaxResults int    `json:"MaxResults" schema:"MaxResults"`
+}
+
+func (r *ListMessageMoveTasksRequest) SetAttributesFromForm(values url.Values) {}
+
+func NewCancelMessageMoveTaskRequest() *CancelMessageMoveTaskRequest {
+	return &CancelMessageMoveTaskRequest{}
+}
+
+type CancelMessageMoveTaskRequest struct {
+	TaskHandle string `json:"TaskHandle" schema:"TaskHandle"`
+}
+
+func (r *CancelMessageMoveTaskRequest) SetAttributesFromForm(values url.Values) {}
+
+// Add Permission
+func NewAddPermissionRequest() *AddPermissionRequest {
+	return &AddPermissionRequest{}
+}
+
+type AddPermissionRequest struct {
+	QueueUrl      string   `json:"QueueUrl" schema:"QueueUrl"`
+	Label         string   `json:"Label" schema:"Label"`
+	AWSAccountIds []string `json:"AWSAccountIds"`
+	Actions       []string `json:"Actions"`
+}
+
+func (r *AddPermissionRequest) SetAttributesFromForm(values url.Values) {
+	for i := 1; true; i++ {
+		accountId := values.Get(fmt.Sprintf("AWSAccountId.%d", i))
+		if accountId == "" {
+			break
+		}
+		r.AWSAccountIds = append(r.AWSAccountIds, accountId)
+	}
+	for i := 1; true; i++ {
+		action := values.Get(fmt.Sprintf("ActionName.%d", i))
+		if action == "" {
+			break
+		}
+		r.Actions = append(r.Actions, action)
+	}
+}
+
+// Remove Permission
+func NewRemovePermissionRequest() *RemovePermissionRequest {
+	return &RemovePermissionRequest{}
+}
+
+type RemovePermissionRequest struct {
+	QueueUrl string `json:"QueueUrl" schema:"QueueUrl"`
+	Label    string `json:"Label" schema:"Label"`
+}
+
+func (r *RemovePermissionRequest) SetAttributesFromForm(values url.Values) {}
+
+// Tag Queue
+func NewTagQueueRequest() *TagQueueRequest {
+	return &TagQueueRequest{}
+}
+
+type TagQueueRequest struct {
+	QueueUrl string            `json:"QueueUrl" schema:"QueueUrl"`
+	Tags     map[string]string `json:"Tags" schema:"Tags"`
+}
+
+func (r *TagQueueRequest) SetAttributesFromForm(values url.Values) {
+	r.Tags = parseTags(values, "Tag")
+}
+
+// Untag Queue
+func NewUntagQueueRequest() *UntagQueueRequest {
+	return &UntagQueueRequest{}
+}
+
+type UntagQueueRequest struct {
+	QueueUrl string   `json:"QueueUrl" schema:"QueueUrl"`
+	TagKeys  []string `json:"TagKeys" schema:"TagKeys"`
+}
+
+func (r *UntagQueueRequest) SetAttributesFromForm(values url.Values) {
+	for i := 1; true; i++ {
+		tagKey := values.Get(fmt.Sprintf("TagKey.%d", i))
+		if tagKey == "" {
+			break
+		}
+		r.TagKeys = append(r.TagKeys, tagKey)
+	}
+}
+
+// List Queue Tags
+func NewListQueueTagsRequest() *ListQueueTagsRequest {
+	return &ListQueueTagsRequest{}
+}
+
+type ListQueueTagsRequest struct {
+	QueueUrl string `json:"QueueUrl" schema:"QueueUrl"`
+}
+
+func (r *ListQueueTagsRequest) SetAttributesFromForm(values url.Values) {}
+
 // ---- SNS ----
 func NewCreateTopicRequest() *CreateTopicRequest {
 	return &CreateTopicRequest{
-		Attributes: TopicAttributes{
-			FifoTopic:                 false,
-			SignatureVersion:          1,
-			TracingConfig:             "Active",
-			ContentBasedDeduplication: false,
-		},
+		Attributes: NewTopicAttributes(),
 	}
 }
 
 type CreateTopicRequest struct {
-	Name string `json:"Name" schema:"Name"`
-
-	// Goaws unsupports below properties currently.
-	DataProtectionPolicy string            `json:"DataProtectionPolicy" schema:"DataProtectionPolicy"`
-	Attributes           TopicAttributes   `json:"Attributes" schema:"Attributes"`
-	Tags                 map[string]string `json:"Tags" schema:"Tags"`
+	Name                 string          `json:"Name" schema:"Name"`
+	DataProtectionPolicy string          `json:"DataProtectionPolicy" schema:"DataProtectionPolicy"` // NOTE: not implemented
+	Attributes           TopicAttributes `json:"Attributes" schema:"Attributes"`
+	Tags                 []TopicTag      `json:"Tags" schema:"-"`
 }
 
-// Ref: https://docs.aws.amazon.com/sns/latest/api/API_CreateTopic.html
-type TopicAttributes struct {
-	DeliveryPolicy            map[string]interface{} `json:"DeliveryPolicy"`            // NOTE: not implemented
-	DisplayName               string                 `json:"DisplayName"`               // NOTE: not implemented
-	FifoTopic                 bool                   `json:"FifoTopic"`                 // NOTE: not implemented
-	Policy                    map[string]interface{} `json:"Policy"`                    // NOTE: not implemented
-	SignatureVersion          StringToInt            `json:"SignatureVersion"`          // NOTE: not implemented
-	TracingConfig             string                 `json:"TracingConfig"`             // NOTE: not implemented
-	KmsMasterKeyId            string                 `json:"KmsMasterKeyId"`            // NOTE: not implemented
-	ArchivePolicy             map[string]interface{} `json:"ArchivePolicy"`             // NOTE: not implemented
-	BeginningArchiveTime      string                 `json:"BeginningArchiveTime"`      // NOTE: not implemented
-	ContentBasedDeduplication bool                   `json:"ContentBasedDeduplication"` // NOTE: not implemented
+type TopicTag struct {
+	Key   string `json:"Key" xml:"Key"`
+	Value string `json:"Value" xml:"Value"`
 }
 
-func (r *CreateTopicRequest) SetAttributesFromForm(values url.Values) {
+// parseTopicTags reads the `<keyPrefix>.N.Key` and `<keyPrefix>.N.Value` pairs that SNS uses to send tags.
+func parseTopicTags(values url.Values, keyPrefix string) []TopicTag {
+	var result []TopicTag
 	for i := 1; true; i++ {
-		nameKey := fmt.Sprintf("Attribute.%d.Name", i)
-		attrName := values.Get(nameKey)
-		if attrName == "" {
+		key := values.Get(fmt.Sprintf("%s.%d.Key", keyPrefix, i))
+		if key == "" {
 			break
 		}
+		result = append(result, TopicTag{
+			Key:   key,
+			Value: values.Get(fmt.Sprintf("%s.%d.Value", keyPrefix, i)),
+		})
+	}
+	return result
+}
 
-		valueKey := fmt.Sprintf("Attribute.%d.Value", i)
-		attrValue := values.Get(valueKey)
-		if attrValue == "" {
+// Ref: https://docs.aws.amazon.com/sns/latest/api/API_CreateTopic.html
+type TopicAttributes struct {
+	DeliveryPolicy            map[string]interface{} `json:"DeliveryPolicy"`
+	DisplayName               string                 `json:"DisplayName"`
+	FifoTopic                 bool                   `json:"FifoTopic"`
+	Policy                    map[string]interface{} `json:"Policy"`
+	SignatureVersion          StringToInt            `json:"SignatureVersion"`
+	TracingConfig             string                 `json:"TracingConfig"`
+	KmsMasterKeyId            string                 `json:"KmsMasterKeyId"`
+	ArchivePolicy             map[string]interface{} `json:"ArchivePolicy"`
+	BeginningArchiveTime      string                 `json:"BeginningArchiveTime"`
+	ContentBasedDeduplication bool                   `json:"ContentBasedDeduplication"`
+}
+
+func NewTopicAttributes() TopicAttributes {
+	return TopicAttributes{
+		FifoTopic:                 false,
+		SignatureVersion:          1,
+		TracingConfig:             "Active",
+		ContentBasedDeduplication: false,
+	}
+}
+
+// AttributesMap flattens the attributes into the string values SNS stores on a topic.  Unset values are left
+// out, and the FIFO only attributes are only included for FIFO topics.
+func (a TopicAttributes) AttributesMap() map[string]string {
+	result := map[string]string{
+		"SignatureVersion": strconv.Itoa(int(a.SignatureVersion)),
+	}
+	policies := map[string]map[string]interface{}{
+		"DeliveryPolicy": a.DeliveryPolicy,
+		"Policy":         a.Policy,
+		"ArchivePolicy":  a.ArchivePolicy,
+	}
+	for name, policy := range policies {
+		if policy == nil {
 			continue
 		}
+		policyBytes, err := json.Marshal(policy)
+		if err != nil {
+			log.Debugf("Failed to marshal topic attribute - %s: %v", name, policy)
+			continue
+		}
+		result[name] = string(policyBytes)
+	}
+	strs := map[string]string{
+		"DisplayName":          a.DisplayName,
+		"TracingConfig":        a.TracingConfig,
+		"KmsMasterKeyId":       a.KmsMasterKeyId,
+		"BeginningArchiveTime": a.BeginningArchiveTime,
+	}
+	for name, value := range strs {
+		if value != "" {
+			result[name] = value
+		}
+	}
+	if a.FifoTopic {
+		result["FifoTopic"] = "true"
+		result["ContentBasedDeduplication"] = strconv.FormatBool(a.ContentBasedDeduplication)
+	}
+	return result
+}
+
+func (r *CreateTopicRequest) SetAttributesFromForm(values url.Values) {
+	// The SNS SDKs send `Attributes.entry.N.key`, but we've historically accepted the SQS style `Attribute.N.Name` too.
+	attributes := make(map[string]string)
+	for _, keyFormat := range [][2]string{{"Attributes.entry.%d.key", "Attributes.entry.%d.value"}, {"Attribute.%d.Name", "Attribute.%d.Value"}} {
+		for i := 1; true; i++ {
+			attrName := values.Get(fmt.Sprintf(keyFormat[0], i))
+			if attrName == "" {
+				break
+			}
+			attrValue := values.Get(fmt.Sprintf(keyFormat[1], i))
+			if attrValue == "" {
+				continue
+			}
+			attributes[attrName] = attrValue
+		}
+	}
 
+	for attrName, attrValue := range attributes {
 		switch attrName {
 		case "DeliveryPolicy":
 			var tmp map[string]interface{}
@@ -619,6 +989,7 @@ func (r *CreateTopicRequest) SetAttributesFromForm(values url.Values) {
 			r.Attributes.ContentBasedDeduplication = tmp
 		}
 	}
+	r.Tags = parseTopicTags(values, "Tags.member")
 }
 
 func NewSubscribeRequest() *SubscribeRequest {
@@ -626,10 +997,11 @@ func NewSubscribeRequest() *SubscribeRequest {
 }
 
 type SubscribeRequest struct {
-	TopicArn   string                 `json:"TopicArn" schema:"TopicArn"`
-	Endpoint   string                 `json:"Endpoint" schema:"Endpoint"`
-	Protocol   string                 `json:"Protocol" schema:"Protocol"`
-	Attributes SubscriptionAttributes `json:"Attributes"`
+	TopicArn              string                 `json:"TopicArn" schema:"TopicArn"`
+	Endpoint              string                 `json:"Endpoint" schema:"Endpoint"`
+	Protocol              string                 `json:"Protocol" schema:"Protocol"`
+	Attributes            SubscriptionAttributes `json:"Attributes"`
+	ReturnSubscriptionArn bool                   `json:"ReturnSubscriptionArn" schema:"ReturnSubscriptionArn"`
 }
 
 func (r *SubscribeRequest) SetAttributesFromForm(values url.Values) {
@@ -654,24 +1026,49 @@ func (r *SubscribeRequest) SetAttributesFromForm(values url.Values) {
 			}
 			r.Attributes.RawMessageDelivery = tmp
 		case "FilterPolicy":
-			var tmp map[string][]string
+			var tmp FilterPolicy
 			err := json.Unmarshal([]byte(attrValue), &tmp)
+			if err == nil {
+				err = tmp.Validate()
+			}
 			if err != nil {
 				log.Debugf("Failed to parse form attribute - %s: %s", attrName, attrValue)
 				continue
 			}
 			r.Attributes.FilterPolicy = tmp
+		case "FilterPolicyScope":
+			if attrValue != FilterPolicyScopeMessageAttributes && attrValue != FilterPolicyScopeMessageBody {
+				log.Debugf("Failed to parse form attribute - %s: %s", attrName, attrValue)
+				continue
+			}
+			r.Attributes.FilterPolicyScope = attrValue
+		case "DeliveryPolicy":
+			tmp := &DeliveryPolicy{}
+			err := json.Unmarshal([]byte(attrValue), tmp)
+			if err != nil {
+				log.Debugf("Failed to parse form attribute - %s: %s", attrName, attrValue)
+				continue
+			}
+			r.Attributes.DeliveryPolicy = tmp
+		case "RedrivePolicy":
+			tmp := &SubscriptionRedrivePolicy{}
+			err := json.Unmarshal([]byte(attrValue), tmp)
+			if err != nil || tmp.DeadLetterTargetArn == "" {
+				log.Debugf("Failed to parse form attribute - %s: %s", attrName, attrValue)
+				continue
+			}
+			r.Attributes.RedrivePolicy = tmp
 		}
 	}
 	return
 }
 
 type SubscriptionAttributes struct {
-	FilterPolicy       FilterPolicy `json:"FilterPolicy" schema:"FilterPolicy"`
-	RawMessageDelivery bool         `json:"RawMessageDelivery" schema:"RawMessageDelivery"`
-	//DeliveryPolicy      map[string]interface{} `json:"DeliveryPolicy" schema:"DeliveryPolicy"`
-	//FilterPolicyScope   string                 `json:"FilterPolicyScope" schema:"FilterPolicyScope"`
-	//RedrivePolicy       RedrivePolicy          `json:"RedrivePolicy" schema:"RawMessageDelivery"`
+	FilterPolicy       FilterPolicy               `json:"FilterPolicy" schema:"FilterPolicy"`
+	FilterPolicyScope  string                     `json:"FilterPolicyScope" schema:"FilterPolicyScope"`
+	RawMessageDelivery bool                       `json:"RawMessageDelivery" schema:"RawMessageDelivery"`
+	DeliveryPolicy     *DeliveryPolicy            `json:"DeliveryPolicy" schema:"DeliveryPolicy"`
+	RedrivePolicy      *SubscriptionRedrivePolicy `json:"RedrivePolicy" schema:"RedrivePolicy"`
 	//SubscriptionRoleArn string                 `json:"SubscriptionRoleArn" schema:"SubscriptionRoleArn"`
 	//ReplayPolicy        string                 `json:"ReplayPolicy" schema:"ReplayPolicy"`
 	//ReplayStatus        string                 `json:"ReplayStatus" schema:"ReplayStatus"`
@@ -701,6 +1098,8 @@ type PublishRequest struct {
 	Subject                string                      `json:"Subject" schema:"Subject"`
 	TargetArn              string                      `json:"TargetArn" schema:"TargetArn"` // Not implemented
 	TopicArn               string                      `json:"TopicArn" schema:"TopicArn"`
+	// AWSTraceHeader is the X-Ray trace header the message was published with, passed on to SQS subscriptions.
+	AWSTraceHeader string `json:"-" schema:"-"`
 }
 
 func (r *PublishRequest) SetAttributesFromForm(values url.Values) {
@@ -724,6 +1123,10 @@ func (r *PublishRequest) GetSubject() string {
 	return r.Subject
 }
 
+func (r *PublishRequest) GetAWSTraceHeader() string {
+	return r.AWSTraceHeader
+}
+
 // ListTopics
 
 func NewListTopicsRequest() *ListTopicsRequest {
@@ -786,6 +1189,33 @@ type SetSubscriptionAttributesRequest struct {
 
 func (r *SetSubscriptionAttributesRequest) SetAttributesFromForm(values url.Values) {}
 
+// Get Topic Attributes
+
+func NewGetTopicAttributesRequest() *GetTopicAttributesRequest {
+	return &GetTopicAttributesRequest{}
+}
+
+type GetTopicAttributesRequest struct {
+	TopicArn string `json:"TopicArn" schema:"TopicArn"`
+}
+
+func (r *GetTopicAttributesRequest) SetAttributesFromForm(values url.Values) {}
+
+// Set Topic Attributes
+
+func NewSetTopicAttributesRequest() *SetTopicAttributesRequest {
+	return &SetTopicAttributesRequest{}
+}
+
+// Ref: https://docs.aws.amazon.com/sns/latest/api/API_SetTopicAttributes.html
+type SetTopicAttributesRequest struct {
+	TopicArn       string `json:"TopicArn" schema:"TopicArn"`
+	AttributeName  string `json:"AttributeName" schema:"AttributeName"`
+	AttributeValue string `json:"AttributeValue" schema:"AttributeValue"`
+}
+
+func (r *SetTopicAttributesRequest) SetAttributesFromForm(values url.Values) {}
+
 // List Subscriptions By Topic
 
 func NewListSubscriptionsByTopicRequest() *ListSubscriptionsByTopicRequest {
@@ -845,6 +1275,8 @@ type PublishBatchRequestEntry struct {
 	MessageGroupId         string                      `json:"MessageGroupId" schema:"MessageGroupId"`                 // Not implemented
 	MessageStructure       string                      `json:"MessageStructure" schema:"MessageStructure"`
 	Subject                string                      `json:"Subject" schema:"Subject"`
+	// AWSTraceHeader is the X-Ray trace header the batch was published with, passed on to SQS subscriptions.
+	AWSTraceHeader string `json:"-" schema:"-"`
 }
 
 // Satisfy the AbstractPublishEntry interface
@@ -863,3 +1295,55 @@ func (r *PublishBatchRequestEntry) GetMessageStructure() string {
 func (r *PublishBatchRequestEntry) GetSubject() string {
 	return r.Subject
 }
+
+func (r *PublishBatchRequestEntry) GetAWSTraceHeader() string {
+	return r.AWSTraceHeader
+}
+
+// Tag Resource
+
+func NewTagResourceRequest() *TagResourceRequest {
+	return &TagResourceRequest{}
+}
+
+type TagResourceRequest struct {
+	ResourceArn string     `json:"ResourceArn" schema:"ResourceArn"`
+	Tags        []TopicTag `json:"Tags" schema:"-"`
+}
+
+func (r *TagResourceRequest) SetAttributesFromForm(values url.Values) {
+	r.Tags = parseTopicTags(values, "Tags.member")
+}
+
+// Untag Resource
+
+func NewUntagResourceRequest() *UntagResourceRequest {
+	return &UntagResourceRequest{}
+}
+
+type UntagResourceRequest struct {
+	ResourceArn string   `json:"ResourceArn" schema:"ResourceArn"`
+	TagKeys     []string `json:"TagKeys" schema:"-"`
+}
+
+func (r *UntagResourceRequest) SetAttributesFromForm(values url.Values) {
+	for i := 1; true; i++ {
+		tagKey := values.Get(fmt.Sprintf("TagKeys.member.%d", i))
+		if tagKey == "" {
+			break
+		}
+		r.TagKeys = append(r.TagKeys, tagKey)
+	}
+}
+
+// List Tags For Resource
+
+func NewListTagsForResourceRequest() *ListTagsForResourceRequest {
+	return &ListTagsForResourceRequest{}
+}
+
+type ListTagsForResourceRequest struct {
+	ResourceArn string `json:"ResourceArn" schema:"ResourceArn"`
+}
+
+func (r *ListTagsForResourceRequest) SetAttributesFromForm(values url.Values) {}
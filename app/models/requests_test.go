@@ -61,7 +61,13 @@ func TestCreateQueueRequest_SetAttributesFromForm_success(t *testing.T) {
 	form.Add("Attribute.7.Name", "RedrivePolicy")
 	form.Add("Attribute.7.Value", "{\"maxReceiveCount\": 100, \"deadLetterTargetArn\":\"dead-letter-queue-arn\"}")
 	form.Add("Attribute.8.Name", "RedriveAllowPolicy")
-	form.Add("Attribute.8.Value", "{\"i-am\":\"the-redrive-allow-policy\"}")
+	form.Add("Attribute.8.Value", "{\"redrivePermission\":\"byQueue\",\"sourceQueueArns\":[\"source-queue-arn\"]}")
+	form.Add("Attribute.9.Name", "ContentBasedDeduplication")
+	form.Add("Attribute.9.Value", "true")
+	form.Add("Attribute.10.Name", "DeduplicationScope")
+	form.Add("Attribute.10.Value", "messageGroup")
+	form.Add("Attribute.11.Name", "FifoThroughputLimit")
+	form.Add("Attribute.11.Value", "perMessageGroupId")
 
 	cqr := &CreateQueueRequest{
 		Attributes: QueueAttributes{
@@ -77,11 +83,31 @@ func TestCreateQueueRequest_SetAttributesFromForm_success(t *testing.T) {
 	assert.Equal(t, StringToInt(1), cqr.Attributes.DelaySeconds)
 	assert.Equal(t, StringToInt(2), cqr.Attributes.MaximumMessageSize)
 	assert.Equal(t, StringToInt(3), cqr.Attributes.MessageRetentionPeriod)
-	assert.Equal(t, map[string]interface{}{"i-am": "the-policy"}, cqr.Attributes.Policy)
+	assert.Equal(t, PolicyDocument(`{"i-am":"the-policy"}`), *cqr.Attributes.Policy)
 	assert.Equal(t, StringToInt(4), cqr.Attributes.ReceiveMessageWaitTimeSeconds)
 	assert.Equal(t, StringToInt(5), cqr.Attributes.VisibilityTimeout)
 	assert.Equal(t, expectedRedrivePolicy, cqr.Attributes.RedrivePolicy)
-	assert.Equal(t, map[string]interface{}{"i-am": "the-redrive-allow-policy"}, cqr.Attributes.RedriveAllowPolicy)
+	assert.Equal(t, &RedriveAllowPolicy{RedrivePermission: "byQueue", SourceQueueArns: []string{"source-queue-arn"}}, cqr.Attributes.RedriveAllowPolicy)
+	assert.True(t, cqr.Attributes.ContentBasedDeduplication.Bool())
+	assert.Equal(t, "messageGroup", cqr.Attributes.DeduplicationScope)
+	assert.Equal(t, "perMessageGroupId", cqr.Attributes.FifoThroughputLimit)
+}
+
+func TestCreateQueueRequest_SetAttributesFromForm_success_parses_tags(t *testing.T) {
+	form := url.Values{}
+	form.Add("Action", "CreateQueue")
+	form.Add("QueueName", "new-queue")
+	form.Add("Tag.1.Key", "team")
+	form.Add("Tag.1.Value", "platform")
+	form.Add("Tag.2.Key", "empty")
+	form.Add("Tag.2.Value", "")
+	form.Add("Tag.4.Key", "skipped")
+	form.Add("Tag.4.Value", "not-sequential")
+
+	cqr := &CreateQueueRequest{}
+	cqr.SetAttributesFromForm(form)
+
+	assert.Equal(t, map[string]string{"team": "platform", "empty": ""}, cqr.Tags)
 }
 
 func TestCreateQueueRequest_SetAttributesFromForm_success_handles_redrive_recieve_count_int(t *testing.T) {
@@ -156,6 +182,8 @@ func TestCreateQueueRequest_SetAttributesFromForm_success_skips_invalid_values(t
 	form.Add("Attribute.7.Value", "garbage")
 	form.Add("Attribute.8.Name", "RedriveAllowPolicy")
 	form.Add("Attribute.8.Value", "garbage")
+	form.Add("Attribute.9.Name", "ContentBasedDeduplication")
+	form.Add("Attribute.9.Value", "garbage")
 
 	cqr := &CreateQueueRequest{
 		Attributes: QueueAttributes{
@@ -171,11 +199,12 @@ func TestCreateQueueRequest_SetAttributesFromForm_success_skips_invalid_values(t
 	assert.Equal(t, StringToInt(1), cqr.Attributes.DelaySeconds)
 	assert.Equal(t, StringToInt(262144), cqr.Attributes.MaximumMessageSize)
 	assert.Equal(t, StringToInt(345600), cqr.Attributes.MessageRetentionPeriod)
-	assert.Equal(t, map[string]interface{}(nil), cqr.Attributes.Policy)
+	assert.Nil(t, cqr.Attributes.Policy)
 	assert.Equal(t, StringToInt(10), cqr.Attributes.ReceiveMessageWaitTimeSeconds)
 	assert.Equal(t, StringToInt(30), cqr.Attributes.VisibilityTimeout)
 	assert.Equal(t, RedrivePolicy{}, cqr.Attributes.RedrivePolicy)
-	assert.Equal(t, map[string]interface{}(nil), cqr.Attributes.RedriveAllowPolicy)
+	assert.Nil(t, cqr.Attributes.RedriveAllowPolicy)
+	assert.Nil(t, cqr.Attributes.ContentBasedDeduplication)
 }
 
 func TestRedrivePolicy_UnmarshalJSON_handles_nested_json(t *testing.T) {
@@ -227,6 +256,65 @@ func TestRedrivePolicy_UnmarshalJSON_invalid_type_returns_error(t *testing.T) {
 	assert.Equal(t, "", r.DeadLetterTargetArn)
 }
 
+func TestRedriveAllowPolicy_UnmarshalJSON_handles_escaped_string(t *testing.T) {
+	request := `{"redrivePermission":"byQueue","sourceQueueArns":["arn:source-queue"]}`
+	b, _ := json.Marshal(request)
+	var r = RedriveAllowPolicy{}
+	err := r.UnmarshalJSON(b)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "byQueue", r.RedrivePermission)
+	assert.Equal(t, []string{"arn:source-queue"}, r.SourceQueueArns)
+}
+
+func TestRedriveAllowPolicy_UnmarshalJSON_invalid_json_request_returns_error(t *testing.T) {
+	var r = RedriveAllowPolicy{}
+	err := r.UnmarshalJSON([]byte("garbage"))
+
+	assert.Error(t, err)
+}
+
+func TestRedriveAllowPolicy_IsValid(t *testing.T) {
+	tooMany := []string{}
+	for i := 0; i < 11; i++ {
+		tooMany = append(tooMany, fmt.Sprintf("arn:source-queue-%d", i))
+	}
+
+	assert.True(t, (&RedriveAllowPolicy{RedrivePermission: "allowAll"}).IsValid())
+	assert.True(t, (&RedriveAllowPolicy{RedrivePermission: "denyAll"}).IsValid())
+	assert.True(t, (&RedriveAllowPolicy{RedrivePermission: "byQueue", SourceQueueArns: []string{"arn:source-queue"}}).IsValid())
+	assert.False(t, (&RedriveAllowPolicy{RedrivePermission: "byQueue"}).IsValid())
+	assert.False(t, (&RedriveAllowPolicy{RedrivePermission: "byQueue", SourceQueueArns: tooMany}).IsValid())
+	assert.False(t, (&RedriveAllowPolicy{RedrivePermission: "denyAll", SourceQueueArns: []string{"arn:source-queue"}}).IsValid())
+	assert.False(t, (&RedriveAllowPolicy{RedrivePermission: "garbage"}).IsValid())
+}
+
+func TestRedriveAllowPolicy_Allows(t *testing.T) {
+	byQueue := &RedriveAllowPolicy{RedrivePermission: "byQueue", SourceQueueArns: []string{"arn:source-queue"}}
+
+	assert.True(t, (&RedriveAllowPolicy{RedrivePermission: "allowAll"}).Allows("arn:source-queue"))
+	assert.False(t, (&RedriveAllowPolicy{RedrivePermission: "denyAll"}).Allows("arn:source-queue"))
+	assert.True(t, byQueue.Allows("arn:source-queue"))
+	assert.False(t, byQueue.Allows("arn:other-queue"))
+}
+
+func TestAddPermissionRequest_SetAttributesFromForm(t *testing.T) {
+	form := url.Values{}
+	form.Add("QueueUrl", "queue-url")
+	form.Add("Label", "label")
+	form.Add("AWSAccountId.1", "111122223333")
+	form.Add("AWSAccountId.2", "444455556666")
+	form.Add("ActionName.1", "SendMessage")
+	form.Add("ActionName.2", "ReceiveMessage")
+	form.Add("ActionName.4", "DeleteMessage")
+
+	r := &AddPermissionRequest{}
+	r.SetAttributesFromForm(form)
+
+	assert.Equal(t, []string{"111122223333", "444455556666"}, r.AWSAccountIds)
+	assert.Equal(t, []string{"SendMessage", "ReceiveMessage"}, r.Actions)
+}
+
 func TestNewListQueuesRequest_SetAttributesFromForm(t *testing.T) {
 	form := url.Values{}
 	form.Add("MaxResults", "1")
@@ -320,6 +408,48 @@ func TestSendMessageRequest_SetAttributesFromForm_success(t *testing.T) {
 	assert.Equal(t, "VmFsdWUy", attr2.BinaryValue)
 }
 
+func TestSendMessageRequest_SetAttributesFromForm_message_system_attributes(t *testing.T) {
+	form := url.Values{}
+	form.Add("MessageSystemAttribute.1.Name", "AWSTraceHeader")
+	form.Add("MessageSystemAttribute.1.Value.DataType", "String")
+	form.Add("MessageSystemAttribute.1.Value.StringValue", "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1")
+
+	r := &SendMessageRequest{
+		MessageAttributes:       make(map[string]MessageAttribute),
+		MessageSystemAttributes: make(map[string]MessageAttribute),
+	}
+	r.SetAttributesFromForm(form)
+
+	assert.Empty(t, r.MessageAttributes)
+	assert.Equal(t, map[string]MessageAttribute{
+		"AWSTraceHeader": {DataType: "String", StringValue: "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1"},
+	}, r.MessageSystemAttributes)
+}
+
+func TestTraceHeader(t *testing.T) {
+	traceHeader, err := TraceHeader(map[string]MessageAttribute{
+		"AWSTraceHeader": {DataType: "String", StringValue: "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1", traceHeader)
+
+	traceHeader, err = TraceHeader(nil)
+	assert.Nil(t, err)
+	assert.Empty(t, traceHeader)
+
+	_, err = TraceHeader(map[string]MessageAttribute{
+		"SomethingElse": {DataType: "String", StringValue: "value"},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "InvalidSystemAttribute", err.Error())
+
+	_, err = TraceHeader(map[string]MessageAttribute{
+		"AWSTraceHeader": {DataType: "Binary", BinaryValue: "dmFsdWU="},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "InvalidSystemAttribute", err.Error())
+}
+
 func TestSetQueueAttributesRequest_SetAttributesFromForm_success(t *testing.T) {
 	expectedRedrivePolicy := RedrivePolicy{
 		MaxReceiveCount:     100,
@@ -345,7 +475,13 @@ func TestSetQueueAttributesRequest_SetAttributesFromForm_success(t *testing.T) {
 	form.Add("Attribute.7.Name", "RedrivePolicy")
 	form.Add("Attribute.7.Value", "{\"maxReceiveCount\": 100, \"deadLetterTargetArn\":\"dead-letter-queue-arn\"}")
 	form.Add("Attribute.8.Name", "RedriveAllowPolicy")
-	form.Add("Attribute.8.Value", "{\"i-am\":\"the-redrive-allow-policy\"}")
+	form.Add("Attribute.8.Value", "{\"redrivePermission\":\"byQueue\",\"sourceQueueArns\":[\"source-queue-arn\"]}")
+	form.Add("Attribute.9.Name", "ContentBasedDeduplication")
+	form.Add("Attribute.9.Value", "true")
+	form.Add("Attribute.10.Name", "DeduplicationScope")
+	form.Add("Attribute.10.Value", "messageGroup")
+	form.Add("Attribute.11.Name", "FifoThroughputLimit")
+	form.Add("Attribute.11.Value", "perMessageGroupId")
 
 	cqr := &SetQueueAttributesRequest{
 		Attributes: QueueAttributes{
@@ -361,11 +497,14 @@ func TestSetQueueAttributesRequest_SetAttributesFromForm_success(t *testing.T) {
 	assert.Equal(t, StringToInt(1), cqr.Attributes.DelaySeconds)
 	assert.Equal(t, StringToInt(2), cqr.Attributes.MaximumMessageSize)
 	assert.Equal(t, StringToInt(3), cqr.Attributes.MessageRetentionPeriod)
-	assert.Equal(t, map[string]interface{}{"i-am": "the-policy"}, cqr.Attributes.Policy)
+	assert.Equal(t, PolicyDocument(`{"i-am":"the-policy"}`), *cqr.Attributes.Policy)
 	assert.Equal(t, StringToInt(4), cqr.Attributes.ReceiveMessageWaitTimeSeconds)
 	assert.Equal(t, StringToInt(5), cqr.Attributes.VisibilityTimeout)
 	assert.Equal(t, expectedRedrivePolicy, cqr.Attributes.RedrivePolicy)
-	assert.Equal(t, map[string]interface{}{"i-am": "the-redrive-allow-policy"}, cqr.Attributes.RedriveAllowPolicy)
+	assert.Equal(t, &RedriveAllowPolicy{RedrivePermission: "byQueue", SourceQueueArns: []string{"source-queue-arn"}}, cqr.Attributes.RedriveAllowPolicy)
+	assert.True(t, cqr.Attributes.ContentBasedDeduplication.Bool())
+	assert.Equal(t, "messageGroup", cqr.Attributes.DeduplicationScope)
+	assert.Equal(t, "perMessageGroupId", cqr.Attributes.FifoThroughputLimit)
 }
 
 func TestSetQueueAttributesRequest_SetAttributesFromForm_success_handles_redrive_recieve_count_int(t *testing.T) {
@@ -386,6 +525,35 @@ func TestSetQueueAttributesRequest_SetAttributesFromForm_success_handles_redrive
 	assert.Equal(t, expectedRedrivePolicy, cqr.Attributes.RedrivePolicy)
 }
 
+func TestSetQueueAttributesRequest_SetAttributesFromForm_success_empty_policy(t *testing.T) {
+	form := url.Values{}
+	form.Add("Attribute.1.Name", "Policy")
+	form.Add("Attribute.1.Value", "")
+
+	cqr := &SetQueueAttributesRequest{
+		Attributes: QueueAttributes{},
+	}
+	cqr.SetAttributesFromForm(form)
+
+	assert.NotNil(t, cqr.Attributes.Policy)
+	assert.Equal(t, PolicyDocument(""), *cqr.Attributes.Policy)
+}
+
+func TestSetQueueAttributesRequest_json_empty_policy(t *testing.T) {
+	cqr := &SetQueueAttributesRequest{}
+	err := json.Unmarshal([]byte(`{"QueueUrl": "queue-url", "Attributes": {"Policy": ""}}`), cqr)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, cqr.Attributes.Policy)
+	assert.Equal(t, PolicyDocument(""), *cqr.Attributes.Policy)
+
+	cqr = &SetQueueAttributesRequest{}
+	err = json.Unmarshal([]byte(`{"QueueUrl": "queue-url", "Attributes": {"VisibilityTimeout": "5"}}`), cqr)
+
+	assert.Nil(t, err)
+	assert.Nil(t, cqr.Attributes.Policy)
+}
+
 func TestSetQueueAttributesRequest_SetAttributesFromForm_success_handles_redrive_recieve_count_string(t *testing.T) {
 	expectedRedrivePolicy := RedrivePolicy{
 		MaxReceiveCount:     100,
@@ -440,6 +608,8 @@ func TestSetQueueAttributesRequest_SetAttributesFromForm_success_skips_invalid_v
 	form.Add("Attribute.7.Value", "garbage")
 	form.Add("Attribute.8.Name", "RedriveAllowPolicy")
 	form.Add("Attribute.8.Value", "garbage")
+	form.Add("Attribute.9.Name", "ContentBasedDeduplication")
+	form.Add("Attribute.9.Value", "garbage")
 
 	cqr := &SetQueueAttributesRequest{
 		Attributes: QueueAttributes{
@@ -455,11 +625,39 @@ func TestSetQueueAttributesRequest_SetAttributesFromForm_success_skips_invalid_v
 	assert.Equal(t, StringToInt(1), cqr.Attributes.DelaySeconds)
 	assert.Equal(t, StringToInt(262144), cqr.Attributes.MaximumMessageSize)
 	assert.Equal(t, StringToInt(345600), cqr.Attributes.MessageRetentionPeriod)
-	assert.Equal(t, map[string]interface{}(nil), cqr.Attributes.Policy)
+	assert.Nil(t, cqr.Attributes.Policy)
 	assert.Equal(t, StringToInt(10), cqr.Attributes.ReceiveMessageWaitTimeSeconds)
 	assert.Equal(t, StringToInt(30), cqr.Attributes.VisibilityTimeout)
 	assert.Equal(t, RedrivePolicy{}, cqr.Attributes.RedrivePolicy)
-	assert.Equal(t, map[string]interface{}(nil), cqr.Attributes.RedriveAllowPolicy)
+	assert.Nil(t, cqr.Attributes.RedriveAllowPolicy)
+	assert.Nil(t, cqr.Attributes.ContentBasedDeduplication)
+}
+
+func TestTagQueueRequest_SetAttributesFromForm(t *testing.T) {
+	form := url.Values{}
+	form.Add("QueueUrl", "queue-url")
+	form.Add("Tag.1.Key", "team")
+	form.Add("Tag.1.Value", "platform")
+	form.Add("Tag.2.Key", "env")
+	form.Add("Tag.2.Value", "local")
+
+	r := &TagQueueRequest{}
+	r.SetAttributesFromForm(form)
+
+	assert.Equal(t, map[string]string{"team": "platform", "env": "local"}, r.Tags)
+}
+
+func TestUntagQueueRequest_SetAttributesFromForm(t *testing.T) {
+	form := url.Values{}
+	form.Add("QueueUrl", "queue-url")
+	form.Add("TagKey.1", "team")
+	form.Add("TagKey.2", "env")
+	form.Add("TagKey.4", "skipped")
+
+	r := &UntagQueueRequest{}
+	r.SetAttributesFromForm(form)
+
+	assert.Equal(t, []string{"team", "env"}, r.TagKeys)
 }
 
 func TestNewCreateTopicRequest(t *testing.T) {
@@ -475,6 +673,36 @@ func TestNewCreateTopicRequest(t *testing.T) {
 	assert.Equal(t, false, result.Attributes.ContentBasedDeduplication)
 }
 
+func TestTopicAttributes_AttributesMap_defaults(t *testing.T) {
+	result := NewTopicAttributes().AttributesMap()
+
+	expected := map[string]string{
+		"SignatureVersion": "1",
+		"TracingConfig":    "Active",
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestTopicAttributes_AttributesMap_fifo_topic(t *testing.T) {
+	attrs := NewTopicAttributes()
+	attrs.FifoTopic = true
+	attrs.ContentBasedDeduplication = true
+	attrs.DisplayName = "display-name"
+	attrs.Policy = map[string]interface{}{"i-am": "the-policy"}
+
+	result := attrs.AttributesMap()
+
+	expected := map[string]string{
+		"ContentBasedDeduplication": "true",
+		"DisplayName":               "display-name",
+		"FifoTopic":                 "true",
+		"Policy":                    `{"i-am":"the-policy"}`,
+		"SignatureVersion":          "1",
+		"TracingConfig":             "Active",
+	}
+	assert.Equal(t, expected, result)
+}
+
 func TestCreateTopicRequest_SetAttributesFromForm_success(t *testing.T) {
 	form := url.Values{}
 	form.Add("Action", "CreateQueue")
@@ -528,6 +756,10 @@ func TestSubscribeRequest_SetAttributesFromForm_success(t *testing.T) {
 	form.Add("Attributes.entry.1.value", "true")
 	form.Add("Attributes.entry.2.key", "FilterPolicy")
 	form.Add("Attributes.entry.2.value", "{\"filter\": [\"policy\"]}")
+	form.Add("Attributes.entry.3.key", "DeliveryPolicy")
+	form.Add("Attributes.entry.3.value", "{\"healthyRetryPolicy\": {\"numRetries\": 2}}")
+	form.Add("Attributes.entry.4.key", "RedrivePolicy")
+	form.Add("Attributes.entry.4.value", "{\"deadLetterTargetArn\": \"arn:aws:sqs:region:accountID:dlq\"}")
 
 	cqr := &SubscribeRequest{
 		Attributes: SubscriptionAttributes{},
@@ -535,7 +767,9 @@ func TestSubscribeRequest_SetAttributesFromForm_success(t *testing.T) {
 	cqr.SetAttributesFromForm(form)
 
 	assert.True(t, cqr.Attributes.RawMessageDelivery)
-	assert.Equal(t, FilterPolicy{"filter": []string{"policy"}}, cqr.Attributes.FilterPolicy)
+	assert.Equal(t, FilterPolicy{"filter": []interface{}{"policy"}}, cqr.Attributes.FilterPolicy)
+	assert.Equal(t, &DeliveryPolicy{HealthyRetryPolicy: &RetryPolicy{NumRetries: 2}}, cqr.Attributes.DeliveryPolicy)
+	assert.Equal(t, &SubscriptionRedrivePolicy{DeadLetterTargetArn: "arn:aws:sqs:region:accountID:dlq"}, cqr.Attributes.RedrivePolicy)
 }
 
 func TestSubscribeRequest_SetAttributesFromForm_skips_invalid_values(t *testing.T) {
@@ -544,6 +778,10 @@ func TestSubscribeRequest_SetAttributesFromForm_skips_invalid_values(t *testing.
 	form.Add("Attributes.entry.1.value", "garbage")
 	form.Add("Attributes.entry.2.key", "FilterPolicy")
 	form.Add("Attributes.entry.2.value", "also-garbage")
+	form.Add("Attributes.entry.3.key", "DeliveryPolicy")
+	form.Add("Attributes.entry.3.value", "more-garbage")
+	form.Add("Attributes.entry.4.key", "RedrivePolicy")
+	form.Add("Attributes.entry.4.value", "{}")
 
 	cqr := &SubscribeRequest{
 		Attributes: SubscriptionAttributes{},
@@ -552,6 +790,8 @@ func TestSubscribeRequest_SetAttributesFromForm_skips_invalid_values(t *testing.
 
 	assert.False(t, cqr.Attributes.RawMessageDelivery)
 	assert.Equal(t, FilterPolicy(nil), cqr.Attributes.FilterPolicy)
+	assert.Nil(t, cqr.Attributes.DeliveryPolicy)
+	assert.Nil(t, cqr.Attributes.RedrivePolicy)
 }
 
 func TestSubscribeRequest_SetAttributesFromForm_stops_if_attributes_not_numbered_sequentially(t *testing.T) {
@@ -625,6 +865,38 @@ func Test_DeleteMessageBatchRequest_SetAttributesFromForm_stops_at_invalid_keys(
 	assert.Equal(t, "receipt-handle-1", dmbr.Entries[0].ReceiptHandle)
 }
 
+func Test_ChangeMessageVisibilityBatchRequest_SetAttributesFromForm_success(t *testing.T) {
+	form := url.Values{}
+	form.Add("ChangeMessageVisibilityBatchRequestEntry.1.Id", "message-id-1")
+	form.Add("ChangeMessageVisibilityBatchRequestEntry.1.ReceiptHandle", "receipt-handle-1")
+	form.Add("ChangeMessageVisibilityBatchRequestEntry.1.VisibilityTimeout", "60")
+	form.Add("ChangeMessageVisibilityBatchRequestEntry.2.Id", "message-id-2")
+	form.Add("ChangeMessageVisibilityBatchRequestEntry.2.ReceiptHandle", "receipt-handle-2")
+	form.Add("ChangeMessageVisibilityBatchRequestEntry.2.VisibilityTimeout", "0")
+	form.Add("ChangeMessageVisibilityBatchRequestEntry.4.Id", "message-id-4")
+	form.Add("ChangeMessageVisibilityBatchRequestEntry.4.ReceiptHandle", "receipt-handle-4")
+
+	cmvbr := &ChangeMessageVisibilityBatchRequest{}
+	cmvbr.SetAttributesFromForm(form)
+
+	assert.Equal(t, []ChangeMessageVisibilityBatchRequestEntry{
+		{Id: "message-id-1", ReceiptHandle: "receipt-handle-1", VisibilityTimeout: 60},
+		{Id: "message-id-2", ReceiptHandle: "receipt-handle-2", VisibilityTimeout: 0},
+	}, cmvbr.Entries)
+}
+
+func Test_ChangeMessageVisibilityBatchRequest_SetAttributesFromForm_missing_visibility_timeout_is_invalid(t *testing.T) {
+	form := url.Values{}
+	form.Add("ChangeMessageVisibilityBatchRequestEntry.1.Id", "message-id-1")
+	form.Add("ChangeMessageVisibilityBatchRequestEntry.1.ReceiptHandle", "receipt-handle-1")
+
+	cmvbr := &ChangeMessageVisibilityBatchRequest{}
+	cmvbr.SetAttributesFromForm(form)
+
+	assert.Len(t, cmvbr.Entries, 1)
+	assert.Equal(t, -1, cmvbr.Entries[0].VisibilityTimeout)
+}
+
 func TestPublishRequest_SetAttributesFromForm_success_concurrent(t *testing.T) {
 	form := url.Values{}
 	form.Add("MessageAttributes.entry.1.Name", "test1")
@@ -737,3 +1009,119 @@ func TestParseMessageAttributes(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateTopicRequest_SetAttributesFromForm_parses_tags(t *testing.T) {
+	form := url.Values{}
+	form.Add("Name", "new-topic")
+	form.Add("Tags.member.1.Key", "team")
+	form.Add("Tags.member.1.Value", "platform")
+	form.Add("Tags.member.2.Key", "env")
+	form.Add("Tags.member.2.Value", "dev")
+
+	r := &CreateTopicRequest{}
+	r.SetAttributesFromForm(form)
+
+	expectedTags := []TopicTag{
+		{Key: "team", Value: "platform"},
+		{Key: "env", Value: "dev"},
+	}
+	assert.Equal(t, expectedTags, r.Tags)
+}
+
+func TestTagResourceRequest_SetAttributesFromForm_success(t *testing.T) {
+	form := url.Values{}
+	form.Add("ResourceArn", "arn:aws:sns:us-east-1:100010001000:new-topic")
+	form.Add("Tags.member.1.Key", "team")
+	form.Add("Tags.member.1.Value", "platform")
+	form.Add("Tags.member.3.Key", "skipped")
+	form.Add("Tags.member.3.Value", "skipped")
+
+	r := &TagResourceRequest{}
+	r.SetAttributesFromForm(form)
+
+	assert.Equal(t, []TopicTag{{Key: "team", Value: "platform"}}, r.Tags)
+}
+
+func TestUntagResourceRequest_SetAttributesFromForm_success(t *testing.T) {
+	form := url.Values{}
+	form.Add("ResourceArn", "arn:aws:sns:us-east-1:100010001000:new-topic")
+	form.Add("TagKeys.member.1", "team")
+	form.Add("TagKeys.member.2", "env")
+	form.Add("TagKeys.member.4", "skipped")
+
+	r := &UntagResourceRequest{}
+	r.SetAttributesFromForm(form)
+
+	assert.Equal(t, []string{"team", "env"}, r.TagKeys)
+}
+
+func TestCreateTopicRequest_SetAttributesFromForm_sdk_attribute_entries(t *testing.T) {
+	form := url.Values{}
+	form.Add("Name", "new-topic")
+	form.Add("Attributes.entry.1.key", "DisplayName")
+	form.Add("Attributes.entry.1.value", "Foo")
+	form.Add("Attributes.entry.2.key", "FifoTopic")
+	form.Add("Attributes.entry.2.value", "true")
+
+	ctr := &CreateTopicRequest{}
+	ctr.SetAttributesFromForm(form)
+
+	assert.Equal(t, "Foo", ctr.Attributes.DisplayName)
+	assert.Equal(t, true, ctr.Attributes.FifoTopic)
+}
+
+func TestSubscribeRequest_SetAttributesFromForm_parses_filter_policy_operators(t *testing.T) {
+	form := url.Values{}
+	form.Add("Attributes.entry.1.key", "FilterPolicy")
+	form.Add("Attributes.entry.1.value", `{"price": [{"numeric": [">", 0]}], "color": [{"anything-but": {"prefix": "re"}}]}`)
+
+	cqr := &SubscribeRequest{
+		Attributes: SubscriptionAttributes{},
+	}
+	cqr.SetAttributesFromForm(form)
+
+	expectedFilterPolicy := FilterPolicy{
+		"price": []interface{}{map[string]interface{}{"numeric": []interface{}{">", float64(0)}}},
+		"color": []interface{}{map[string]interface{}{"anything-but": map[string]interface{}{"prefix": "re"}}},
+	}
+	assert.Equal(t, expectedFilterPolicy, cqr.Attributes.FilterPolicy)
+}
+
+func TestSubscribeRequest_SetAttributesFromForm_parses_filter_policy_scope(t *testing.T) {
+	form := url.Values{}
+	form.Add("Attributes.entry.1.key", "FilterPolicyScope")
+	form.Add("Attributes.entry.1.value", "MessageBody")
+
+	cqr := &SubscribeRequest{}
+	cqr.SetAttributesFromForm(form)
+
+	assert.Equal(t, FilterPolicyScopeMessageBody, cqr.Attributes.FilterPolicyScope)
+}
+
+func TestSubscribeRequest_SetAttributesFromForm_skips_invalid_filter_policy_scope(t *testing.T) {
+	form := url.Values{}
+	form.Add("Attributes.entry.1.key", "FilterPolicyScope")
+	form.Add("Attributes.entry.1.value", "garbage")
+
+	cqr := &SubscribeRequest{}
+	cqr.SetAttributesFromForm(form)
+
+	assert.Equal(t, "", cqr.Attributes.FilterPolicyScope)
+}
+
+func TestReceiveMessageRequest_SetAttributesFromForm_success(t *testing.T) {
+	form := url.Values{}
+	form.Add("AttributeName.1", "SentTimestamp")
+	form.Add("AttributeName.2", "SenderId")
+	form.Add("MessageSystemAttributeName.1", "AWSTraceHeader")
+	form.Add("MessageAttributeName.1", "attr1")
+	form.Add("MessageAttributeName.2", "prefix.*")
+	form.Add("MessageAttributeName.4", "skipped")
+
+	rmr := &ReceiveMessageRequest{}
+	rmr.SetAttributesFromForm(form)
+
+	assert.Equal(t, []string{"SentTimestamp", "SenderId"}, rmr.AttributeNames)
+	assert.Equal(t, []string{"AWSTraceHeader"}, rmr.MessageSystemAttributeNames)
+	assert.Equal(t, []string{"attr1", "prefix.*"}, rmr.MessageAttributeNames)
+}
@@ -154,6 +154,26 @@ func (r ListQueuesResponse) GetRequestId() string {
 	return r.Metadata.RequestId
 }
 
+/*** List Dead Letter Source Queues ***/
+type ListDeadLetterSourceQueuesResult struct {
+	QueueUrls []string `json:"queueUrls" xml:"QueueUrl"`
+	NextToken string   `json:"NextToken,omitempty" xml:"NextToken,omitempty"`
+}
+
+type ListDeadLetterSourceQueuesResponse struct {
+	Xmlns    string                           `json:"Xmlns" xml:"xmlns,attr"`
+	Result   ListDeadLetterSourceQueuesResult `json:"ListDeadLetterSourceQueuesResult" xml:"ListDeadLetterSourceQueuesResult"`
+	Metadata ResponseMetadata                 `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r ListDeadLetterSourceQueuesResponse) GetResult() interface{} {
+	return r.Result
+}
+
+func (r ListDeadLetterSourceQueuesResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
 /*** Get Queue QueueAttributes ***/
 type Attribute struct {
 	Name  string `json:"Name,omitempty" xml:"Name,omitempty"`
@@ -315,6 +335,62 @@ func (r DeleteQueueResponse) GetRequestId() string {
 	return r.Metadata.RequestId
 }
 
+/*** Tag Queue Response */
+type TagQueueResponse struct {
+	Xmlns    string           `json:"Xmlns" xml:"xmlns,attr"`
+	Metadata ResponseMetadata `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r TagQueueResponse) GetResult() interface{} {
+	return nil
+}
+
+func (r TagQueueResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
+/*** Untag Queue Response */
+type UntagQueueResponse struct {
+	Xmlns    string           `json:"Xmlns" xml:"xmlns,attr"`
+	Metadata ResponseMetadata `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r UntagQueueResponse) GetResult() interface{} {
+	return nil
+}
+
+func (r UntagQueueResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
+/*** List Queue Tags Response */
+type QueueTag struct {
+	Key   string `json:"Key" xml:"Key"`
+	Value string `json:"Value" xml:"Value"`
+}
+
+type ListQueueTagsResult struct {
+	Tags []QueueTag `json:"Tags,omitempty" xml:"Tag,omitempty"`
+}
+
+type ListQueueTagsResponse struct {
+	Xmlns    string              `json:"Xmlns" xml:"xmlns,attr"`
+	Result   ListQueueTagsResult `json:"ListQueueTagsResult" xml:"ListQueueTagsResult"`
+	Metadata ResponseMetadata    `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r ListQueueTagsResponse) GetResult() interface{} {
+	result := map[string]string{}
+	for _, tag := range r.Result.Tags {
+		result[tag.Key] = tag.Value
+	}
+	return map[string]map[string]string{"Tags": result}
+}
+
+func (r ListQueueTagsResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
 /*** Create Topic Response */
 type CreateTopicResult struct {
 	TopicArn string `json:"TopicArn" xml:"TopicArn"`
@@ -410,6 +486,127 @@ func (r DeleteMessageBatchResponse) GetRequestId() string {
 	return r.Metadata.RequestId
 }
 
+type ChangeMessageVisibilityBatchResultEntry struct {
+	Id string `json:"Id" xml:"Id"`
+}
+
+type ChangeMessageVisibilityBatchResult struct {
+	Successful []ChangeMessageVisibilityBatchResultEntry `json:"Successful" xml:"ChangeMessageVisibilityBatchResultEntry"`
+	Failed     []BatchResultErrorEntry                   `json:"Failed,omitempty" xml:"BatchResultErrorEntry,omitempty"`
+}
+
+/*** Change Message Visibility Batch Response */
+type ChangeMessageVisibilityBatchResponse struct {
+	Xmlns    string                             `json:"Xmlns" xml:"xmlns,attr"`
+	Result   ChangeMessageVisibilityBatchResult `json:"ChangeMessageVisibilityBatchResult" xml:"ChangeMessageVisibilityBatchResult"`
+	Metadata ResponseMetadata                   `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r ChangeMessageVisibilityBatchResponse) GetResult() interface{} {
+	return r.Result
+}
+
+func (r ChangeMessageVisibilityBatchResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
+/*** Start Message Move Task ***/
+type StartMessageMoveTaskResult struct {
+	TaskHandle string `json:"TaskHandle" xml:"TaskHandle"`
+}
+
+type StartMessageMoveTaskResponse struct {
+	Xmlns    string                     `json:"Xmlns" xml:"xmlns,attr"`
+	Result   StartMessageMoveTaskResult `json:"StartMessageMoveTaskResult" xml:"StartMessageMoveTaskResult"`
+	Metadata ResponseMetadata           `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r StartMessageMoveTaskResponse) GetResult() interface{} {
+	return r.Result
+}
+
+func (r StartMessageMoveTaskResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
+/*** List Message Move Tasks ***/
+type ListMessageMoveTasksResultEntry struct {
+	TaskHandle                        string `json:"TaskHandle,omitempty" xml:"TaskHandle,omitempty"`
+	Status                            string `json:"Status" xml:"Status"`
+	SourceArn                         string `json:"SourceArn" xml:"SourceArn"`
+	DestinationArn                    string `json:"DestinationArn,omitempty" xml:"DestinationArn,omitempty"`
+	MaxNumberOfMessagesPerSecond      int    `json:"MaxNumberOfMessagesPerSecond,omitempty" xml:"MaxNumberOfMessagesPerSecond,omitempty"`
+	ApproximateNumberOfMessagesMoved  int64  `json:"ApproximateNumberOfMessagesMoved" xml:"ApproximateNumberOfMessagesMoved"`
+	ApproximateNumberOfMessagesToMove int64  `json:"ApproximateNumberOfMessagesToMove" xml:"ApproximateNumberOfMessagesToMove"`
+	FailureReason                     string `json:"FailureReason,omitempty" xml:"FailureReason,omitempty"`
+	StartedTimestamp                  int64  `json:"StartedTimestamp" xml:"StartedTimestamp"`
+}
+
+type ListMessageMoveTasksResult struct {
+	Results []ListMessageMoveTasksResultEntry `json:"Results" xml:"ListMessageMoveTasksResultEntry"`
+}
+
+type ListMessageMoveTasksResponse struct {
+	Xmlns    string                     `json:"Xmlns" xml:"xmlns,attr"`
+	Result   ListMessageMoveTasksResult `json:"ListMessageMoveTasksResult" xml:"ListMessageMoveTasksResult"`
+	Metadata ResponseMetadata           `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r ListMessageMoveTasksResponse) GetResult() interface{} {
+	return r.Result
+}
+
+func (r ListMessageMoveTasksResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
+/*** Cancel Message Move Task ***/
+type CancelMessageMoveTaskResult struct {
+	ApproximateNumberOfMessagesMoved int64 `json:"ApproximateNumberOfMessagesMoved" xml:"ApproximateNumberOfMessagesMoved"`
+}
+
+type CancelMessageMoveTaskResponse struct {
+	Xmlns    string                      `json:"Xmlns" xml:"xmlns,attr"`
+	Result   CancelMessageMoveTaskResult `json:"CancelMessageMoveTaskResult" xml:"CancelMessageMoveTaskResult"`
+	Metadata ResponseMetadata            `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r CancelMessageMoveTaskResponse) GetResult() interface{} {
+	return r.Result
+}
+
+func (r CancelMessageMoveTaskResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
+/*** Add Permission Response */
+type AddPermissionResponse struct {
+	Xmlns    string           `json:"Xmlns" xml:"xmlns,attr"`
+	Metadata ResponseMetadata `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r AddPermissionResponse) GetResult() interface{} {
+	return nil
+}
+
+func (r AddPermissionResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
+/*** Remove Permission Response */
+type RemovePermissionResponse struct {
+	Xmlns    string           `json:"Xmlns" xml:"xmlns,attr"`
+	Metadata ResponseMetadata `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r RemovePermissionResponse) GetResult() interface{} {
+	return nil
+}
+
+func (r RemovePermissionResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
 /*** Publish ***/
 type PublishResult struct {
 	MessageId string `xml:"MessageId"`
@@ -545,6 +742,48 @@ func (r SetSubscriptionAttributesResponse) GetRequestId() string {
 	return r.Metadata.RequestId
 }
 
+/*** Get Topic Attributes ***/
+type GetTopicAttributesResult struct {
+	Attributes TopicAttributeEntries `json:"Attributes,omitempty" xml:"Attributes,omitempty"`
+}
+
+type TopicAttributeEntries struct {
+	Entries []TopicAttributeEntry `json:"Entries,omitempty" xml:"entry,omitempty"`
+}
+
+type TopicAttributeEntry struct {
+	Key   string `json:"Key,omitempty" xml:"key,omitempty"`
+	Value string `json:"Value,omitempty" xml:"value,omitempty"`
+}
+
+type GetTopicAttributesResponse struct {
+	Xmlns    string                   `json:"Xmlns" xml:"xmlns,attr"`
+	Result   GetTopicAttributesResult `json:"GetTopicAttributesResult" xml:"GetTopicAttributesResult"`
+	Metadata ResponseMetadata         `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r GetTopicAttributesResponse) GetResult() interface{} {
+	return r.Result
+}
+
+func (r GetTopicAttributesResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
+/*** Set Topic Attributes ***/
+type SetTopicAttributesResponse struct {
+	Xmlns    string           `json:"Xmlns" xml:"xmlns,attr"`
+	Metadata ResponseMetadata `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r SetTopicAttributesResponse) GetResult() interface{} {
+	return nil
+}
+
+func (r SetTopicAttributesResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
 /*** List Subscriptions By Topic Response */
 type ListSubscriptionsByTopicResult struct {
 	NextToken     string             `json:"NextToken" xml:"NextToken"` // not implemented
@@ -597,3 +836,62 @@ func (r PublishBatchResponse) GetResult() interface{} {
 func (r PublishBatchResponse) GetRequestId() string {
 	return r.Metadata.RequestId
 }
+
+/*** Tag Resource ***/
+// TagResourceResult is always empty, but the SDKs expect to find the node in the response.
+type TagResourceResult struct{}
+
+type TagResourceResponse struct {
+	Xmlns    string            `json:"Xmlns" xml:"xmlns,attr"`
+	Result   TagResourceResult `json:"TagResourceResult" xml:"TagResourceResult"`
+	Metadata ResponseMetadata  `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r TagResourceResponse) GetResult() interface{} {
+	return nil
+}
+
+func (r TagResourceResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
+/*** Untag Resource ***/
+// UntagResourceResult is always empty, but the SDKs expect to find the node in the response.
+type UntagResourceResult struct{}
+
+type UntagResourceResponse struct {
+	Xmlns    string              `json:"Xmlns" xml:"xmlns,attr"`
+	Result   UntagResourceResult `json:"UntagResourceResult" xml:"UntagResourceResult"`
+	Metadata ResponseMetadata    `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r UntagResourceResponse) GetResult() interface{} {
+	return nil
+}
+
+func (r UntagResourceResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
+
+/*** List Tags For Resource ***/
+type TopicTags struct {
+	Member []TopicTag `json:"Members" xml:"member"`
+}
+
+type ListTagsForResourceResult struct {
+	Tags TopicTags `json:"Tags" xml:"Tags"`
+}
+
+type ListTagsForResourceResponse struct {
+	Xmlns    string                    `json:"Xmlns" xml:"xmlns,attr"`
+	Result   ListTagsForResourceResult `json:"ListTagsForResourceResult" xml:"ListTagsForResourceResult"`
+	Metadata ResponseMetadata          `json:"ResponseMetadata" xml:"ResponseMetadata"`
+}
+
+func (r ListTagsForResourceResponse) GetResult() interface{} {
+	return r.Result
+}
+
+func (r ListTagsForResourceResponse) GetRequestId() string {
+	return r.Metadata.RequestId
+}
@@ -28,6 +28,25 @@ func TestGetQueueAttributesResponse_GetResult(t *testing.T) {
 	assert.Equal(t, expectedAttributes, result)
 }
 
+func TestListQueueTagsResponse_GetResult(t *testing.T) {
+	lqt := ListQueueTagsResponse{
+		Result: ListQueueTagsResult{Tags: []QueueTag{
+			{Key: "tag-key1", Value: "tag-value1"},
+			{Key: "tag-key2", Value: "tag-value2"},
+		}},
+	}
+
+	expectedTags := map[string]map[string]string{
+		"Tags": {
+			"tag-key1": "tag-value1",
+			"tag-key2": "tag-value2",
+		},
+	}
+	result := lqt.GetResult()
+
+	assert.Equal(t, expectedTags, result)
+}
+
 func Test_ResultMessage_MarshalXML_success_with_attributes(t *testing.T) {
 	input := &ResultMessage{
 		MessageId:              "message-id",
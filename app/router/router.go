@@ -27,6 +27,7 @@ func New() http.Handler {
 	r.HandleFunc("/{account}", actionHandler).Methods("GET", "POST")
 	r.HandleFunc("/queue/{queueName}", actionHandler).Methods("GET", "POST")
 	r.HandleFunc("/SimpleNotificationService/{id}.pem", pemHandler).Methods("GET")
+	r.HandleFunc("/SimpleNotificationService/DeliveryLog", deliveryLogHandler).Methods("GET")
 	r.HandleFunc("/{account}/{queueName}", actionHandler).Methods("GET", "POST")
 
 	return r
@@ -64,19 +65,29 @@ func encodeResponse(w http.ResponseWriter, req *http.Request, statusCode int, bo
 // V1 - includes JSON Support (and of course the old XML).
 var routingTableV1 = map[string]func(r *http.Request) (int, interfaces.AbstractResponseBody){
 	// SQS
-	"CreateQueue":             sqs.CreateQueueV1,
-	"ListQueues":              sqs.ListQueuesV1,
-	"GetQueueAttributes":      sqs.GetQueueAttributesV1,
-	"SetQueueAttributes":      sqs.SetQueueAttributesV1,
-	"SendMessage":             sqs.SendMessageV1,
-	"ReceiveMessage":          sqs.ReceiveMessageV1,
-	"ChangeMessageVisibility": sqs.ChangeMessageVisibilityV1,
-	"DeleteMessage":           sqs.DeleteMessageV1,
-	"GetQueueUrl":             sqs.GetQueueUrlV1,
-	"PurgeQueue":              sqs.PurgeQueueV1,
-	"DeleteQueue":             sqs.DeleteQueueV1,
-	"SendMessageBatch":        sqs.SendMessageBatchV1,
-	"DeleteMessageBatch":      sqs.DeleteMessageBatchV1,
+	"CreateQueue":                  sqs.CreateQueueV1,
+	"ListQueues":                   sqs.ListQueuesV1,
+	"GetQueueAttributes":           sqs.GetQueueAttributesV1,
+	"SetQueueAttributes":           sqs.SetQueueAttributesV1,
+	"SendMessage":                  sqs.SendMessageV1,
+	"ReceiveMessage":               sqs.ReceiveMessageV1,
+	"ChangeMessageVisibility":      sqs.ChangeMessageVisibilityV1,
+	"ChangeMessageVisibilityBatch": sqs.ChangeMessageVisibilityBatchV1,
+	"DeleteMessage":                sqs.DeleteMessageV1,
+	"GetQueueUrl":                  sqs.GetQueueUrlV1,
+	"PurgeQueue":                   sqs.PurgeQueueV1,
+	"DeleteQueue":                  sqs.DeleteQueueV1,
+	"SendMessageBatch":             sqs.SendMessageBatchV1,
+	"DeleteMessageBatch":           sqs.DeleteMessageBatchV1,
+	"TagQueue":                     sqs.TagQueueV1,
+	"UntagQueue":                   sqs.UntagQueueV1,
+	"ListQueueTags":                sqs.ListQueueTagsV1,
+	"ListDeadLetterSourceQueues":   sqs.ListDeadLetterSourceQueuesV1,
+	"StartMessageMoveTask":         sqs.StartMessageMoveTaskV1,
+	"ListMessageMoveTasks":         sqs.ListMessageMoveTasksV1,
+	"CancelMessageMoveTask":        sqs.CancelMessageMoveTaskV1,
+	"AddPermission":                sqs.AddPermissionV1,
+	"RemovePermission":             sqs.RemovePermissionV1,
 
 	// SNS
 	"Subscribe":                 sns.SubscribeV1,
@@ -90,6 +101,11 @@ var routingTableV1 = map[string]func(r *http.Request) (int, interfaces.AbstractR
 	"SetSubscriptionAttributes": sns.SetSubscriptionAttributesV1,
 	"ListSubscriptionsByTopic":  sns.ListSubscriptionsByTopicV1,
 	"PublishBatch":              sns.PublishBatchV1,
+	"TagResource":               sns.TagResourceV1,
+	"UntagResource":             sns.UntagResourceV1,
+	"ListTagsForResource":       sns.ListTagsForResourceV1,
+	"GetTopicAttributes":        sns.GetTopicAttributesV1,
+	"SetTopicAttributes":        sns.SetTopicAttributesV1,
 
 	// SNS Internal
 	"ConfirmSubscription": sns.ConfirmSubscriptionV1,
@@ -124,6 +140,23 @@ func pemHandler(w http.ResponseWriter, req *http.Request) {
 	w.Write(sns.PemKEY)
 }
 
+// deliveryLogHandler lists the attempts made to deliver to an HTTP/S subscription, so tests can check what their
+// endpoints were sent, e.g. `GET /SimpleNotificationService/DeliveryLog?SubscriptionArn=...`
+func deliveryLogHandler(w http.ResponseWriter, req *http.Request) {
+	subscriptionArn := req.URL.Query().Get("SubscriptionArn")
+	if subscriptionArn == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, "Bad Request")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err := json.NewEncoder(w).Encode(sns.GetDeliveryLog(subscriptionArn))
+	if err != nil {
+		log.Errorf("Response Encoding Error: %v", err)
+	}
+}
+
 type AwsProtocol int
 
 const (
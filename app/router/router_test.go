@@ -17,6 +17,7 @@ import (
 
 	"github.com/Admiral-Piett/goaws/app/interfaces"
 
+	sns "github.com/Admiral-Piett/goaws/app/gosns"
 	sqs "github.com/Admiral-Piett/goaws/app/gosqs"
 
 	"github.com/stretchr/testify/assert"
@@ -140,6 +141,33 @@ func TestIndexServerhandler_GET_GoodRequest_Pem_cert(t *testing.T) {
 		t.Errorf("handler returned wrong status code: got %v want %v",
 			status, http.StatusOK)
 	}
+	assert.Equal(t, sns.PemKEY, rr.Body.Bytes())
+}
+
+func TestIndexServerhandler_GET_DeliveryLog(t *testing.T) {
+	req, err := http.NewRequest("GET", "/SimpleNotificationService/DeliveryLog?SubscriptionArn=arn:aws:sns:us-east-1:100010001000:local-topic1:sub", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	New().ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, "[]", rr.Body.String())
+}
+
+func TestIndexServerhandler_GET_DeliveryLog_missing_subscription_arn(t *testing.T) {
+	req, err := http.NewRequest("GET", "/SimpleNotificationService/DeliveryLog", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	New().ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
 }
 
 func TestEncodeResponse_success_xml(t *testing.T) {
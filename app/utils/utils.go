@@ -50,12 +50,17 @@ func TransformRequest(resultingStruct interfaces.AbstractRequestBody, req *http.
 			log.Debugf("TransformRequest Failure - %s", err.Error())
 			return false
 		}
-		err = XmlDecoder.Decode(resultingStruct, req.PostForm)
+		values := req.PostForm
+		// The links we hand out to endpoints, like a SubscribeURL, are plain GETs with everything in the query.
+		if req.Method == http.MethodGet {
+			values = req.Form
+		}
+		err = XmlDecoder.Decode(resultingStruct, values)
 		if err != nil {
 			log.Debugf("TransformRequest Failure - %s", err.Error())
 			return false
 		}
-		resultingStruct.SetAttributesFromForm(req.PostForm)
+		resultingStruct.SetAttributesFromForm(values)
 	}
 
 	return true
@@ -146,3 +151,17 @@ func addBytesToHash(hasher hash.Hash, arr []byte) {
 func HasFIFOQueueName(queueName string) bool {
 	return strings.HasSuffix(queueName, ".fifo")
 }
+
+// GetAccessKeyId returns the access key ID that signed the request, from either the SigV4 Authorization header or a
+// presigned URL's X-Amz-Credential.
+// ref: https://docs.aws.amazon.com/IAM/latest/UserGuide/create-signed-request.html
+func GetAccessKeyId(req *http.Request) string {
+	credential := req.URL.Query().Get("X-Amz-Credential")
+	for _, part := range strings.Split(req.Header.Get("Authorization"), ",") {
+		part = strings.TrimSpace(part)
+		if i := strings.Index(part, "Credential="); i >= 0 {
+			credential = part[i+len("Credential="):]
+		}
+	}
+	return strings.Split(credential, "/")[0]
+}
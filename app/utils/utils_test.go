@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"net/http"
 	"net/url"
 	"testing"
 
@@ -58,6 +59,19 @@ func TestTransformRequest_success_xml(t *testing.T) {
 	assert.Equal(t, []interface{}{form}, mock.SetAttributesFromFormCalledWith)
 }
 
+func TestTransformRequest_success_xml_get_query_string(t *testing.T) {
+	_, r := test.GenerateRequestInfo("GET", "/?Action=ConfirmSubscription&TopicArn=topic-arn&Token=token", nil, false)
+
+	mock := &mocks.MockRequestBody{}
+
+	ok := TransformRequest(mock, r, false)
+
+	assert.True(t, ok)
+	assert.True(t, mock.SetAttributesFromFormCalled)
+	expected := url.Values{"Action": {"ConfirmSubscription"}, "TopicArn": {"topic-arn"}, "Token": {"token"}}
+	assert.Equal(t, []interface{}{expected}, mock.SetAttributesFromFormCalledWith)
+}
+
 func TestTransformRequest_error_invalid_request_body_json(t *testing.T) {
 	_, r := test.GenerateRequestInfo("POST", "url", "\"I-am-garbage", true)
 
@@ -113,6 +127,25 @@ func TestExtractQueueAttributes_success(t *testing.T) {
 	assert.Equal(t, expected, attr)
 }
 
+func TestGetAccessKeyId_authorization_header(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost:4100/", nil)
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240101/us-east-1/sqs/aws4_request, SignedHeaders=host;x-amz-date, Signature=abc")
+
+	assert.Equal(t, "AKIDEXAMPLE", GetAccessKeyId(req))
+}
+
+func TestGetAccessKeyId_presigned_url(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost:4100/?X-Amz-Credential=AKIDEXAMPLE%2F20240101%2Fus-east-1%2Fsqs%2Faws4_request", nil)
+
+	assert.Equal(t, "AKIDEXAMPLE", GetAccessKeyId(req))
+}
+
+func TestGetAccessKeyId_unsigned_request(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost:4100/", nil)
+
+	assert.Equal(t, "", GetAccessKeyId(req))
+}
+
 func TestGetMD5Hash(t *testing.T) {
 	hash1 := GetMD5Hash("This is a test")
 	hash2 := GetMD5Hash("This is a test")
@@ -0,0 +1,108 @@
+package smoke_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	"github.com/Admiral-Piett/goaws/app/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ConfirmSubscription_http_lifecycle(t *testing.T) {
+	server := generateServer()
+	defaultEnv := models.CurrentEnvironment
+	conf.LoadYamlConfig("../app/conf/mock-data/mock-config.yaml", "BaseUnitTests")
+
+	received := make(chan models.SNSMessage, 10)
+	subscribedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := models.SNSMessage{}
+		json.NewDecoder(r.Body).Decode(&msg)
+		received <- msg
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer func() {
+		server.Close()
+		subscribedServer.Close()
+		models.ResetResources()
+		models.CurrentEnvironment = defaultEnv
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	snsClient := sns.NewFromConfig(sdkConfig)
+
+	topicArn := models.SyncTopics.Topics["unit-topic2"].Arn
+	subscribeResponse, err := snsClient.Subscribe(context.TODO(), &sns.SubscribeInput{
+		Protocol: aws.String("http"),
+		TopicArn: aws.String(topicArn),
+		Endpoint: aws.String(subscribedServer.URL),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "pending confirmation", *subscribeResponse.SubscriptionArn)
+
+	var confirmation models.SNSMessage
+	select {
+	case confirmation = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the endpoint never received the confirmation")
+	}
+	assert.Equal(t, "SubscriptionConfirmation", confirmation.Type)
+
+	listResponse, err := snsClient.ListSubscriptionsByTopic(context.TODO(), &sns.ListSubscriptionsByTopicInput{
+		TopicArn: aws.String(topicArn),
+	})
+	assert.Nil(t, err)
+	assert.Len(t, listResponse.Subscriptions, 1)
+	assert.Equal(t, "PendingConfirmation", *listResponse.Subscriptions[0].SubscriptionArn)
+
+	// Nothing is delivered until the subscription is confirmed
+	_, err = snsClient.Publish(context.TODO(), &sns.PublishInput{
+		TopicArn: aws.String(topicArn),
+		Message:  aws.String("too early"),
+	})
+	assert.Nil(t, err)
+
+	// Follow the SubscribeURL the way an endpoint would, pointed at the test server
+	query := strings.SplitN(confirmation.SubscribeURL, "?", 2)[1]
+	confirmResponse, err := http.Get(server.URL + "/?" + query)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, confirmResponse.StatusCode)
+	confirmResponse.Body.Close()
+
+	models.SyncTopics.Lock()
+	subscription := models.SyncTopics.Topics["unit-topic2"].Subscriptions[0]
+	subscriptionArn := subscription.SubscriptionArn
+	assert.False(t, subscription.PendingConfirmation)
+	assert.False(t, subscription.ConfirmationWasAuthenticated)
+	models.SyncTopics.Unlock()
+
+	_, err = snsClient.Publish(context.TODO(), &sns.PublishInput{
+		TopicArn: aws.String(topicArn),
+		Message:  aws.String("just right"),
+	})
+	assert.Nil(t, err)
+
+	waitForDeliveries(t, server, subscriptionArn, 1)
+	notification := <-received
+	assert.Equal(t, "Notification", notification.Type)
+	assert.Equal(t, "just right", notification.Message)
+	assert.Len(t, received, 0)
+
+	// The token can't be used twice
+	_, err = snsClient.ConfirmSubscription(context.TODO(), &sns.ConfirmSubscriptionInput{
+		TopicArn: aws.String(topicArn),
+		Token:    aws.String(confirmation.Token),
+	})
+	assert.NotNil(t, err)
+}
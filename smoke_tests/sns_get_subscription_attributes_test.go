@@ -220,6 +220,10 @@ func Test_GetSubscriptionAttributes_xml_success(t *testing.T) {
 			Key:   "FilterPolicy",
 			Value: "null",
 		},
+		{
+			Key:   "FilterPolicyScope",
+			Value: "MessageAttributes",
+		},
 	}
 
 	assert.ElementsMatch(t, expectedAttributes, getSubscriptionAttributesResponse.Result.Attributes.Entries)
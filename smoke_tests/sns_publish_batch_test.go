@@ -63,8 +63,9 @@ func Test_Publish_batch_sqs_json_raw(t *testing.T) {
 	assert.Len(t, response.Successful, 2)
 
 	receivedMessage, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl:            &models.SyncQueues.Queues["subscribed-queue1"].URL,
-		MaxNumberOfMessages: 3,
+		QueueUrl:              &models.SyncQueues.Queues["subscribed-queue1"].URL,
+		MessageAttributeNames: []string{"All"},
+		MaxNumberOfMessages:   3,
 	})
 
 	assert.Equal(t, 2, len(receivedMessage.Messages))
@@ -72,14 +73,14 @@ func Test_Publish_batch_sqs_json_raw(t *testing.T) {
 
 	assert.Equal(t, message, *receivedMessage.Messages[0].Body)
 	assert.Equal(t, "649b2c548f103e499304eda4d6d4c5a2", *receivedMessage.Messages[0].MD5OfBody)
-	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", *receivedMessage.Messages[0].MD5OfMessageAttributes)
+	assert.Nil(t, receivedMessage.Messages[0].MD5OfMessageAttributes)
 	assert.Len(t, receivedMessage.Messages[0].MessageAttributes, 0)
 	assert.NotNil(t, receivedMessage.Messages[0].MessageId)
 	assert.NotNil(t, receivedMessage.Messages[0].ReceiptHandle)
 
 	assert.Equal(t, message, *receivedMessage.Messages[1].Body)
 	assert.Equal(t, "649b2c548f103e499304eda4d6d4c5a2", *receivedMessage.Messages[1].MD5OfBody)
-	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", *receivedMessage.Messages[1].MD5OfMessageAttributes)
+	assert.Nil(t, receivedMessage.Messages[1].MD5OfMessageAttributes)
 	assert.Len(t, receivedMessage.Messages[1].MessageAttributes, 0)
 	assert.NotNil(t, receivedMessage.Messages[1].MessageId)
 	assert.NotNil(t, receivedMessage.Messages[1].ReceiptHandle)
@@ -180,8 +181,9 @@ func Test_Publish_batch_sqs_json_not_raw(t *testing.T) {
 	assert.Len(t, response.Successful, 2)
 
 	receivedMessage, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl:            &models.SyncQueues.Queues["subscribed-queue3"].URL,
-		MaxNumberOfMessages: 3,
+		QueueUrl:              &models.SyncQueues.Queues["subscribed-queue3"].URL,
+		MessageAttributeNames: []string{"All"},
+		MaxNumberOfMessages:   3,
 	})
 
 	assert.Equal(t, 2, len(receivedMessage.Messages))
@@ -269,8 +271,9 @@ func Test_Publish_batch_sqs_json_raw_with_optional_fields(t *testing.T) {
 	assert.Len(t, response.Successful, 2)
 
 	receivedMessage, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl:            &models.SyncQueues.Queues["subscribed-queue1"].URL,
-		MaxNumberOfMessages: 3,
+		QueueUrl:              &models.SyncQueues.Queues["subscribed-queue1"].URL,
+		MessageAttributeNames: []string{"All"},
+		MaxNumberOfMessages:   3,
 	})
 
 	assert.Len(t, receivedMessage.Messages, 2)
@@ -285,7 +288,7 @@ func Test_Publish_batch_sqs_json_raw_with_optional_fields(t *testing.T) {
 
 	assert.Equal(t, message, *receivedMessage.Messages[1].Body)
 	assert.Equal(t, "649b2c548f103e499304eda4d6d4c5a2", *receivedMessage.Messages[1].MD5OfBody)
-	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", *receivedMessage.Messages[1].MD5OfMessageAttributes)
+	assert.Nil(t, receivedMessage.Messages[1].MD5OfMessageAttributes)
 	assert.Len(t, receivedMessage.Messages[1].MessageAttributes, 0)
 	assert.NotNil(t, receivedMessage.Messages[1].MessageId)
 	assert.NotNil(t, receivedMessage.Messages[1].ReceiptHandle)
@@ -340,8 +343,9 @@ func Test_Publish_batch_sqs_json_not_raw_with_optional_fields(t *testing.T) {
 	assert.Len(t, response.Successful, 2)
 
 	receivedMessage, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl:            &models.SyncQueues.Queues["subscribed-queue3"].URL,
-		MaxNumberOfMessages: 3,
+		QueueUrl:              &models.SyncQueues.Queues["subscribed-queue3"].URL,
+		MessageAttributeNames: []string{"All"},
+		MaxNumberOfMessages:   3,
 	})
 
 	assert.Len(t, receivedMessage.Messages, 2)
@@ -432,6 +436,7 @@ func Test_Publish_batch_http_json(t *testing.T) {
 	assert.Len(t, response.Failed, 0)
 	assert.Len(t, response.Successful, 2)
 
+	waitForDeliveries(t, server, models.SyncTopics.Topics["unit-topic-http"].Subscriptions[0].SubscriptionArn, 2)
 	assert.True(t, called)
 	assert.Equal(t, "\"{\\\"IAm\\\": \\\"aMessage\\\"}\"", httpMessage)
 }
@@ -490,6 +495,7 @@ func Test_Publish_batch_https_json_raw(t *testing.T) {
 	assert.Len(t, response.Failed, 0)
 	assert.Len(t, response.Successful, 2)
 
+	waitForDeliveries(t, server, models.SyncTopics.Topics["unit-topic-http"].Subscriptions[0].SubscriptionArn, 2)
 	assert.True(t, called)
 	assert.Equal(t, "\"{\\\"IAm\\\": \\\"aMessage\\\"}\"", httpMessage)
 }
@@ -551,6 +557,7 @@ func Test_Publish_batch_https_json_not_raw(t *testing.T) {
 	assert.Len(t, response.Failed, 0)
 	assert.Len(t, response.Successful, 2)
 
+	waitForDeliveries(t, server, models.SyncTopics.Topics["unit-topic-http"].Subscriptions[0].SubscriptionArn, 2)
 	assert.True(t, called)
 	assert.Contains(t, httpMessage, "\"Message\":\"{\\\"IAm\\\": \\\"aMessage\\\"}\"")
 	assert.Contains(t, httpMessage, "Type")
@@ -627,8 +634,9 @@ func Test_Publish_batch_sqs_xml_raw(t *testing.T) {
 		Body().Raw()
 
 	receivedMessage, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl:            &models.SyncQueues.Queues["subscribed-queue1"].URL,
-		MaxNumberOfMessages: 3,
+		QueueUrl:              &models.SyncQueues.Queues["subscribed-queue1"].URL,
+		MessageAttributeNames: []string{"All"},
+		MaxNumberOfMessages:   3,
 	})
 
 	assert.Equal(t, 2, len(receivedMessage.Messages))
@@ -636,14 +644,14 @@ func Test_Publish_batch_sqs_xml_raw(t *testing.T) {
 
 	assert.Equal(t, message, *receivedMessage.Messages[0].Body)
 	assert.Equal(t, "649b2c548f103e499304eda4d6d4c5a2", *receivedMessage.Messages[0].MD5OfBody)
-	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", *receivedMessage.Messages[0].MD5OfMessageAttributes)
+	assert.Nil(t, receivedMessage.Messages[0].MD5OfMessageAttributes)
 	assert.Len(t, receivedMessage.Messages[0].MessageAttributes, 0)
 	assert.NotNil(t, receivedMessage.Messages[0].MessageId)
 	assert.NotNil(t, receivedMessage.Messages[0].ReceiptHandle)
 
 	assert.Equal(t, message, *receivedMessage.Messages[1].Body)
 	assert.Equal(t, "649b2c548f103e499304eda4d6d4c5a2", *receivedMessage.Messages[1].MD5OfBody)
-	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", *receivedMessage.Messages[1].MD5OfMessageAttributes)
+	assert.Nil(t, receivedMessage.Messages[1].MD5OfMessageAttributes)
 	assert.Len(t, receivedMessage.Messages[1].MessageAttributes, 0)
 	assert.NotNil(t, receivedMessage.Messages[1].MessageId)
 	assert.NotNil(t, receivedMessage.Messages[1].ReceiptHandle)
@@ -715,8 +723,9 @@ func Test_Publish_batch_sqs_xml_not_raw(t *testing.T) {
 		Body().Raw()
 
 	receivedMessage, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl:            &models.SyncQueues.Queues["subscribed-queue3"].URL,
-		MaxNumberOfMessages: 3,
+		QueueUrl:              &models.SyncQueues.Queues["subscribed-queue3"].URL,
+		MessageAttributeNames: []string{"All"},
+		MaxNumberOfMessages:   3,
 	})
 
 	assert.Equal(t, 2, len(receivedMessage.Messages))
@@ -833,6 +842,7 @@ func Test_Publish_batch_http_xml(t *testing.T) {
 		Status(http.StatusOK).
 		Body().Raw()
 
+	waitForDeliveries(t, server, models.SyncTopics.Topics["unit-topic-http"].Subscriptions[0].SubscriptionArn, 2)
 	assert.True(t, called)
 	assert.Equal(t, "\"{\\\"IAm\\\": \\\"aMessage\\\"}\"", httpMessage)
 }
@@ -916,6 +926,7 @@ func Test_Publish_batch_https_xml_raw(t *testing.T) {
 		Status(http.StatusOK).
 		Body().Raw()
 
+	waitForDeliveries(t, server, models.SyncTopics.Topics["unit-topic-http"].Subscriptions[0].SubscriptionArn, 2)
 	assert.True(t, called)
 	assert.Equal(t, "\"{\\\"IAm\\\": \\\"aMessage\\\"}\"", httpMessage)
 }
@@ -1000,6 +1011,7 @@ func Test_Publish_batch_https_xml_not_raw(t *testing.T) {
 		Status(http.StatusOK).
 		Body().Raw()
 
+	waitForDeliveries(t, server, models.SyncTopics.Topics["unit-topic-http"].Subscriptions[0].SubscriptionArn, 2)
 	assert.True(t, called)
 	assert.Contains(t, httpMessage, "\"Message\":\"{\\\"IAm\\\": \\\"aMessage\\\"}\"")
 	assert.Contains(t, httpMessage, "Type")
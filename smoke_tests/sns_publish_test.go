@@ -17,6 +17,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 
 	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 
@@ -57,13 +58,14 @@ func Test_Publish_sqs_json_raw(t *testing.T) {
 	assert.NotNil(t, response)
 
 	receivedMessage, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl: &models.SyncQueues.Queues["subscribed-queue1"].URL,
+		QueueUrl:              &models.SyncQueues.Queues["subscribed-queue1"].URL,
+		MessageAttributeNames: []string{"All"},
 	})
 
 	assert.Len(t, receivedMessage.Messages, 1)
 	assert.Equal(t, 0, len(receivedMessage.Messages[0].MessageAttributes))
 	assert.Equal(t, "649b2c548f103e499304eda4d6d4c5a2", *receivedMessage.Messages[0].MD5OfBody)
-	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", *receivedMessage.Messages[0].MD5OfMessageAttributes)
+	assert.Nil(t, receivedMessage.Messages[0].MD5OfMessageAttributes)
 	assert.Equal(t, message, *receivedMessage.Messages[0].Body)
 }
 
@@ -133,7 +135,8 @@ func Test_Publish_sqs_json_with_message_attributes_raw(t *testing.T) {
 	})
 
 	receiveMessageResponse, receiveErr := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl: createQueueResult.QueueUrl,
+		QueueUrl:              createQueueResult.QueueUrl,
+		MessageAttributeNames: []string{"All"},
 	})
 
 	assert.Nil(t, publishErr)
@@ -249,7 +252,8 @@ func Test_Publish_sqs_json_not_raw(t *testing.T) {
 	assert.NotNil(t, response)
 
 	receivedMessage, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl: &models.SyncQueues.Queues["subscribed-queue3"].URL,
+		QueueUrl:              &models.SyncQueues.Queues["subscribed-queue3"].URL,
+		MessageAttributeNames: []string{"All"},
 	})
 
 	assert.Len(t, receivedMessage.Messages, 1)
@@ -308,7 +312,8 @@ func Test_Publish_sqs_json_raw_optional_fields(t *testing.T) {
 	assert.NotNil(t, response)
 
 	receivedMessages, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl: &models.SyncQueues.Queues["subscribed-queue1"].URL,
+		QueueUrl:              &models.SyncQueues.Queues["subscribed-queue1"].URL,
+		MessageAttributeNames: []string{"All"},
 	})
 
 	assert.Len(t, receivedMessages.Messages, 1)
@@ -364,7 +369,8 @@ func Test_Publish_sqs_json_not_raw_optional_fields(t *testing.T) {
 	assert.NotNil(t, response)
 
 	receivedMessage, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl: &models.SyncQueues.Queues["subscribed-queue3"].URL,
+		QueueUrl:              &models.SyncQueues.Queues["subscribed-queue3"].URL,
+		MessageAttributeNames: []string{"All"},
 	})
 
 	assert.Len(t, receivedMessage.Messages, 1)
@@ -426,6 +432,7 @@ func Test_Publish_http_json(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, response)
 
+	waitForDeliveries(t, server, models.SyncTopics.Topics["unit-topic-http"].Subscriptions[0].SubscriptionArn, 1)
 	assert.True(t, called)
 	assert.Equal(t, "\"{\\\"IAm\\\": \\\"aMessage\\\"}\"", httpMessage)
 }
@@ -472,6 +479,7 @@ func Test_Publish_https_json_raw(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, response)
 
+	waitForDeliveries(t, server, models.SyncTopics.Topics["unit-topic-http"].Subscriptions[0].SubscriptionArn, 1)
 	assert.True(t, called)
 	assert.Equal(t, "\"{\\\"IAm\\\": \\\"aMessage\\\"}\"", httpMessage)
 }
@@ -521,6 +529,7 @@ func Test_Publish_https_json_not_raw(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, response)
 
+	waitForDeliveries(t, server, models.SyncTopics.Topics["unit-topic-http"].Subscriptions[0].SubscriptionArn, 1)
 	assert.True(t, called)
 	assert.Contains(t, httpMessage, "\"Message\":\"{\\\"IAm\\\": \\\"aMessage\\\"}\"")
 	assert.Contains(t, httpMessage, "Type")
@@ -571,8 +580,9 @@ func Test_Publish_sqs_xml_raw(t *testing.T) {
 		Body().Raw()
 
 	receivedMessage, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl:            &models.SyncQueues.Queues["subscribed-queue1"].URL,
-		MaxNumberOfMessages: 3,
+		QueueUrl:              &models.SyncQueues.Queues["subscribed-queue1"].URL,
+		MessageAttributeNames: []string{"All"},
+		MaxNumberOfMessages:   3,
 	})
 
 	assert.Equal(t, 1, len(receivedMessage.Messages))
@@ -580,12 +590,56 @@ func Test_Publish_sqs_xml_raw(t *testing.T) {
 
 	assert.Equal(t, message, *receivedMessage.Messages[0].Body)
 	assert.Equal(t, "649b2c548f103e499304eda4d6d4c5a2", *receivedMessage.Messages[0].MD5OfBody)
-	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", *receivedMessage.Messages[0].MD5OfMessageAttributes)
+	assert.Nil(t, receivedMessage.Messages[0].MD5OfMessageAttributes)
 	assert.Len(t, receivedMessage.Messages[0].MessageAttributes, 0)
 	assert.NotNil(t, receivedMessage.Messages[0].MessageId)
 	assert.NotNil(t, receivedMessage.Messages[0].ReceiptHandle)
 }
 
+func Test_Publish_sqs_xml_passes_trace_header(t *testing.T) {
+	server := generateServer()
+	defaultEnv := models.CurrentEnvironment
+	conf.LoadYamlConfig("../app/conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	defer func() {
+		server.Close()
+		models.ResetResources()
+		models.CurrentEnvironment = defaultEnv
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	e := httpexpect.Default(t, server.URL)
+
+	traceHeader := "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1"
+	requestBody := struct {
+		Action   string `schema:"Action"`
+		TopicArn string `schema:"TopicArn"`
+		Message  string `schema:"Message"`
+	}{
+		Action:   "Publish",
+		TopicArn: models.SyncTopics.Topics["unit-topic1"].Arn,
+		Message:  "{\"IAm\": \"aMessage\"}",
+	}
+
+	e.POST("/").
+		WithHeader("X-Amzn-Trace-Id", traceHeader).
+		WithForm(requestBody).
+		Expect().
+		Status(http.StatusOK).
+		Body().Raw()
+
+	receivedMessage, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+		QueueUrl:       &models.SyncQueues.Queues["subscribed-queue1"].URL,
+		AttributeNames: []sqstypes.QueueAttributeName{"AWSTraceHeader"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(receivedMessage.Messages))
+	assert.Equal(t, traceHeader, receivedMessage.Messages[0].Attributes["AWSTraceHeader"])
+}
+
 func Test_Publish_sqs_xml_not_raw(t *testing.T) {
 	server := generateServer()
 	defaultEnv := models.CurrentEnvironment
@@ -625,8 +679,9 @@ func Test_Publish_sqs_xml_not_raw(t *testing.T) {
 		Body().Raw()
 
 	receivedMessage, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl:            &models.SyncQueues.Queues["subscribed-queue3"].URL,
-		MaxNumberOfMessages: 3,
+		QueueUrl:              &models.SyncQueues.Queues["subscribed-queue3"].URL,
+		MessageAttributeNames: []string{"All"},
+		MaxNumberOfMessages:   3,
 	})
 
 	assert.Equal(t, 1, len(receivedMessage.Messages))
@@ -741,6 +796,7 @@ func Test_Publish_http_xml(t *testing.T) {
 		Status(http.StatusOK).
 		Body().Raw()
 
+	waitForDeliveries(t, server, models.SyncTopics.Topics["unit-topic-http"].Subscriptions[0].SubscriptionArn, 1)
 	assert.True(t, called)
 	assert.Equal(t, "\"{\\\"IAm\\\": \\\"aMessage\\\"}\"", httpMessage)
 }
@@ -797,6 +853,7 @@ func Test_Publish_https_xml_raw(t *testing.T) {
 		Status(http.StatusOK).
 		Body().Raw()
 
+	waitForDeliveries(t, server, models.SyncTopics.Topics["unit-topic-http"].Subscriptions[0].SubscriptionArn, 1)
 	assert.True(t, called)
 	assert.Equal(t, "\"{\\\"IAm\\\": \\\"aMessage\\\"}\"", httpMessage)
 }
@@ -854,6 +911,7 @@ func Test_Publish_https_xml_not_raw(t *testing.T) {
 		Status(http.StatusOK).
 		Body().Raw()
 
+	waitForDeliveries(t, server, models.SyncTopics.Topics["unit-topic-http"].Subscriptions[0].SubscriptionArn, 1)
 	assert.True(t, called)
 	assert.Contains(t, httpMessage, "\"Message\":\"{\\\"IAm\\\": \\\"aMessage\\\"}\"")
 	assert.Contains(t, httpMessage, "Type")
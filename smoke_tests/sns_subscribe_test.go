@@ -143,7 +143,7 @@ func Test_Subscribe_json_with_additional_fields(t *testing.T) {
 	subscriptions := models.SyncTopics.Topics["unit-topic2"].Subscriptions
 	assert.Len(t, subscriptions, 1)
 
-	expectedFilterPolicy := models.FilterPolicy{"filter": []string{"policy"}}
+	expectedFilterPolicy := models.FilterPolicy{"filter": []interface{}{"policy"}}
 	assert.Equal(t, fmt.Sprintf("%s:%s", af.BASE_SQS_ARN, "unit-queue2"), subscriptions[0].EndPoint)
 	assert.Equal(t, &expectedFilterPolicy, subscriptions[0].FilterPolicy)
 	assert.Equal(t, "sqs", subscriptions[0].Protocol)
@@ -192,7 +192,7 @@ func Test_Subscribe_xml(t *testing.T) {
 	subscriptions := models.SyncTopics.Topics["unit-topic2"].Subscriptions
 	assert.Len(t, subscriptions, 1)
 
-	expectedFilterPolicy := models.FilterPolicy{"filter": []string{"policy"}}
+	expectedFilterPolicy := models.FilterPolicy{"filter": []interface{}{"policy"}}
 	assert.Equal(t, fmt.Sprintf("%s:%s", af.BASE_SQS_ARN, "unit-queue2"), subscriptions[0].EndPoint)
 	assert.Equal(t, &expectedFilterPolicy, subscriptions[0].FilterPolicy)
 	assert.Equal(t, "sqs", subscriptions[0].Protocol)
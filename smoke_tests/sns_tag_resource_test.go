@@ -0,0 +1,115 @@
+package smoke_tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/gavv/httpexpect/v2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TagResource_UntagResource_ListTagsForResource_success(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	snsClient := sns.NewFromConfig(sdkConfig)
+
+	createResp, _ := snsClient.CreateTopic(context.TODO(), &sns.CreateTopicInput{
+		Name: aws.String("new-topic-1"),
+		Tags: []types.Tag{{Key: aws.String("team"), Value: aws.String("platform")}},
+	})
+
+	_, err := snsClient.TagResource(context.TODO(), &sns.TagResourceInput{
+		ResourceArn: createResp.TopicArn,
+		Tags: []types.Tag{
+			{Key: aws.String("env"), Value: aws.String("dev")},
+			{Key: aws.String("owner"), Value: aws.String("me")},
+		},
+	})
+	assert.Nil(t, err)
+
+	_, err = snsClient.UntagResource(context.TODO(), &sns.UntagResourceInput{
+		ResourceArn: createResp.TopicArn,
+		TagKeys:     []string{"owner"},
+	})
+	assert.Nil(t, err)
+
+	listResp, err := snsClient.ListTagsForResource(context.TODO(), &sns.ListTagsForResourceInput{
+		ResourceArn: createResp.TopicArn,
+	})
+	assert.Nil(t, err)
+
+	expectedTags := []types.Tag{
+		{Key: aws.String("env"), Value: aws.String("dev")},
+		{Key: aws.String("team"), Value: aws.String("platform")},
+	}
+	assert.Equal(t, expectedTags, listResp.Tags)
+}
+
+func Test_TagResource_topic_not_found(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	snsClient := sns.NewFromConfig(sdkConfig)
+
+	_, err := snsClient.TagResource(context.TODO(), &sns.TagResourceInput{
+		ResourceArn: aws.String("arn:aws:sns:us-east-1:100010001000:garbage"),
+		Tags:        []types.Tag{{Key: aws.String("env"), Value: aws.String("dev")}},
+	})
+	assert.Contains(t, err.Error(), "404")
+	assert.Contains(t, err.Error(), "AWS.SimpleNotificationService.ResourceNotFound")
+}
+
+func Test_ListTagsForResource_xml_success(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	e := httpexpect.Default(t, server.URL)
+
+	e.POST("/").
+		WithForm(struct {
+			Action string `xml:"Action"`
+			Name   string `xml:"Name"`
+		}{
+			Action: "CreateTopic",
+			Name:   "new-topic-1",
+		}).
+		WithFormField("Tags.member.1.Key", "team").
+		WithFormField("Tags.member.1.Value", "platform").
+		Expect().
+		Status(http.StatusOK)
+
+	r := e.POST("/").
+		WithForm(struct {
+			Action      string `schema:"Action"`
+			ResourceArn string `schema:"ResourceArn"`
+		}{
+			Action:      "ListTagsForResource",
+			ResourceArn: "arn:aws:sns:us-east-1:100010001000:new-topic-1",
+		}).
+		Expect().
+		Status(http.StatusOK).
+		Body().Raw()
+
+	assert.Contains(t, r, "<ListTagsForResourceResult><Tags><member><Key>team</Key><Value>platform</Value></member></Tags></ListTagsForResourceResult>")
+}
@@ -0,0 +1,68 @@
+package smoke_tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetTopicAttributes_SetTopicAttributes_success(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	snsClient := sns.NewFromConfig(sdkConfig)
+
+	createResp, _ := snsClient.CreateTopic(context.TODO(), &sns.CreateTopicInput{
+		Name:       aws.String("new-topic-1"),
+		Attributes: map[string]string{"DisplayName": "first-name", "TracingConfig": "PassThrough"},
+	})
+
+	_, err := snsClient.SetTopicAttributes(context.TODO(), &sns.SetTopicAttributesInput{
+		TopicArn:       createResp.TopicArn,
+		AttributeName:  aws.String("DisplayName"),
+		AttributeValue: aws.String("second-name"),
+	})
+	assert.Nil(t, err)
+
+	getResp, err := snsClient.GetTopicAttributes(context.TODO(), &sns.GetTopicAttributesInput{
+		TopicArn: createResp.TopicArn,
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "second-name", getResp.Attributes["DisplayName"])
+	assert.Equal(t, "PassThrough", getResp.Attributes["TracingConfig"])
+	assert.Equal(t, *createResp.TopicArn, getResp.Attributes["TopicArn"])
+	assert.Equal(t, models.CurrentEnvironment.AccountID, getResp.Attributes["Owner"])
+	assert.Equal(t, "0", getResp.Attributes["SubscriptionsConfirmed"])
+	assert.Equal(t, "0", getResp.Attributes["SubscriptionsPending"])
+	assert.Equal(t, "0", getResp.Attributes["SubscriptionsDeleted"])
+	assert.Equal(t, models.DefaultEffectiveDeliveryPolicy, getResp.Attributes["EffectiveDeliveryPolicy"])
+}
+
+func Test_GetTopicAttributes_topic_not_found(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	snsClient := sns.NewFromConfig(sdkConfig)
+
+	_, err := snsClient.GetTopicAttributes(context.TODO(), &sns.GetTopicAttributesInput{
+		TopicArn: aws.String("arn:aws:sns:us-east-1:100010001000:garbage"),
+	})
+	assert.Contains(t, err.Error(), "AWS.SimpleNotificationService.NonExistentTopic")
+}
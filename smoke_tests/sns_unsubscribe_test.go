@@ -2,8 +2,12 @@ package smoke_tests
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Admiral-Piett/goaws/app/models"
 
@@ -76,3 +80,77 @@ func Test_Unsubscribe_xml(t *testing.T) {
 	subscriptions := models.SyncTopics.Topics["unit-topic1"].Subscriptions
 	assert.Len(t, subscriptions, 0)
 }
+
+func Test_Unsubscribe_http_unsubscribe_url(t *testing.T) {
+	server := generateServer()
+	defaultEnv := models.CurrentEnvironment
+	conf.LoadYamlConfig("../app/conf/mock-data/mock-config.yaml", "BaseUnitTests")
+
+	received := make(chan models.SNSMessage, 10)
+	subscribedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := models.SNSMessage{}
+		json.NewDecoder(r.Body).Decode(&msg)
+		received <- msg
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer func() {
+		server.Close()
+		subscribedServer.Close()
+		models.ResetResources()
+		models.CurrentEnvironment = defaultEnv
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	snsClient := sns.NewFromConfig(sdkConfig)
+
+	models.SyncTopics.Lock()
+	topic := models.SyncTopics.Topics["unit-topic1"]
+	subscription := topic.Subscriptions[0]
+	subscription.Protocol = "http"
+	subscription.EndPoint = subscribedServer.URL
+	subscription.Raw = false
+	models.SyncTopics.Unlock()
+
+	_, err := snsClient.Publish(context.TODO(), &sns.PublishInput{
+		TopicArn: aws.String(topic.Arn),
+		Message:  aws.String("hello"),
+	})
+	assert.Nil(t, err)
+
+	waitForDeliveries(t, server, subscription.SubscriptionArn, 1)
+	notification := <-received
+	assert.Equal(t, "Notification", notification.Type)
+
+	// Follow the UnsubscribeURL the way a browser would, pointed at the test server
+	query := strings.SplitN(notification.UnsubscribeURL, "?", 2)[1]
+	unsubscribeResponse, err := http.Get(server.URL + "/?" + query)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, unsubscribeResponse.StatusCode)
+	unsubscribeResponse.Body.Close()
+
+	var confirmation models.SNSMessage
+	select {
+	case confirmation = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the endpoint never received the unsubscribe confirmation")
+	}
+	assert.Equal(t, "UnsubscribeConfirmation", confirmation.Type)
+	assert.NotEmpty(t, confirmation.Signature)
+
+	models.SyncTopics.Lock()
+	assert.Len(t, topic.Subscriptions, 0)
+	models.SyncTopics.Unlock()
+
+	// The SubscribeURL in the confirmation puts the subscription back
+	query = strings.SplitN(confirmation.SubscribeURL, "?", 2)[1]
+	resubscribeResponse, err := http.Get(server.URL + "/?" + query)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resubscribeResponse.StatusCode)
+	resubscribeResponse.Body.Close()
+
+	models.SyncTopics.Lock()
+	defer models.SyncTopics.Unlock()
+	assert.Equal(t, []*models.Subscription{subscription}, topic.Subscriptions)
+}
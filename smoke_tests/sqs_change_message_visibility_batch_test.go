@@ -0,0 +1,169 @@
+package smoke_tests
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/models"
+
+	af "github.com/Admiral-Piett/goaws/app/fixtures"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ChangeMessageVisibilityBatchV1_json(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	createQueueResponse, _ := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &af.QueueName,
+	})
+	for _, body := range []string{"message 1", "message 2"} {
+		_, err := sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+			QueueUrl:    createQueueResponse.QueueUrl,
+			MessageBody: aws.String(body),
+		})
+		assert.Nil(t, err)
+	}
+
+	receiveMessageResponse, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+		QueueUrl:            createQueueResponse.QueueUrl,
+		MaxNumberOfMessages: 10,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, receiveMessageResponse.Messages, 2)
+
+	response, err := sqsClient.ChangeMessageVisibilityBatch(context.TODO(), &sqs.ChangeMessageVisibilityBatchInput{
+		QueueUrl: createQueueResponse.QueueUrl,
+		Entries: []types.ChangeMessageVisibilityBatchRequestEntry{
+			{
+				Id:                aws.String("release"),
+				ReceiptHandle:     receiveMessageResponse.Messages[0].ReceiptHandle,
+				VisibilityTimeout: 0,
+			},
+			{
+				Id:                aws.String("extend"),
+				ReceiptHandle:     receiveMessageResponse.Messages[1].ReceiptHandle,
+				VisibilityTimeout: 600,
+			},
+			{
+				Id:                aws.String("unknown"),
+				ReceiptHandle:     aws.String("garbage"),
+				VisibilityTimeout: 600,
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	assert.Len(t, response.Successful, 2)
+	assert.Equal(t, "release", *response.Successful[0].Id)
+	assert.Equal(t, "extend", *response.Successful[1].Id)
+	assert.Len(t, response.Failed, 1)
+	assert.Equal(t, "unknown", *response.Failed[0].Id)
+	assert.Equal(t, "AWS.SimpleQueueService.MessageNotInFlight", *response.Failed[0].Code)
+	assert.True(t, response.Failed[0].SenderFault)
+
+	// Only the released message can be received again
+	receiveMessageResponse2, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+		QueueUrl:            createQueueResponse.QueueUrl,
+		MaxNumberOfMessages: 10,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, receiveMessageResponse2.Messages, 1)
+	assert.Equal(t, "message 1", *receiveMessageResponse2.Messages[0].Body)
+}
+
+func Test_ChangeMessageVisibilityBatchV1_json_error_no_entry(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	createQueueResponse, _ := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &af.QueueName,
+	})
+
+	_, err := sqsClient.ChangeMessageVisibilityBatch(context.TODO(), &sqs.ChangeMessageVisibilityBatchInput{
+		QueueUrl: createQueueResponse.QueueUrl,
+		Entries:  []types.ChangeMessageVisibilityBatchRequestEntry{},
+	})
+
+	assert.Contains(t, err.Error(), "400")
+	assert.Contains(t, err.Error(), "AWS.SimpleQueueService.EmptyBatchRequest")
+}
+
+func Test_ChangeMessageVisibilityBatchV1_xml(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	e := httpexpect.Default(t, server.URL)
+
+	createQueueResponse, _ := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &af.QueueName,
+	})
+	_, err := sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+		QueueUrl:    createQueueResponse.QueueUrl,
+		MessageBody: aws.String("message 1"),
+	})
+	assert.Nil(t, err)
+
+	receiveMessageResponse, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+		QueueUrl: createQueueResponse.QueueUrl,
+	})
+	assert.Nil(t, err)
+
+	requestBody := struct {
+		Action   string `xml:"Action"`
+		QueueUrl string `xml:"QueueUrl"`
+		Version  string `xml:"Version"`
+	}{
+		Action:   "ChangeMessageVisibilityBatch",
+		QueueUrl: *createQueueResponse.QueueUrl,
+		Version:  "2012-11-05",
+	}
+
+	body := e.POST("/").
+		WithForm(requestBody).
+		WithFormField("ChangeMessageVisibilityBatchRequestEntry.1.Id", "extend").
+		WithFormField("ChangeMessageVisibilityBatchRequestEntry.1.ReceiptHandle", *receiveMessageResponse.Messages[0].ReceiptHandle).
+		WithFormField("ChangeMessageVisibilityBatchRequestEntry.1.VisibilityTimeout", "600").
+		WithFormField("ChangeMessageVisibilityBatchRequestEntry.2.Id", "too-long").
+		WithFormField("ChangeMessageVisibilityBatchRequestEntry.2.ReceiptHandle", *receiveMessageResponse.Messages[0].ReceiptHandle).
+		WithFormField("ChangeMessageVisibilityBatchRequestEntry.2.VisibilityTimeout", "43201").
+		Expect().
+		Status(http.StatusOK).
+		Body().Raw()
+
+	response := models.ChangeMessageVisibilityBatchResponse{}
+	xml.Unmarshal([]byte(body), &response)
+
+	assert.Equal(t, []models.ChangeMessageVisibilityBatchResultEntry{{Id: "extend"}}, response.Result.Successful)
+	assert.Len(t, response.Result.Failed, 1)
+	assert.Equal(t, "too-long", response.Result.Failed[0].Id)
+	assert.Equal(t, "AWS.SimpleQueueService.ValidationError", response.Result.Failed[0].Code)
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"testing"
 
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
 	"github.com/Admiral-Piett/goaws/app/models"
 
 	af "github.com/Admiral-Piett/goaws/app/fixtures"
@@ -95,3 +97,59 @@ func Test_ChangeMessageVisibilityV1_xml(t *testing.T) {
 		Status(http.StatusOK).
 		Body().Raw()
 }
+
+func Test_ChangeMessageVisibilityV1_json_dead_letters_after_max_receive_count(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	dlqResponse, _ := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: aws.String("dead-letter-queue"),
+	})
+	createQueueResponse, err := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &af.QueueName,
+		Attributes: map[string]string{
+			"RedrivePolicy": fmt.Sprintf(`{"maxReceiveCount":"2","deadLetterTargetArn":"%s:dead-letter-queue"}`, af.BASE_SQS_ARN),
+		},
+	})
+	assert.Nil(t, err)
+
+	_, err = sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+		QueueUrl:    createQueueResponse.QueueUrl,
+		MessageBody: aws.String("poison"),
+	})
+	assert.Nil(t, err)
+
+	for _, receiveCount := range []string{"1", "2"} {
+		receiveMessageResponse, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+			QueueUrl:       createQueueResponse.QueueUrl,
+			AttributeNames: []sqstypes.QueueAttributeName{"ApproximateReceiveCount"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(receiveMessageResponse.Messages))
+		assert.Equal(t, receiveCount, receiveMessageResponse.Messages[0].Attributes["ApproximateReceiveCount"])
+
+		_, err = sqsClient.ChangeMessageVisibility(context.TODO(), &sqs.ChangeMessageVisibilityInput{
+			QueueUrl:          createQueueResponse.QueueUrl,
+			ReceiptHandle:     receiveMessageResponse.Messages[0].ReceiptHandle,
+			VisibilityTimeout: 0,
+		})
+		assert.Nil(t, err)
+	}
+
+	assert.Len(t, models.SyncQueues.Queues[af.QueueName].Messages, 0)
+	dlqMessages, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+		QueueUrl:       dlqResponse.QueueUrl,
+		AttributeNames: []sqstypes.QueueAttributeName{"ApproximateReceiveCount"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(dlqMessages.Messages))
+	assert.Equal(t, "poison", *dlqMessages.Messages[0].Body)
+	assert.Equal(t, "3", dlqMessages.Messages[0].Attributes["ApproximateReceiveCount"])
+}
@@ -90,14 +90,14 @@ func Test_CreateQueueV1_json_with_attributes(t *testing.T) {
 	sdkResponse, err := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
 		QueueName: &af.QueueName,
 		Attributes: map[string]string{
-			"DelaySeconds":           "1",
-			"MaximumMessageSize":     "2",
-			"MessageRetentionPeriod": "3",
-			//"Policy":                        "{\"this-is\": \"the-policy\"}",
+			"DelaySeconds":                  "1",
+			"MaximumMessageSize":            "2",
+			"MessageRetentionPeriod":        "3",
+			"Policy":                        `{"this-is": "the-policy"}`,
 			"ReceiveMessageWaitTimeSeconds": "4",
 			"VisibilityTimeout":             "5",
 			"RedrivePolicy":                 fmt.Sprintf(`{"maxReceiveCount":"100","deadLetterTargetArn":"%s:%s"}`, af.BASE_SQS_ARN, redriveQueue),
-			//"RedriveAllowPolicy":            "{\"this-is\": \"the-redrive-allow-policy\"}",
+			"RedriveAllowPolicy":            `{"redrivePermission":"denyAll"}`,
 		},
 	})
 
@@ -134,6 +134,12 @@ func Test_CreateQueueV1_json_with_attributes(t *testing.T) {
 	exp3.Result.Attrs[4].Value = "5"
 	exp3.Result.Attrs[9].Value = fmt.Sprintf("%s:%s", af.BASE_SQS_ARN, af.QueueName)
 	exp3.Result.Attrs = append(exp3.Result.Attrs, models.Attribute{
+		Name:  "Policy",
+		Value: `{"this-is": "the-policy"}`,
+	}, models.Attribute{
+		Name:  "RedriveAllowPolicy",
+		Value: `{"redrivePermission":"denyAll"}`,
+	}, models.Attribute{
 		Name:  "RedrivePolicy",
 		Value: fmt.Sprintf(`{"maxReceiveCount":"100", "deadLetterTargetArn":"%s:%s"}`, af.BASE_SQS_ARN, redriveQueue),
 	})
@@ -196,6 +202,13 @@ func Test_CreateQueueV1_json_with_attributes_as_ints(t *testing.T) {
 	exp3.Result.Attrs[3].Value = "4"
 	exp3.Result.Attrs[4].Value = "5"
 	exp3.Result.Attrs[9].Value = fmt.Sprintf("%s:%s", af.BASE_SQS_ARN, af.QueueName)
+	exp3.Result.Attrs = append(exp3.Result.Attrs, models.Attribute{
+		Name:  "Policy",
+		Value: `{"this-is":"the-policy"}`,
+	}, models.Attribute{
+		Name:  "RedriveAllowPolicy",
+		Value: `{"redrivePermission":"allowAll"}`,
+	})
 
 	r3 := models.GetQueueAttributesResponse{}
 	xml.Unmarshal([]byte(r), &r3)
@@ -407,7 +420,7 @@ func Test_CreateQueueV1_xml_with_attributes(t *testing.T) {
 		WithFormField("Attribute.7.Name", "RedrivePolicy").
 		WithFormField("Attribute.7.Value", fmt.Sprintf("{\"maxReceiveCount\": 100, \"deadLetterTargetArn\":\"%s:new-queue-1\"}", af.BASE_SQS_ARN)).
 		WithFormField("Attribute.8.Name", "RedriveAllowPolicy").
-		WithFormField("Attribute.8.Value", "{\"this-is\": \"the-redrive-allow-policy\"}").
+		WithFormField("Attribute.8.Value", "{\"redrivePermission\": \"allowAll\"}").
 		Expect().
 		Status(http.StatusOK).
 		Body().Raw()
@@ -446,6 +459,12 @@ func Test_CreateQueueV1_xml_with_attributes(t *testing.T) {
 	exp3.Result.Attrs[4].Value = "5"
 	exp3.Result.Attrs[9].Value = fmt.Sprintf("%s:new-queue-2", af.BASE_SQS_ARN)
 	exp3.Result.Attrs = append(exp3.Result.Attrs, models.Attribute{
+		Name:  "Policy",
+		Value: `{"this-is": "the-policy"}`,
+	}, models.Attribute{
+		Name:  "RedriveAllowPolicy",
+		Value: `{"redrivePermission":"allowAll"}`,
+	}, models.Attribute{
 		Name:  "RedrivePolicy",
 		Value: fmt.Sprintf(`{"maxReceiveCount":"100", "deadLetterTargetArn":"%s:%s"}`, af.BASE_SQS_ARN, af.QueueName),
 	})
@@ -0,0 +1,120 @@
+package smoke_tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/conf"
+	af "github.com/Admiral-Piett/goaws/app/fixtures"
+	"github.com/Admiral-Piett/goaws/app/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_EnforcePolicies_json_send_message_from_other_account(t *testing.T) {
+	server := generateServer()
+	defaultEnv := models.CurrentEnvironment
+	models.CurrentEnvironment.EnforcePolicies = true
+	models.CurrentEnvironment.AccessKeys = map[string]string{"AKIAOTHERACCOUNT": "arn:aws:iam::200020002000:user/other"}
+	defer func() {
+		server.Close()
+		models.ResetResources()
+		models.CurrentEnvironment = defaultEnv
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	otherConfig, _ := config.LoadDefaultConfig(context.TODO(),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider("AKIAOTHERACCOUNT", "secret", "")),
+	)
+	otherConfig.BaseEndpoint = aws.String(server.URL)
+	otherClient := sqs.NewFromConfig(otherConfig)
+
+	createResponse, err := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &af.QueueName,
+	})
+	assert.Nil(t, err)
+
+	_, err = otherClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+		QueueUrl:    createResponse.QueueUrl,
+		MessageBody: aws.String("denied"),
+	})
+	assert.Contains(t, err.Error(), "AccessDenied")
+
+	_, err = sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+		QueueUrl:    createResponse.QueueUrl,
+		MessageBody: aws.String("owner"),
+	})
+	assert.Nil(t, err)
+
+	_, err = sqsClient.AddPermission(context.TODO(), &sqs.AddPermissionInput{
+		QueueUrl:      createResponse.QueueUrl,
+		Label:         aws.String("other-account"),
+		AWSAccountIds: []string{"200020002000"},
+		Actions:       []string{"SendMessage"},
+	})
+	assert.Nil(t, err)
+
+	_, err = otherClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+		QueueUrl:    createResponse.QueueUrl,
+		MessageBody: aws.String("allowed"),
+	})
+	assert.Nil(t, err)
+
+	assert.Len(t, models.SyncQueues.Queues[af.QueueName].Messages, 2)
+}
+
+func Test_EnforcePolicies_json_topic_delivery_needs_queue_policy(t *testing.T) {
+	server := generateServer()
+	defaultEnv := models.CurrentEnvironment
+	conf.LoadYamlConfig("../app/conf/mock-data/mock-config.yaml", "BaseUnitTests")
+	models.CurrentEnvironment.EnforcePolicies = true
+	defer func() {
+		server.Close()
+		models.ResetResources()
+		models.CurrentEnvironment = defaultEnv
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	snsClient := sns.NewFromConfig(sdkConfig)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	topicArn := models.SyncTopics.Topics["unit-topic1"].Arn
+	queue := models.SyncQueues.Queues["subscribed-queue1"]
+
+	_, err := snsClient.Publish(context.TODO(), &sns.PublishInput{
+		TopicArn: &topicArn,
+		Message:  aws.String("denied"),
+	})
+	assert.Nil(t, err)
+
+	policy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"sns.amazonaws.com"},"Action":"sqs:SendMessage","Resource":"%s","Condition":{"ArnEquals":{"aws:SourceArn":"%s"}}}]}`, queue.Arn, topicArn)
+	_, err = sqsClient.SetQueueAttributes(context.TODO(), &sqs.SetQueueAttributesInput{
+		QueueUrl:   &queue.URL,
+		Attributes: map[string]string{"Policy": policy},
+	})
+	assert.Nil(t, err)
+
+	_, err = snsClient.Publish(context.TODO(), &sns.PublishInput{
+		TopicArn: &topicArn,
+		Message:  aws.String("allowed"),
+	})
+	assert.Nil(t, err)
+
+	receivedMessage, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+		QueueUrl:            &queue.URL,
+		MaxNumberOfMessages: 10,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, receivedMessage.Messages, 1)
+	assert.Equal(t, "allowed", *receivedMessage.Messages[0].Body)
+}
@@ -0,0 +1,153 @@
+package smoke_tests
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// createRedrivenQueues creates a dead-letter queue plus source queues that redrive into it after maxReceiveCount
+// receives, and returns the dead-letter queue's URL.
+func createRedrivenQueues(t *testing.T, sqsClient *sqs.Client, maxReceiveCount int, sourceQueueNames ...string) string {
+	dlqResponse, err := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: aws.String("dead-letter-queue"),
+	})
+	assert.Nil(t, err)
+	dlqAttributes, err := sqsClient.GetQueueAttributes(context.TODO(), &sqs.GetQueueAttributesInput{
+		QueueUrl:       dlqResponse.QueueUrl,
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameQueueArn},
+	})
+	assert.Nil(t, err)
+
+	redrivePolicy := fmt.Sprintf(`{"maxReceiveCount": %d, "deadLetterTargetArn": "%s"}`, maxReceiveCount, dlqAttributes.Attributes["QueueArn"])
+	for _, name := range sourceQueueNames {
+		_, err := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+			QueueName:  aws.String(name),
+			Attributes: map[string]string{"RedrivePolicy": redrivePolicy},
+		})
+		assert.Nil(t, err)
+	}
+	return *dlqResponse.QueueUrl
+}
+
+func Test_ListDeadLetterSourceQueues_json_paginates(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	dlqUrl := createRedrivenQueues(t, sqsClient, 3, "source-queue-1", "source-queue-2", "source-queue-3")
+	_, err := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: aws.String("unrelated-queue"),
+	})
+	assert.Nil(t, err)
+
+	paginator := sqs.NewListDeadLetterSourceQueuesPaginator(sqsClient, &sqs.ListDeadLetterSourceQueuesInput{
+		QueueUrl:   aws.String(dlqUrl),
+		MaxResults: aws.Int32(2),
+	})
+	pages := 0
+	queueUrls := []string{}
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, len(page.QueueUrls), 2)
+		queueUrls = append(queueUrls, page.QueueUrls...)
+		pages++
+	}
+
+	assert.Equal(t, 2, pages)
+	assert.Len(t, queueUrls, 3)
+	for i, queueUrl := range queueUrls {
+		assert.Contains(t, queueUrl, fmt.Sprintf("source-queue-%d", i+1))
+	}
+}
+
+func Test_ListDeadLetterSourceQueues_json_queue_not_found(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	_, err := sqsClient.ListDeadLetterSourceQueues(context.TODO(), &sqs.ListDeadLetterSourceQueuesInput{
+		QueueUrl: aws.String(fmt.Sprintf("%s/garbage", server.URL)),
+	})
+
+	assert.Contains(t, err.Error(), "AWS.SimpleQueueService.NonExistentQueue")
+}
+
+func Test_ListDeadLetterSourceQueues_xml(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	dlqUrl := createRedrivenQueues(t, sqsClient, 3, "source-queue-1", "source-queue-2")
+
+	e := httpexpect.Default(t, server.URL)
+
+	requestBody := struct {
+		Action     string `xml:"Action"`
+		QueueUrl   string `xml:"QueueUrl"`
+		MaxResults int    `xml:"MaxResults"`
+		Version    string `xml:"Version"`
+	}{
+		Action:     "ListDeadLetterSourceQueues",
+		QueueUrl:   dlqUrl,
+		MaxResults: 1,
+		Version:    "2012-11-05",
+	}
+
+	body := e.POST("/").
+		WithForm(requestBody).
+		Expect().
+		Status(http.StatusOK).
+		Body().Raw()
+
+	response := models.ListDeadLetterSourceQueuesResponse{}
+	xml.Unmarshal([]byte(body), &response)
+
+	assert.Len(t, response.Result.QueueUrls, 1)
+	assert.Contains(t, response.Result.QueueUrls[0], "source-queue-1")
+	assert.NotEmpty(t, response.Result.NextToken)
+
+	body = e.POST("/").
+		WithForm(requestBody).
+		WithFormField("NextToken", response.Result.NextToken).
+		Expect().
+		Status(http.StatusOK).
+		Body().Raw()
+
+	response = models.ListDeadLetterSourceQueuesResponse{}
+	xml.Unmarshal([]byte(body), &response)
+
+	assert.Len(t, response.Result.QueueUrls, 1)
+	assert.Contains(t, response.Result.QueueUrls[0], "source-queue-2")
+	assert.Empty(t, response.Result.NextToken)
+}
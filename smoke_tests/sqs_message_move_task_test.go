@@ -0,0 +1,179 @@
+package smoke_tests
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Admiral-Piett/goaws/app/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func queueArn(t *testing.T, sqsClient *sqs.Client, queueUrl string) string {
+	response, err := sqsClient.GetQueueAttributes(context.TODO(), &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(queueUrl),
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameQueueArn},
+	})
+	assert.Nil(t, err)
+	return response.Attributes["QueueArn"]
+}
+
+func Test_MessageMoveTask_json_redrives_dead_letter_queue(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	dlqUrl := createRedrivenQueues(t, sqsClient, 1, "source-queue")
+	sourceUrl, err := sqsClient.GetQueueUrl(context.TODO(), &sqs.GetQueueUrlInput{QueueName: aws.String("source-queue")})
+	assert.Nil(t, err)
+
+	// Fail the message once so it's dead-lettered
+	_, err = sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+		QueueUrl:    sourceUrl.QueueUrl,
+		MessageBody: aws.String("poison"),
+	})
+	assert.Nil(t, err)
+	received, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{QueueUrl: sourceUrl.QueueUrl})
+	assert.Nil(t, err)
+	_, err = sqsClient.ChangeMessageVisibility(context.TODO(), &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          sourceUrl.QueueUrl,
+		ReceiptHandle:     received.Messages[0].ReceiptHandle,
+		VisibilityTimeout: 0,
+	})
+	assert.Nil(t, err)
+
+	dlqArn := queueArn(t, sqsClient, dlqUrl)
+	startResponse, err := sqsClient.StartMessageMoveTask(context.TODO(), &sqs.StartMessageMoveTaskInput{
+		SourceArn:                    aws.String(dlqArn),
+		MaxNumberOfMessagesPerSecond: aws.Int32(100),
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, *startResponse.TaskHandle)
+
+	var task types.ListMessageMoveTasksResultEntry
+	assert.Eventually(t, func() bool {
+		listResponse, err := sqsClient.ListMessageMoveTasks(context.TODO(), &sqs.ListMessageMoveTasksInput{
+			SourceArn: aws.String(dlqArn),
+		})
+		if err != nil || len(listResponse.Results) != 1 {
+			return false
+		}
+		task = listResponse.Results[0]
+		return *task.Status == "COMPLETED"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(1), task.ApproximateNumberOfMessagesMoved)
+	assert.Equal(t, int32(100), *task.MaxNumberOfMessagesPerSecond)
+	assert.Nil(t, task.TaskHandle)
+
+	redriven, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{QueueUrl: sourceUrl.QueueUrl})
+	assert.Nil(t, err)
+	assert.Len(t, redriven.Messages, 1)
+	assert.Equal(t, "poison", *redriven.Messages[0].Body)
+
+	// The finished task can't be cancelled
+	_, err = sqsClient.CancelMessageMoveTask(context.TODO(), &sqs.CancelMessageMoveTaskInput{
+		TaskHandle: startResponse.TaskHandle,
+	})
+	assert.Contains(t, err.Error(), "AWS.SimpleQueueService.UnsupportedOperation")
+}
+
+func Test_MessageMoveTask_xml_cancel(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	dlqUrl := createRedrivenQueues(t, sqsClient, 1, "source-queue")
+	destinationUrl, err := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{QueueName: aws.String("destination-queue")})
+	assert.Nil(t, err)
+	for _, body := range []string{"one", "two", "three"} {
+		_, err = sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+			QueueUrl:    aws.String(dlqUrl),
+			MessageBody: aws.String(body),
+		})
+		assert.Nil(t, err)
+	}
+
+	e := httpexpect.Default(t, server.URL)
+
+	startRequest := struct {
+		Action                       string `xml:"Action"`
+		SourceArn                    string `xml:"SourceArn"`
+		DestinationArn               string `xml:"DestinationArn"`
+		MaxNumberOfMessagesPerSecond int    `xml:"MaxNumberOfMessagesPerSecond"`
+		Version                      string `xml:"Version"`
+	}{
+		Action:                       "StartMessageMoveTask",
+		SourceArn:                    queueArn(t, sqsClient, dlqUrl),
+		DestinationArn:               queueArn(t, sqsClient, *destinationUrl.QueueUrl),
+		MaxNumberOfMessagesPerSecond: 1,
+		Version:                      "2012-11-05",
+	}
+	body := e.POST("/").
+		WithForm(startRequest).
+		Expect().
+		Status(http.StatusOK).
+		Body().Raw()
+	startResponse := models.StartMessageMoveTaskResponse{}
+	xml.Unmarshal([]byte(body), &startResponse)
+	assert.NotEmpty(t, startResponse.Result.TaskHandle)
+
+	cancelRequest := struct {
+		Action     string `xml:"Action"`
+		TaskHandle string `xml:"TaskHandle"`
+		Version    string `xml:"Version"`
+	}{
+		Action:     "CancelMessageMoveTask",
+		TaskHandle: startResponse.Result.TaskHandle,
+		Version:    "2012-11-05",
+	}
+	e.POST("/").
+		WithForm(cancelRequest).
+		Expect().
+		Status(http.StatusOK)
+
+	listRequest := struct {
+		Action    string `xml:"Action"`
+		SourceArn string `xml:"SourceArn"`
+		Version   string `xml:"Version"`
+	}{
+		Action:    "ListMessageMoveTasks",
+		SourceArn: startRequest.SourceArn,
+		Version:   "2012-11-05",
+	}
+	listResponse := models.ListMessageMoveTasksResponse{}
+	assert.Eventually(t, func() bool {
+		body := e.POST("/").
+			WithForm(listRequest).
+			Expect().
+			Status(http.StatusOK).
+			Body().Raw()
+		listResponse = models.ListMessageMoveTasksResponse{}
+		xml.Unmarshal([]byte(body), &listResponse)
+		return len(listResponse.Result.Results) == 1 && listResponse.Result.Results[0].Status == "CANCELLED"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	task := listResponse.Result.Results[0]
+	assert.Equal(t, startRequest.DestinationArn, task.DestinationArn)
+	assert.Equal(t, int64(3), task.ApproximateNumberOfMessagesToMove)
+	assert.Less(t, task.ApproximateNumberOfMessagesMoved, int64(3))
+}
@@ -0,0 +1,165 @@
+package smoke_tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	af "github.com/Admiral-Piett/goaws/app/fixtures"
+	"github.com/Admiral-Piett/goaws/app/models"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Permission_json_add_and_remove(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	createResponse, err := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &af.QueueName,
+	})
+	assert.Nil(t, err)
+
+	_, err = sqsClient.AddPermission(context.TODO(), &sqs.AddPermissionInput{
+		QueueUrl:      createResponse.QueueUrl,
+		Label:         aws.String("send"),
+		AWSAccountIds: []string{"111122223333"},
+		Actions:       []string{"SendMessage"},
+	})
+	assert.Nil(t, err)
+
+	_, err = sqsClient.AddPermission(context.TODO(), &sqs.AddPermissionInput{
+		QueueUrl:      createResponse.QueueUrl,
+		Label:         aws.String("send"),
+		AWSAccountIds: []string{"111122223333"},
+		Actions:       []string{"SendMessage"},
+	})
+	assert.Contains(t, err.Error(), "AWS.SimpleQueueService.InvalidParameterValue")
+
+	attributesResponse, err := sqsClient.GetQueueAttributes(context.TODO(), &sqs.GetQueueAttributesInput{
+		QueueUrl:       createResponse.QueueUrl,
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNamePolicy},
+	})
+	assert.Nil(t, err)
+
+	queueArn := fmt.Sprintf("%s:%s", af.BASE_SQS_ARN, af.QueueName)
+	policy := map[string]interface{}{}
+	json.Unmarshal([]byte(attributesResponse.Attributes["Policy"]), &policy)
+	expectedPolicy := map[string]interface{}{
+		"Version": "2012-10-17",
+		"Id":      fmt.Sprintf("%s/SQSDefaultPolicy", queueArn),
+		"Statement": []interface{}{
+			map[string]interface{}{
+				"Sid":       "send",
+				"Effect":    "Allow",
+				"Principal": map[string]interface{}{"AWS": "arn:aws:iam::111122223333:root"},
+				"Action":    "SQS:SendMessage",
+				"Resource":  queueArn,
+			},
+		},
+	}
+	assert.Equal(t, expectedPolicy, policy)
+
+	_, err = sqsClient.RemovePermission(context.TODO(), &sqs.RemovePermissionInput{
+		QueueUrl: createResponse.QueueUrl,
+		Label:    aws.String("send"),
+	})
+	assert.Nil(t, err)
+
+	attributesResponse, err = sqsClient.GetQueueAttributes(context.TODO(), &sqs.GetQueueAttributesInput{
+		QueueUrl:       createResponse.QueueUrl,
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNamePolicy},
+	})
+	assert.Nil(t, err)
+	assert.Empty(t, attributesResponse.Attributes)
+
+	_, err = sqsClient.RemovePermission(context.TODO(), &sqs.RemovePermissionInput{
+		QueueUrl: createResponse.QueueUrl,
+		Label:    aws.String("send"),
+	})
+	assert.Contains(t, err.Error(), "AWS.SimpleQueueService.InvalidParameterValue")
+}
+
+func Test_Permission_xml_add_and_remove(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &af.QueueName,
+	})
+
+	e := httpexpect.Default(t, server.URL)
+
+	addRequest := struct {
+		Action   string `xml:"Action"`
+		QueueUrl string `xml:"QueueUrl"`
+		Label    string `xml:"Label"`
+		Version  string `xml:"Version"`
+	}{
+		Action:   "AddPermission",
+		QueueUrl: af.QueueUrl,
+		Label:    "receive",
+		Version:  "2012-11-05",
+	}
+	e.POST("/").
+		WithForm(addRequest).
+		WithFormField("AWSAccountId.1", "111122223333").
+		WithFormField("AWSAccountId.2", "444455556666").
+		WithFormField("ActionName.1", "ReceiveMessage").
+		WithFormField("ActionName.2", "DeleteMessage").
+		Expect().
+		Status(http.StatusOK)
+
+	queueArn := fmt.Sprintf("%s:%s", af.BASE_SQS_ARN, af.QueueName)
+	expectedPolicy := fmt.Sprintf(`{"Version":"2012-10-17","Id":"%s/SQSDefaultPolicy","Statement":[`+
+		`{"Sid":"receive","Effect":"Allow","Principal":{"AWS":["arn:aws:iam::111122223333:root","arn:aws:iam::444455556666:root"]},"Action":["SQS:ReceiveMessage","SQS:DeleteMessage"],"Resource":"%s"}]}`,
+		queueArn, queueArn)
+	attributesResponse, err := sqsClient.GetQueueAttributes(context.TODO(), &sqs.GetQueueAttributesInput{
+		QueueUrl:       &af.QueueUrl,
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNamePolicy},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, expectedPolicy, attributesResponse.Attributes["Policy"])
+
+	removeRequest := struct {
+		Action   string `xml:"Action"`
+		QueueUrl string `xml:"QueueUrl"`
+		Label    string `xml:"Label"`
+		Version  string `xml:"Version"`
+	}{
+		Action:   "RemovePermission",
+		QueueUrl: af.QueueUrl,
+		Label:    "receive",
+		Version:  "2012-11-05",
+	}
+	e.POST("/").
+		WithForm(removeRequest).
+		Expect().
+		Status(http.StatusOK)
+
+	e.POST("/").
+		WithForm(removeRequest).
+		Expect().
+		Status(http.StatusBadRequest)
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/xml"
 	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
 
@@ -44,7 +46,9 @@ func Test_ReceiveMessageV1_json(t *testing.T) {
 	assert.Nil(t, err)
 
 	receiveMessageResponse, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl: createQueueResponse.QueueUrl,
+		QueueUrl:              createQueueResponse.QueueUrl,
+		AttributeNames:        []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameAll},
+		MessageAttributeNames: []string{"All"},
 	})
 
 	assert.Nil(t, err)
@@ -100,7 +104,9 @@ func Test_ReceiveMessageV1_json_with_attributes(t *testing.T) {
 	assert.Nil(t, err)
 
 	receiveMessageResponse, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl: createQueueResponse.QueueUrl,
+		QueueUrl:              createQueueResponse.QueueUrl,
+		AttributeNames:        []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameAll},
+		MessageAttributeNames: []string{"All"},
 	})
 
 	assert.Nil(t, err)
@@ -188,6 +194,8 @@ func Test_ReceiveMessageV1_xml(t *testing.T) {
 
 	response := e.POST("/queue/new-queue-1").
 		WithForm(sf.ReceiveMessageRequestBodyXML).
+		WithFormField("AttributeName.1", "All").
+		WithFormField("MessageAttributeName.1", "All").
 		Expect().
 		Status(http.StatusOK).
 		Body().Raw()
@@ -257,6 +265,8 @@ func Test_ReceiveMessageV1_xml_with_attributes(t *testing.T) {
 
 	response := e.POST("/queue/new-queue-1").
 		WithForm(sf.ReceiveMessageRequestBodyXML).
+		WithFormField("AttributeName.1", "All").
+		WithFormField("MessageAttributeName.1", "All").
 		Expect().
 		Status(http.StatusOK).
 		Body().Raw()
@@ -291,3 +301,136 @@ func Test_ReceiveMessageV1_xml_with_attributes(t *testing.T) {
 	entry = "<MessageAttribute><Name>attr3</Name><Value><BinaryValue>YmluYXJ5LXZhbHVl</BinaryValue><DataType>Binary</DataType></Value></MessageAttribute>"
 	assert.Contains(t, response, entry)
 }
+
+func Test_ReceiveMessageV1_json_fifo_retried_receive_request_attempt_id(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	createQueueResponse, _ := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: aws.String("new-queue-1.fifo"),
+		Attributes: map[string]string{
+			"FifoQueue":                 "true",
+			"ContentBasedDeduplication": "true",
+		},
+	})
+	for _, groupId := range []string{"group-1", "group-2"} {
+		_, err := sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+			QueueUrl:       createQueueResponse.QueueUrl,
+			MessageBody:    aws.String(groupId),
+			MessageGroupId: aws.String(groupId),
+		})
+		assert.Nil(t, err)
+	}
+
+	receiveMessageResponse, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+		QueueUrl:                createQueueResponse.QueueUrl,
+		ReceiveRequestAttemptId: aws.String("attempt-1"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(receiveMessageResponse.Messages))
+	assert.Equal(t, "group-1", *receiveMessageResponse.Messages[0].Body)
+
+	// The response was "lost", so the retry gets the same message and receipt handle - not the next group
+	retriedResponse, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+		QueueUrl:                createQueueResponse.QueueUrl,
+		ReceiveRequestAttemptId: aws.String("attempt-1"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(retriedResponse.Messages))
+	assert.Equal(t, "group-1", *retriedResponse.Messages[0].Body)
+	assert.Equal(t, *receiveMessageResponse.Messages[0].ReceiptHandle, *retriedResponse.Messages[0].ReceiptHandle)
+
+	// A new attempt receives the next group
+	nextResponse, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+		QueueUrl:                createQueueResponse.QueueUrl,
+		ReceiveRequestAttemptId: aws.String("attempt-2"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(nextResponse.Messages))
+	assert.Equal(t, "group-2", *nextResponse.Messages[0].Body)
+}
+
+func Test_ReceiveMessageV1_json_fifo_message_group_attributes(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	createQueueResponse, _ := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName:  aws.String("new-queue-1.fifo"),
+		Attributes: map[string]string{"FifoQueue": "true"},
+	})
+	sendMessageResponse, err := sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+		QueueUrl:               createQueueResponse.QueueUrl,
+		MessageBody:            aws.String("MyTestMessage"),
+		MessageGroupId:         aws.String("group-1"),
+		MessageDeduplicationId: aws.String("dedup-1"),
+	})
+	assert.Nil(t, err)
+
+	receiveMessageResponse, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+		QueueUrl:       createQueueResponse.QueueUrl,
+		AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameAll},
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, len(receiveMessageResponse.Messages))
+	attributes := receiveMessageResponse.Messages[0].Attributes
+	assert.Equal(t, "group-1", attributes["MessageGroupId"])
+	assert.Equal(t, "dedup-1", attributes["MessageDeduplicationId"])
+	assert.Equal(t, *sendMessageResponse.SequenceNumber, attributes["SequenceNumber"])
+}
+
+func Test_ReceiveMessageV1_json_selected_attributes(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	createQueueResponse, _ := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &af.QueueName,
+	})
+	before := time.Now().UnixMilli()
+	_, err := sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+		QueueUrl:    createQueueResponse.QueueUrl,
+		MessageBody: aws.String("MyTestMessage"),
+		MessageAttributes: map[string]sqstypes.MessageAttributeValue{
+			"attr1":        {DataType: aws.String("String"), StringValue: aws.String("value1")},
+			"prefix.attr2": {DataType: aws.String("String"), StringValue: aws.String("value2")},
+		},
+	})
+	assert.Nil(t, err)
+
+	receiveMessageResponse, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+		QueueUrl:              createQueueResponse.QueueUrl,
+		AttributeNames:        []sqstypes.QueueAttributeName{"SentTimestamp"},
+		MessageAttributeNames: []string{"prefix.*"},
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, len(receiveMessageResponse.Messages))
+	message := receiveMessageResponse.Messages[0]
+	assert.Len(t, message.Attributes, 1)
+	sentTimestamp, _ := strconv.ParseInt(message.Attributes["SentTimestamp"], 10, 64)
+	assert.GreaterOrEqual(t, sentTimestamp, before)
+	assert.Len(t, message.MessageAttributes, 1)
+	assert.Equal(t, "value2", *message.MessageAttributes["prefix.attr2"].StringValue)
+	assert.NotNil(t, message.MD5OfMessageAttributes)
+}
@@ -290,8 +290,9 @@ func TestSendMessageBatchV1_Json_Success_including_attributes(t *testing.T) {
 	assert.Equal(t, "2", getQueueAttributeOutput.Attributes["ApproximateNumberOfMessages"])
 
 	receiveMessageOutput, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl:            &queueUrl,
-		MaxNumberOfMessages: 10,
+		QueueUrl:              &queueUrl,
+		MessageAttributeNames: []string{"All"},
+		MaxNumberOfMessages:   10,
 	})
 
 	assert.Len(t, receiveMessageOutput.Messages, 2)
@@ -396,8 +397,9 @@ func TestSendMessageBatchV1_Xml_Success_including_attributes(t *testing.T) {
 	assert.Equal(t, "2", getQueueAttributeOutput.Attributes["ApproximateNumberOfMessages"])
 
 	receiveMessageOutput, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl:            &af.QueueUrl,
-		MaxNumberOfMessages: 10,
+		QueueUrl:              &af.QueueUrl,
+		MessageAttributeNames: []string{"All"},
+		MaxNumberOfMessages:   10,
 	})
 
 	assert.Len(t, receiveMessageOutput.Messages, 2)
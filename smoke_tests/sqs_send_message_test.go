@@ -116,7 +116,8 @@ func Test_SendMessageV1_json_with_attributes(t *testing.T) {
 
 	// Receive message and check attribute
 	receivedMessages, _ := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl: targetQueueUrl,
+		QueueUrl:              targetQueueUrl,
+		MessageAttributeNames: []string{"All"},
 	})
 
 	assert.Len(t, receivedMessages.Messages, 1)
@@ -294,8 +295,9 @@ func Test_SendMessageV1_xml_with_attributes(t *testing.T) {
 	time.Sleep(1 * time.Second)
 
 	receivedMessages, _ := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl:            &af.QueueUrl,
-		MaxNumberOfMessages: 10,
+		QueueUrl:              &af.QueueUrl,
+		MessageAttributeNames: []string{"All"},
+		MaxNumberOfMessages:   10,
 	})
 
 	assert.Len(t, receivedMessages.Messages, 1)
@@ -312,3 +314,184 @@ func Test_SendMessageV1_xml_with_attributes(t *testing.T) {
 	assert.Equal(t, "Binary", *receivedMessages.Messages[0].MessageAttributes["attr3"].DataType)
 	assert.Equal(t, []uint8("attr3_value"), receivedMessages.Messages[0].MessageAttributes["attr3"].BinaryValue)
 }
+
+func Test_SendMessageV1_json_fifo_content_based_deduplication(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+	sdkResponse, err := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: aws.String("new-queue-1.fifo"),
+		Attributes: map[string]string{
+			"FifoQueue":                 "true",
+			"ContentBasedDeduplication": "true",
+		},
+	})
+	assert.Nil(t, err)
+	targetQueueUrl := sdkResponse.QueueUrl
+
+	getQueueAttributeOutput, _ := sqsClient.GetQueueAttributes(context.TODO(), &sqs.GetQueueAttributesInput{
+		QueueUrl:       targetQueueUrl,
+		AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameContentBasedDeduplication},
+	})
+	assert.Equal(t, map[string]string{"ContentBasedDeduplication": "true"}, getQueueAttributeOutput.Attributes)
+
+	for i := 0; i < 2; i++ {
+		_, err = sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+			QueueUrl:       targetQueueUrl,
+			MessageBody:    aws.String("same body"),
+			MessageGroupId: aws.String("group"),
+		})
+		assert.Nil(t, err)
+	}
+	assert.Len(t, models.SyncQueues.Queues["new-queue-1.fifo"].Messages, 1)
+
+	_, err = sqsClient.SetQueueAttributes(context.TODO(), &sqs.SetQueueAttributesInput{
+		QueueUrl:   targetQueueUrl,
+		Attributes: map[string]string{"ContentBasedDeduplication": "false"},
+	})
+	assert.Nil(t, err)
+
+	_, err = sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+		QueueUrl:       targetQueueUrl,
+		MessageBody:    aws.String("no deduplication id"),
+		MessageGroupId: aws.String("group"),
+	})
+	assert.Contains(t, err.Error(), "ContentBasedDeduplication")
+}
+
+func Test_SendMessageV1_xml_content_based_deduplication_on_standard_queue(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/").
+		WithForm(struct {
+			Action    string `xml:"Action"`
+			Version   string `xml:"Version"`
+			QueueName string `xml:"QueueName"`
+		}{
+			Action:    "CreateQueue",
+			Version:   "2012-11-05",
+			QueueName: af.QueueName,
+		}).
+		WithFormField("Attribute.1.Name", "ContentBasedDeduplication").
+		WithFormField("Attribute.1.Value", "true").
+		Expect().
+		Status(http.StatusBadRequest).
+		Body().Contains("InvalidAttributeName")
+
+	_, ok := models.SyncQueues.Queues[af.QueueName]
+	assert.False(t, ok)
+}
+
+func Test_SendMessageV1_json_fifo_deduplication_scope_message_group(t *testing.T) {
+	server := generateServer()
+	defaultEnv := models.CurrentEnvironment
+	models.CurrentEnvironment.EnableDuplicates = true
+	defer func() {
+		server.Close()
+		models.ResetResources()
+		models.CurrentEnvironment = defaultEnv
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+	sdkResponse, err := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: aws.String("new-queue-1.fifo"),
+		Attributes: map[string]string{
+			"FifoQueue":           "true",
+			"DeduplicationScope":  "messageGroup",
+			"FifoThroughputLimit": "perMessageGroupId",
+		},
+	})
+	assert.Nil(t, err)
+	targetQueueUrl := sdkResponse.QueueUrl
+
+	getQueueAttributeOutput, _ := sqsClient.GetQueueAttributes(context.TODO(), &sqs.GetQueueAttributesInput{
+		QueueUrl: targetQueueUrl,
+		AttributeNames: []sqstypes.QueueAttributeName{
+			sqstypes.QueueAttributeNameDeduplicationScope,
+			sqstypes.QueueAttributeNameFifoThroughputLimit,
+		},
+	})
+	assert.Equal(t, map[string]string{
+		"DeduplicationScope":  "messageGroup",
+		"FifoThroughputLimit": "perMessageGroupId",
+	}, getQueueAttributeOutput.Attributes)
+
+	for _, groupId := range []string{"group-1", "group-2", "group-1"} {
+		_, err = sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+			QueueUrl:               targetQueueUrl,
+			MessageBody:            aws.String("same body"),
+			MessageGroupId:         aws.String(groupId),
+			MessageDeduplicationId: aws.String("same-id"),
+		})
+		assert.Nil(t, err)
+	}
+	assert.Len(t, models.SyncQueues.Queues["new-queue-1.fifo"].Messages, 2)
+
+	_, err = sqsClient.SetQueueAttributes(context.TODO(), &sqs.SetQueueAttributesInput{
+		QueueUrl:   targetQueueUrl,
+		Attributes: map[string]string{"DeduplicationScope": "queue"},
+	})
+	assert.Contains(t, err.Error(), "InvalidAttributeValue")
+}
+
+func Test_SendMessageV1_json_aws_trace_header(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+	sdkResponse, _ := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &af.QueueName,
+	})
+	targetQueueUrl := sdkResponse.QueueUrl
+
+	traceHeader := "Root=1-5759e988-bd862e3fe1be46a994272793;Sampled=1"
+	_, err := sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+		QueueUrl:    targetQueueUrl,
+		MessageBody: aws.String("Test_SendMessageV1_json_aws_trace_header"),
+		MessageSystemAttributes: map[string]sqstypes.MessageSystemAttributeValue{
+			"AWSTraceHeader": {
+				DataType:    aws.String("String"),
+				StringValue: aws.String(traceHeader),
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	receiveMessageOutput, err := sqsClient.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+		QueueUrl:       targetQueueUrl,
+		AttributeNames: []sqstypes.QueueAttributeName{"AWSTraceHeader"},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, receiveMessageOutput.Messages, 1)
+	assert.Equal(t, map[string]string{"AWSTraceHeader": traceHeader}, receiveMessageOutput.Messages[0].Attributes)
+
+	_, err = sqsClient.SendMessage(context.TODO(), &sqs.SendMessageInput{
+		QueueUrl:    targetQueueUrl,
+		MessageBody: aws.String("Test_SendMessageV1_json_aws_trace_header"),
+		MessageSystemAttributes: map[string]sqstypes.MessageSystemAttributeValue{
+			"AWSTraceHeader": {
+				DataType:    aws.String("Number"),
+				StringValue: aws.String("1"),
+			},
+		},
+	})
+	assert.Contains(t, err.Error(), "The only supported message system attribute is AWSTraceHeader, of type String.")
+}
@@ -43,14 +43,14 @@ func Test_SetQueueAttributes_json_multiple(t *testing.T) {
 		QueueName: &queueName,
 	})
 	attributes := map[string]string{
-		"DelaySeconds":           "1",
-		"MaximumMessageSize":     "2",
-		"MessageRetentionPeriod": "3",
-		//"Policy":                        "{\"this-is\": \"the-policy\"}",
+		"DelaySeconds":                  "1",
+		"MaximumMessageSize":            "2",
+		"MessageRetentionPeriod":        "3",
+		"Policy":                        `{"this-is": "the-policy"}`,
 		"ReceiveMessageWaitTimeSeconds": "4",
 		"VisibilityTimeout":             "5",
 		"RedrivePolicy":                 fmt.Sprintf(`{"maxReceiveCount":"100","deadLetterTargetArn":"%s:%s"}`, af.BASE_SQS_ARN, redriveQueue),
-		//"RedriveAllowPolicy":            "{\"this-is\": \"the-redrive-allow-policy\"}",
+		"RedriveAllowPolicy":            `{"redrivePermission":"denyAll"}`,
 	}
 
 	queueUrl := fmt.Sprintf("%s/%s", af.BASE_URL, queueName)
@@ -163,7 +163,7 @@ func Test_SetQueueAttributes_xml_all(t *testing.T) {
 		WithFormField("Attribute.7.Name", "RedrivePolicy").
 		WithFormField("Attribute.7.Value", fmt.Sprintf("{\"maxReceiveCount\": 100, \"deadLetterTargetArn\":\"%s:%s\"}", af.BASE_SQS_ARN, redriveQueue)).
 		WithFormField("Attribute.8.Name", "RedriveAllowPolicy").
-		WithFormField("Attribute.8.Value", "{\"this-is\": \"the-redrive-allow-policy\"}").
+		WithFormField("Attribute.8.Value", "{\"redrivePermission\": \"denyAll\"}").
 		Expect().
 		Status(http.StatusOK).
 		Body().Raw()
@@ -174,14 +174,14 @@ func Test_SetQueueAttributes_xml_all(t *testing.T) {
 	})
 
 	expectedAttributes := map[string]string{
-		"DelaySeconds":           "1",
-		"MaximumMessageSize":     "2",
-		"MessageRetentionPeriod": "3",
-		//"Policy":                        "{\"this-is\": \"the-policy\"}",
-		"ReceiveMessageWaitTimeSeconds": "4",
-		"VisibilityTimeout":             "5",
-		"RedrivePolicy":                 fmt.Sprintf(`{"maxReceiveCount":"100", "deadLetterTargetArn":"%s:%s"}`, af.BASE_SQS_ARN, redriveQueue),
-		//"RedriveAllowPolicy":            "{\"this-is\": \"the-redrive-allow-policy\"}",
+		"DelaySeconds":                          "1",
+		"MaximumMessageSize":                    "2",
+		"MessageRetentionPeriod":                "3",
+		"Policy":                                `{"this-is": "the-policy"}`,
+		"ReceiveMessageWaitTimeSeconds":         "4",
+		"VisibilityTimeout":                     "5",
+		"RedrivePolicy":                         fmt.Sprintf(`{"maxReceiveCount":"100", "deadLetterTargetArn":"%s:%s"}`, af.BASE_SQS_ARN, redriveQueue),
+		"RedriveAllowPolicy":                    `{"redrivePermission":"denyAll"}`,
 		"ApproximateNumberOfMessages":           "0",
 		"ApproximateNumberOfMessagesNotVisible": "0",
 		"CreatedTimestamp":                      "0000000000",
@@ -241,3 +241,118 @@ func Test_SetQueueAttributes_xml_single(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expectedAttributes, sdkResponse.Attributes)
 }
+
+func Test_SetQueueAttributes_json_redrive_allow_policy_rejects_source_queue(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	redriveQueue := "redrive-queue"
+	sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &redriveQueue,
+	})
+	sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &af.QueueName,
+	})
+	redriveQueueUrl := fmt.Sprintf("%s/%s", af.BASE_URL, redriveQueue)
+	redrivePolicy := map[string]string{
+		"RedrivePolicy": fmt.Sprintf(`{"maxReceiveCount":"100","deadLetterTargetArn":"%s:%s"}`, af.BASE_SQS_ARN, redriveQueue),
+	}
+
+	for _, allowPolicy := range []string{
+		`{"redrivePermission":"denyAll"}`,
+		fmt.Sprintf(`{"redrivePermission":"byQueue","sourceQueueArns":["%s:other-queue"]}`, af.BASE_SQS_ARN),
+	} {
+		_, err := sqsClient.SetQueueAttributes(context.TODO(), &sqs.SetQueueAttributesInput{
+			QueueUrl:   &redriveQueueUrl,
+			Attributes: map[string]string{"RedriveAllowPolicy": allowPolicy},
+		})
+		assert.Nil(t, err)
+
+		_, err = sqsClient.SetQueueAttributes(context.TODO(), &sqs.SetQueueAttributesInput{
+			QueueUrl:   &af.QueueUrl,
+			Attributes: redrivePolicy,
+		})
+		assert.Contains(t, err.Error(), "AWS.SimpleQueueService.InvalidParameterValue")
+	}
+
+	allowPolicy := fmt.Sprintf(`{"redrivePermission":"byQueue","sourceQueueArns":["%s:%s"]}`, af.BASE_SQS_ARN, af.QueueName)
+	_, err := sqsClient.SetQueueAttributes(context.TODO(), &sqs.SetQueueAttributesInput{
+		QueueUrl:   &redriveQueueUrl,
+		Attributes: map[string]string{"RedriveAllowPolicy": allowPolicy},
+	})
+	assert.Nil(t, err)
+
+	_, err = sqsClient.SetQueueAttributes(context.TODO(), &sqs.SetQueueAttributesInput{
+		QueueUrl:   &af.QueueUrl,
+		Attributes: redrivePolicy,
+	})
+	assert.Nil(t, err)
+
+	sdkResponse, err := sqsClient.GetQueueAttributes(context.TODO(), &sqs.GetQueueAttributesInput{
+		QueueUrl:       &redriveQueueUrl,
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameRedriveAllowPolicy},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"RedriveAllowPolicy": allowPolicy}, sdkResponse.Attributes)
+
+	// An invalid policy is rejected outright
+	_, err = sqsClient.SetQueueAttributes(context.TODO(), &sqs.SetQueueAttributesInput{
+		QueueUrl:   &redriveQueueUrl,
+		Attributes: map[string]string{"RedriveAllowPolicy": `{"redrivePermission":"byQueue"}`},
+	})
+	assert.Contains(t, err.Error(), "AWS.SimpleQueueService.InvalidAttributeValue")
+}
+
+func Test_SetQueueAttributes_json_empty_policy_removes_policy(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &af.QueueName,
+		Attributes: map[string]string{
+			"Policy": `{"Version":"2012-10-17","Statement":[]}`,
+		},
+	})
+
+	// Setting other attributes leaves the policy alone
+	_, err := sqsClient.SetQueueAttributes(context.TODO(), &sqs.SetQueueAttributesInput{
+		QueueUrl:   &af.QueueUrl,
+		Attributes: map[string]string{"VisibilityTimeout": "5"},
+	})
+	assert.Nil(t, err)
+
+	sdkResponse, err := sqsClient.GetQueueAttributes(context.TODO(), &sqs.GetQueueAttributesInput{
+		QueueUrl:       &af.QueueUrl,
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNamePolicy},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"Policy": `{"Version":"2012-10-17","Statement":[]}`}, sdkResponse.Attributes)
+
+	// An empty policy removes it
+	_, err = sqsClient.SetQueueAttributes(context.TODO(), &sqs.SetQueueAttributesInput{
+		QueueUrl:   &af.QueueUrl,
+		Attributes: map[string]string{"Policy": ""},
+	})
+	assert.Nil(t, err)
+
+	sdkResponse, err = sqsClient.GetQueueAttributes(context.TODO(), &sqs.GetQueueAttributesInput{
+		QueueUrl:       &af.QueueUrl,
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNamePolicy},
+	})
+	assert.Nil(t, err)
+	assert.Empty(t, sdkResponse.Attributes)
+}
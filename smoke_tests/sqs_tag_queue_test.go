@@ -0,0 +1,140 @@
+package smoke_tests
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"testing"
+
+	"github.com/Admiral-Piett/goaws/app/models"
+	"github.com/gavv/httpexpect/v2"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+
+	"github.com/stretchr/testify/assert"
+
+	af "github.com/Admiral-Piett/goaws/app/fixtures"
+)
+
+func Test_QueueTags_json(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	createQueueResponse, _ := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &af.QueueName,
+		Tags:      map[string]string{"team": "platform"},
+	})
+
+	_, err := sqsClient.TagQueue(context.TODO(), &sqs.TagQueueInput{
+		QueueUrl: createQueueResponse.QueueUrl,
+		Tags:     map[string]string{"env": "local", "owner": "me"},
+	})
+	assert.Nil(t, err)
+
+	_, err = sqsClient.UntagQueue(context.TODO(), &sqs.UntagQueueInput{
+		QueueUrl: createQueueResponse.QueueUrl,
+		TagKeys:  []string{"owner"},
+	})
+	assert.Nil(t, err)
+
+	sdkResponse, err := sqsClient.ListQueueTags(context.TODO(), &sqs.ListQueueTagsInput{
+		QueueUrl: createQueueResponse.QueueUrl,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"team": "platform", "env": "local"}, sdkResponse.Tags)
+}
+
+func Test_QueueTags_json_queue_not_found(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	queueUrl := af.QueueUrl
+	_, err := sqsClient.ListQueueTags(context.TODO(), &sqs.ListQueueTagsInput{
+		QueueUrl: &queueUrl,
+	})
+	assert.Contains(t, err.Error(), "AWS.SimpleQueueService.NonExistentQueue")
+}
+
+func Test_QueueTags_xml(t *testing.T) {
+	server := generateServer()
+	defer func() {
+		server.Close()
+		models.ResetResources()
+	}()
+
+	e := httpexpect.Default(t, server.URL)
+
+	sdkConfig, _ := config.LoadDefaultConfig(context.TODO())
+	sdkConfig.BaseEndpoint = aws.String(server.URL)
+	sqsClient := sqs.NewFromConfig(sdkConfig)
+
+	createQueueResponse, _ := sqsClient.CreateQueue(context.TODO(), &sqs.CreateQueueInput{
+		QueueName: &af.QueueName,
+	})
+
+	e.POST("/").
+		WithForm(struct {
+			Action   string `xml:"Action"`
+			QueueUrl string `xml:"QueueUrl"`
+		}{
+			Action:   "TagQueue",
+			QueueUrl: *createQueueResponse.QueueUrl,
+		}).
+		WithFormField("Tag.1.Key", "team").
+		WithFormField("Tag.1.Value", "platform").
+		WithFormField("Tag.2.Key", "env").
+		WithFormField("Tag.2.Value", "local").
+		Expect().
+		Status(http.StatusOK)
+
+	e.POST("/").
+		WithForm(struct {
+			Action   string `xml:"Action"`
+			QueueUrl string `xml:"QueueUrl"`
+		}{
+			Action:   "UntagQueue",
+			QueueUrl: *createQueueResponse.QueueUrl,
+		}).
+		WithFormField("TagKey.1", "env").
+		Expect().
+		Status(http.StatusOK)
+
+	r := e.POST("/").
+		WithForm(struct {
+			Action   string `xml:"Action"`
+			QueueUrl string `xml:"QueueUrl"`
+		}{
+			Action:   "ListQueueTags",
+			QueueUrl: *createQueueResponse.QueueUrl,
+		}).
+		Expect().
+		Status(http.StatusOK).
+		Body().Raw()
+
+	expected := models.ListQueueTagsResponse{
+		Xmlns: models.BaseXmlns,
+		Result: models.ListQueueTagsResult{Tags: []models.QueueTag{
+			{Key: "team", Value: "platform"},
+		}},
+		Metadata: models.BaseResponseMetadata,
+	}
+	response := models.ListQueueTagsResponse{}
+	xml.Unmarshal([]byte(r), &response)
+	assert.Equal(t, expected, response)
+}
@@ -3,10 +3,15 @@ package smoke_tests
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	urlLib "net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -18,6 +23,24 @@ func generateServer() *httptest.Server {
 	return httptest.NewServer(router.New())
 }
 
+// waitForDeliveries waits for goaws to have made count attempts to deliver to an HTTP/S subscription, since
+// those deliveries happen in the background after the publish returns.
+func waitForDeliveries(t *testing.T, server *httptest.Server, subscriptionArn string, count int) {
+	url := fmt.Sprintf("%s/SimpleNotificationService/DeliveryLog?SubscriptionArn=%s", server.URL, urlLib.QueryEscape(subscriptionArn))
+	assert.Eventually(t, func() bool {
+		res, err := http.Get(url)
+		if err != nil {
+			return false
+		}
+		defer res.Body.Close()
+		attempts := []map[string]interface{}{}
+		if json.NewDecoder(res.Body).Decode(&attempts) != nil {
+			return false
+		}
+		return len(attempts) >= count
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 // GenerateLocalProxyConfig use this to create AWS config that can be plugged into your sqs client, and
 // force calls onto a local proxy.  This is helpful for testing directly with an HTTP inspection tool
 // such as Charles or Proxyman.